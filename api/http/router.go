@@ -2,7 +2,13 @@ package http
 
 import (
 	"project-evermos/internal/config"
+	"project-evermos/internal/notify"
+	"project-evermos/internal/storage"
 	categoryHandler "project-evermos/internal/todo/handler/category"
+	filesHandler "project-evermos/internal/todo/handler/files"
+	flashSaleHandler "project-evermos/internal/todo/handler/flashsale"
+	jobHandler "project-evermos/internal/todo/handler/job"
+	notificationHandler "project-evermos/internal/todo/handler/notification"
 	authHandler "project-evermos/internal/todo/handler/auth"
 	productHandler "project-evermos/internal/todo/handler/product"
 	tokoHandler "project-evermos/internal/todo/handler/toko"
@@ -10,12 +16,18 @@ import (
 	transactionHandler "project-evermos/internal/todo/handler/transaction"
 	authRepo "project-evermos/internal/todo/repository/auth"
 	categoryRepo "project-evermos/internal/todo/repository/category"
+	flashSaleRepo "project-evermos/internal/todo/repository/flashsale"
+	jobRepo "project-evermos/internal/todo/repository/job"
+	notificationRepo "project-evermos/internal/todo/repository/notification"
 	productRepo "project-evermos/internal/todo/repository/product"
 	storeRepo "project-evermos/internal/todo/repository/toko"
 	usersRepo "project-evermos/internal/todo/repository/users"
 	transactionRepo "project-evermos/internal/todo/repository/transaction"
 	authService "project-evermos/internal/todo/service/auth"
 	categoryService "project-evermos/internal/todo/service/category"
+	flashSaleService "project-evermos/internal/todo/service/flashsale"
+	jobService "project-evermos/internal/todo/service/job"
+	notificationService "project-evermos/internal/todo/service/notification"
 	productService "project-evermos/internal/todo/service/product"
 	tokoService "project-evermos/internal/todo/service/toko"
 	usersService "project-evermos/internal/todo/service/users"
@@ -32,36 +44,70 @@ import (
 
 // RegisterRoutes registers HTTP routes for the application.
 // This keeps the router setup centralized.
-func RegisterRoutes(app *fiber.App, gdb *gorm.DB, cfg *config.Config) {
+func RegisterRoutes(app *fiber.App, gdb *gorm.DB, cfg *config.Config, store storage.Storage, sender notify.Sender) {
 	// Healthcheck endpoint
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.SendString("OK")
 	})
 
+	// Uploaded files; only the local driver is served by the app itself
+	if local, ok := store.(*storage.Local); ok {
+		fH := filesHandler.NewHandler(local, time.Duration(cfg.FileCacheMaxAgeSec)*time.Second)
+		app.Get(storage.LocalURLPrefix+"/*", fH.Serve)
+	}
+
 	// Auth module wiring
 	repo := authRepo.NewRepository(gdb)
 	storeR := storeRepo.NewRepository(gdb)
-	service := authService.NewService(repo, storeR, cfg)
+	service := authService.NewService(repo, storeR, cfg, sender)
 	h := authHandler.NewHandler(service)
 
 	grp := app.Group("/auth")
 	grp.Post("/login", h.Login)
 	grp.Post("/register", h.Register)
+	// Access tokens are short-lived; refresh tokens rotate on every use and
+	// every JWT middleware rejects tokens of logged out sessions
+	grp.Post("/refresh", h.Refresh)
+	grp.Post("/logout", h.Logout)
+	grp.Post("/logout-all", usersHandler.JWTMiddleware(cfg.JWTSecret, service), h.LogoutAll)
+	// Forgotten password: one-time code by SMS or email, then a new password
+	grp.Post("/password/forgot", h.ForgotPassword)
+	grp.Post("/password/reset", h.ResetPassword)
 
 	// Toko module wiring
 	tSvc := tokoService.NewService(storeR)
-	tH := tokoHandler.NewHandler(tSvc)
+	tH := tokoHandler.NewHandler(tSvc, store)
 
 	// Protected Toko endpoints (require JWT)
-	jwtMW := tokoHandler.JWTMiddleware(cfg.JWTSecret)
+	jwtMW := tokoHandler.JWTMiddleware(cfg.JWTSecret, service)
 	// Register static route before parameterized route to avoid capture as :id_toko
 	app.Get("/toko/my", jwtMW, tH.GetMy)
+	app.Get("/toko/my/stores", jwtMW, tH.MyStores)
+	app.Get("/toko/invitations", jwtMW, tH.MyInvitations)
+	app.Post("/toko/invitations/:id/accept", jwtMW, tH.AnswerInvitation(true))
+	app.Post("/toko/invitations/:id/decline", jwtMW, tH.AnswerInvitation(false))
 	// Tambahkan route PUT untuk update toko (protected)
 	app.Put("/toko/:id_toko", jwtMW, tH.Update)
-
-	// Public Toko endpoints
+	app.Put("/toko/:id_toko/operasional", jwtMW, tH.UpdateOperasional)
+	app.Put("/toko/:id_toko/alamat", jwtMW, tH.UpdateAlamatAsal)
+	app.Post("/toko/:id_toko/follow", jwtMW, tH.FollowHandler(true))
+	app.Delete("/toko/:id_toko/follow", jwtMW, tH.FollowHandler(false))
+	app.Get("/toko/:id_toko/verifikasi", jwtMW, tH.VerificationStatus)
+	app.Post("/toko/:id_toko/verifikasi", jwtMW, tH.RequestVerification)
+	// Store team: members with roles and invitations by email or phone
+	app.Get("/toko/:id_toko/members", jwtMW, tH.ListMembers)
+	app.Put("/toko/:id_toko/members/:user_id", jwtMW, tH.UpdateMember)
+	app.Delete("/toko/:id_toko/members/:user_id", jwtMW, tH.RemoveMember)
+	app.Get("/toko/:id_toko/invitations", jwtMW, tH.ListInvitations)
+	app.Post("/toko/:id_toko/invitations", jwtMW, tH.Invite)
+	app.Delete("/toko/:id_toko/invitations/:id", jwtMW, tH.CancelInvitation)
+
+	// Public Toko endpoints; an optional token lets sellers see their own
+	// unpublished products on their store page
+	optJWT := usersHandler.OptionalJWTMiddleware(cfg.JWTSecret, service)
 	app.Get("/toko", tH.List)
-	app.Get("/toko/:id_toko", tH.GetByID)
+	app.Get("/toko/slug/:slug", optJWT, tH.GetBySlug)
+	app.Get("/toko/:id_toko", optJWT, tH.GetByID)
 
 	// Users module wiring
 	uRepo := usersRepo.NewRepository(gdb)
@@ -69,9 +115,12 @@ func RegisterRoutes(app *fiber.App, gdb *gorm.DB, cfg *config.Config) {
 	uHandler := usersHandler.NewHandler(uService)
 
 	// Protected user endpoints using JWTMiddleware that reads token header
-	uJWT := usersHandler.JWTMiddleware(cfg.JWTSecret)
+	uJWT := usersHandler.JWTMiddleware(cfg.JWTSecret, service)
 	app.Get("/user", uJWT, uHandler.GetProfile)
 	app.Put("/user", uJWT, uHandler.UpdateProfile)
+	// Login sessions of the user; signing out a device revokes its tokens
+	app.Get("/user/sessions", uJWT, h.ListSessions)
+	app.Delete("/user/sessions/:id", uJWT, h.RevokeSession)
 
 	// Alamat Kirim endpoints
 	app.Get("/user/alamat", uJWT, uHandler.ListAlamat)
@@ -82,25 +131,59 @@ func RegisterRoutes(app *fiber.App, gdb *gorm.DB, cfg *config.Config) {
 
 	// Product module wiring
 	pRepo := productRepo.NewRepository(gdb)
-	pService := productService.NewService(pRepo, cfg.BaseFileURL)
-	pHandler := productHandler.NewHandler(pService, storeR, cfg)
+	pService := productService.NewService(pRepo, store)
+	jRepo := jobRepo.NewRepository(gdb)
+	jService := jobService.NewService(jRepo, store)
+	jHandler := jobHandler.NewHandler(jService)
+	pHandler := productHandler.NewHandler(pService, storeR, cfg, store, jService)
+	pService.StartTrashPurger(
+		time.Duration(cfg.TrashRetentionDays)*24*time.Hour,
+		time.Duration(cfg.TrashPurgeIntervalMin)*time.Minute,
+	)
 
 	// JWT for protected product endpoints (supports 'token' header and Authorization: Bearer)
-	pJWT := usersHandler.JWTMiddleware(cfg.JWTSecret)
-
-	// Public Product endpoints
-	app.Get("/product", pHandler.List)
-	app.Get("/product/:id", pHandler.GetByID)
+	pJWT := usersHandler.JWTMiddleware(cfg.JWTSecret, service)
+
+	// Public Product endpoints; an optional token lets sellers see their
+	// own unpublished products
+	app.Get("/product", optJWT, pHandler.List)
+	app.Get("/product/slug/:slug", optJWT, pHandler.GetBySlug)
+	app.Get("/product/:id", optJWT, pHandler.GetByID)
+	app.Get("/product/:id/price-history", optJWT, pHandler.PriceHistory)
+	app.Get("/toko/:id_toko/products", optJWT, pHandler.ListByToko)
+	// store pages embed a page of the store's products
+	tH.SetProducts(pHandler)
 
 	// Protected Product endpoints
 	app.Post("/product", pJWT, pHandler.Create)
 	app.Put("/product/:id", pJWT, pHandler.Update)
 	app.Delete("/product/:id", pJWT, pHandler.Delete)
+	app.Post("/product/:id/stock/adjust", pJWT, pHandler.AdjustStock)
+	app.Get("/product/:id/stock/history", pJWT, pHandler.StockHistory)
+	app.Post("/product/:id/restore", pJWT, pHandler.Restore)
+	app.Post("/product/:id/submit", pJWT, pHandler.Submit)
+	app.Delete("/product/:id/photos/:photo_id", pJWT, pHandler.DeletePhoto)
+	app.Put("/product/:id/photos/order", pJWT, pHandler.ReorderPhotos)
+	app.Get("/toko/my/products/trash", pJWT, pHandler.ListTrash)
+	app.Get("/user/feed", pJWT, pHandler.Feed)
+	app.Get("/toko/my/alerts", pJWT, pHandler.ListAlerts)
+	app.Get("/toko/my/products/export", pJWT, pHandler.Export)
+	app.Post("/toko/my/products/import", pJWT, pHandler.Import)
+	app.Get("/jobs/:id", pJWT, jHandler.GetByID)
+
+	// Notifications
+	nRepo := notificationRepo.NewRepository(gdb)
+	nService := notificationService.NewService(nRepo)
+	nH := notificationHandler.NewHandler(nService)
+	app.Get("/notifications", pJWT, nH.List)
+	app.Put("/notifications/read", pJWT, nH.MarkRead)
+	app.Put("/notifications/:id/read", pJWT, nH.MarkRead)
 
 	// Address (Province/City) public endpoints using EMSIFA
 	addrRepo := addressRepo.NewRepository(cfg.EMSIFABase, cfg.HTTPTimeoutMS, cfg.HTTPRetry)
 	addrSvc := addressService.NewService(addrRepo, time.Duration(cfg.CacheTTLSeconds)*time.Second)
 	addrH := addressHandler.NewHandler(addrSvc)
+	tSvc.SetWilayah(addrSvc)
 	app.Get("/provcity/listprovincies", addrH.ListProvinces)
 	app.Get("/provcity/listcities/:prov_id", addrH.ListCities)
 	app.Get("/provcity/detailprovince/:prov_id", addrH.DetailProvince)
@@ -113,20 +196,46 @@ func RegisterRoutes(app *fiber.App, gdb *gorm.DB, cfg *config.Config) {
 	// Public endpoints
 	app.Get("/category", cH.List)
 	app.Get("/category/:id", cH.GetByID)
+	app.Get("/category/:id/attributes", cH.ListAttributes)
 	// Private ADMIN ONLY endpoints
-	cJWT := categoryHandler.AuthJWT(cfg.JWTSecret, gdb, "POST")
+	cJWT := categoryHandler.AuthJWT(cfg.JWTSecret, gdb, service, "POST")
 	cADM := categoryHandler.RequireAdmin("POST")
 	app.Post("/category", cJWT, cADM, cH.Create)
 	app.Put("/category/:id", cJWT, cADM, cH.Update)
 	app.Delete("/category/:id", cJWT, cADM, cH.Delete)
+	app.Put("/category/:id/attributes", cJWT, cADM, cH.ReplaceAttributes)
+
+	// Product moderation (ADMIN ONLY)
+	app.Get("/admin/products", categoryHandler.AuthJWT(cfg.JWTSecret, gdb, service, "GET"), categoryHandler.RequireAdmin("GET"), pHandler.ListReview)
+	app.Post("/admin/products/:id/approve", cJWT, cADM, pHandler.Approve)
+	app.Post("/admin/products/:id/reject", cJWT, cADM, pHandler.Reject)
+
+	// Store verification (ADMIN ONLY review)
+	app.Get("/admin/toko/verifikasi", categoryHandler.AuthJWT(cfg.JWTSecret, gdb, service, "GET"), categoryHandler.RequireAdmin("GET"), tH.ListVerifications)
+	app.Post("/admin/toko/verifikasi/:id/approve", cJWT, cADM, tH.ReviewVerification(true))
+	app.Post("/admin/toko/verifikasi/:id/reject", cJWT, cADM, tH.ReviewVerification(false))
+
+	// Flash sales: public campaigns, managed by admins; checkout applies
+	// the sale prices
+	fsRepo := flashSaleRepo.NewRepository(gdb)
+	fsService := flashSaleService.NewService(fsRepo)
+	fsH := flashSaleHandler.NewHandler(fsService)
+	app.Get("/flash-sales", fsH.List("active"))
+	app.Get("/flash-sales/:id", fsH.GetByID)
+	app.Get("/admin/flash-sales", categoryHandler.AuthJWT(cfg.JWTSecret, gdb, service, "GET"), categoryHandler.RequireAdmin("GET"), fsH.List("all"))
+	app.Post("/admin/flash-sales", cJWT, cADM, fsH.Create)
+	app.Put("/admin/flash-sales/:id", cJWT, cADM, fsH.Update)
+	app.Delete("/admin/flash-sales/:id", cJWT, cADM, fsH.Delete)
 
 	// Transaction module wiring
 	trxRepo := transactionRepo.NewRepository(gdb)
 	trxService := transactionService.NewService(trxRepo)
-	trxHandler := transactionHandler.NewHandler(trxService)
+	trxHandler := transactionHandler.NewHandler(trxService, tokoService.NewAccess(storeR))
 
-	trxJWT := usersHandler.JWTMiddleware(cfg.JWTSecret)
+	trxJWT := usersHandler.JWTMiddleware(cfg.JWTSecret, service)
 	app.Get("/trx", trxJWT, trxHandler.List)
+	app.Get("/toko/my/orders", trxJWT, trxHandler.ListSeller)
 	app.Get("/trx/:id", trxJWT, trxHandler.GetByID)
 	app.Post("/trx", trxJWT, trxHandler.Create)
+	app.Post("/trx/:id/ulasan", trxJWT, trxHandler.Rate)
 }
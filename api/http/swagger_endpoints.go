@@ -40,15 +40,18 @@ type CityRef struct {
 // AuthLoginData returned in login success
 // swagger:model
 type AuthLoginData struct {
-    Nama          string     `json:"nama" example:"John Doe"`
-    NoTelp        string     `json:"no_telp" example:"08123456789"`
-    TanggalLahir  string     `json:"tanggal_Lahir" example:"01/01/1990"`
-    Tentang       string     `json:"tentang" example:"Saya reseller Evermos"`
-    Pekerjaan     string     `json:"pekerjaan" example:"Reseller"`
-    Email         string     `json:"email" example:"john@example.com"`
-    IDProvinsi    ProvinceRef `json:"id_provinsi"`
-    IDKota        CityRef     `json:"id_kota"`
-    Token         string     `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+    Nama                  string      `json:"nama" example:"John Doe"`
+    NoTelp                string      `json:"no_telp" example:"08123456789"`
+    TanggalLahir          string      `json:"tanggal_Lahir" example:"01/01/1990"`
+    Tentang               string      `json:"tentang" example:"Saya reseller Evermos"`
+    Pekerjaan             string      `json:"pekerjaan" example:"Reseller"`
+    Email                 string      `json:"email" example:"john@example.com"`
+    IDProvinsi            ProvinceRef `json:"id_provinsi"`
+    IDKota                CityRef     `json:"id_kota"`
+    Token                 string      `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+    TokenExpiresAt        string      `json:"token_expires_at" example:"2025-01-01T10:15:00+07:00"`
+    RefreshToken          string      `json:"refresh_token" example:"n3Jq0c5xV2m8pL1sQ7tY4wZ9aB6dE0fG2hK5jM8rT1u"`
+    RefreshTokenExpiresAt string      `json:"refresh_token_expires_at" example:"2025-01-08T10:00:00+07:00"`
 }
 
 // AuthLoginResponse envelope
@@ -69,6 +72,110 @@ type AuthRegisterResponse struct {
     Data    string   `json:"data" example:"Register Succeed"`
 }
 
+// AuthRefreshRequest carries a refresh token
+// swagger:model
+type AuthRefreshRequest struct {
+    RefreshToken string `json:"refresh_token" example:"n3Jq0c5xV2m8pL1sQ7tY4wZ9aB6dE0fG2hK5jM8rT1u"`
+}
+
+// AuthTokens new access and refresh token
+// swagger:model
+type AuthTokens struct {
+    Token                 string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+    TokenExpiresAt        string `json:"token_expires_at" example:"2025-01-01T10:15:00+07:00"`
+    RefreshToken          string `json:"refresh_token" example:"Xk2pV9qL4mT7sB1nW6cR3yF8hJ0dG5aZ2eU7iO4tQ9w"`
+    RefreshTokenExpiresAt string `json:"refresh_token_expires_at" example:"2025-01-08T10:00:00+07:00"`
+}
+
+// AuthTokensResponse envelope
+// swagger:model
+type AuthTokensResponse struct {
+    Status  bool       `json:"status" example:"true"`
+    Message string     `json:"message" example:"Succeed to POST data"`
+    Errors  []string   `json:"errors" example:""`
+    Data    AuthTokens `json:"data"`
+}
+
+// AuthLogoutResponse envelope
+// swagger:model
+type AuthLogoutResponse struct {
+    Status  bool     `json:"status" example:"true"`
+    Message string   `json:"message" example:"Succeed to POST data"`
+    Errors  []string `json:"errors" example:""`
+    Data    string   `json:"data" example:"Logout Succeed"`
+}
+
+// AuthLogoutAllData number of sessions ended
+// swagger:model
+type AuthLogoutAllData struct {
+    SesiDicabut int64 `json:"sesi_dicabut" example:"3"`
+}
+
+// AuthLogoutAllResponse envelope
+// swagger:model
+type AuthLogoutAllResponse struct {
+    Status  bool              `json:"status" example:"true"`
+    Message string            `json:"message" example:"Succeed to POST data"`
+    Errors  []string          `json:"errors" example:""`
+    Data    AuthLogoutAllData `json:"data"`
+}
+
+// AuthForgotPasswordRequest names the account by phone or email (one of them)
+// swagger:model
+type AuthForgotPasswordRequest struct {
+    NoTelp string `json:"no_telp" example:"08123456789"`
+    Email  string `json:"email" example:""`
+}
+
+// AuthResetPasswordRequest sets a new password with the OTP code
+// swagger:model
+type AuthResetPasswordRequest struct {
+    NoTelp    string `json:"no_telp" example:"08123456789"`
+    Email     string `json:"email" example:""`
+    Kode      string `json:"kode" example:"482915"`
+    KataSandi string `json:"kata_sandi" example:"passwordBaru123"`
+}
+
+// AuthForgotPasswordResponse envelope
+// swagger:model
+type AuthForgotPasswordResponse struct {
+    Status  bool     `json:"status" example:"true"`
+    Message string   `json:"message" example:"Succeed to POST data"`
+    Errors  []string `json:"errors" example:""`
+    Data    string   `json:"data" example:"Jika akun terdaftar, kode OTP telah dikirim"`
+}
+
+// AuthResetPasswordResponse envelope
+// swagger:model
+type AuthResetPasswordResponse struct {
+    Status  bool     `json:"status" example:"true"`
+    Message string   `json:"message" example:"Succeed to POST data"`
+    Errors  []string `json:"errors" example:""`
+    Data    string   `json:"data" example:"Reset Password Succeed"`
+}
+
+// UserSession active login session of the user
+// swagger:model
+type UserSession struct {
+    ID           uint   `json:"id" example:"12"`
+    Perangkat    string `json:"perangkat" example:"Chrome di Windows"`
+    IP           string `json:"ip" example:"203.0.113.7"`
+    UserAgent    string `json:"user_agent" example:"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/128.0 Safari/537.36"`
+    CreatedAt    string `json:"created_at" example:"2025-01-01T10:00:00+07:00"`
+    LastActiveAt string `json:"last_active_at" example:"2025-01-03T08:12:00+07:00"`
+    ExpiresAt    string `json:"expires_at" example:"2025-01-10T08:00:00+07:00"`
+    SesiIni      bool   `json:"sesi_ini" example:"true"`
+}
+
+// UserSessionListResponse envelope
+// swagger:model
+type UserSessionListResponse struct {
+    Status  bool          `json:"status" example:"true"`
+    Message string        `json:"message" example:"Succeed to GET data"`
+    Errors  []string      `json:"errors" example:""`
+    Data    []UserSession `json:"data"`
+}
+
 // ErrorResponse generic error envelope
 // swagger:model
 type ErrorResponse struct {
@@ -102,18 +209,34 @@ type UserProfileResponse struct {
 }
 
 // Product models
+// ImageSize is one generated size of an uploaded image
+// swagger:model
+type ImageSize struct {
+    URL    string `json:"url" example:"https://files.local/uploads/products/1758869029454234400-IMG_2867-medium.jpg"`
+    WebP   string `json:"webp" example:"https://files.local/uploads/products/1758869029454234400-IMG_2867-medium.webp"`
+    Width  int    `json:"width" example:"800"`
+    Height int    `json:"height" example:"600"`
+}
+
 // swagger:model
 type ProductPhoto struct {
-    ID        uint   `json:"id" example:"1"`
-    ProductID uint   `json:"product_id" example:"10"`
-    URL       string `json:"url" example:"https://files.local/uploads/products/1758869029454234400-IMG_2867_11zon.jpg"`
+    ID        uint                 `json:"id" example:"1"`
+    ProductID uint                 `json:"product_id" example:"10"`
+    URL       string               `json:"url" example:"https://files.local/uploads/products/1758869029454234400-IMG_2867.jpg"`
+    Sizes     map[string]ImageSize `json:"sizes"` // original, medium, thumb
+    Urutan    int                  `json:"urutan" example:"0"`
+    IsPrimary bool                 `json:"is_primary" example:"true"`
 }
 
 // swagger:model
 type ProductStore struct {
-    ID       uint   `json:"id" example:"5"`
-    NamaToko string `json:"nama_toko" example:"Toko Budi"`
-    URLFoto  string `json:"url_foto" example:"https://files.local/uploads/stores/toko-1758868233503052000.jpg"`
+    ID          uint         `json:"id" example:"5"`
+    NamaToko    string       `json:"nama_toko" example:"Toko Budi"`
+    Slug        string       `json:"slug" example:"toko-budi"`
+    URLFoto     string       `json:"url_foto" example:"https://files.local/uploads/stores/toko-1758868233503052000.jpg"`
+    Status      StoreStatus  `json:"status"`
+    DikirimDari *StoreOrigin `json:"dikirim_dari"` // null until the store sets its origin address
+    Verified    bool         `json:"verified" example:"true"`
 }
 
 // swagger:model
@@ -122,6 +245,30 @@ type ProductCategory struct {
     NamaCategory string `json:"nama_category" example:"Fashion"`
 }
 
+// swagger:model
+type ProductOption struct {
+    ID    uint     `json:"id" example:"1"`
+    Nama  string   `json:"nama" example:"Ukuran"`
+    Nilai []string `json:"nilai" example:"S,M,L"`
+}
+
+// swagger:model
+type ProductVariant struct {
+    ID            uint              `json:"id" example:"7"`
+    SKU           string            `json:"sku" example:"KMJ-M-PTH"`
+    Nama          string            `json:"nama" example:"M / Putih"`
+    Opsi          map[string]string `json:"opsi"`
+    HargaReseller int               `json:"harga_reseller" example:"90000"`
+    HargaKonsumen int               `json:"harga_konsumen" example:"120000"`
+    Stok          int               `json:"stok" example:"20"`
+    Berat         *int              `json:"berat" example:"250"`
+    Panjang       *int              `json:"panjang" example:"30"`
+    Lebar         *int              `json:"lebar" example:"25"`
+    Tinggi        *int              `json:"tinggi" example:"3"`
+    Photos        []ProductPhoto    `json:"photos"`
+    FlashSale     *ProductFlashSale `json:"flash_sale"`
+}
+
 // swagger:model
 type Product struct {
     ID             uint            `json:"id" example:"10"`
@@ -130,10 +277,40 @@ type Product struct {
     HargaReseller  int             `json:"harga_reseller" example:"90000"`
     HargaKonsumen  int             `json:"harga_konsumen" example:"120000"`
     Stok           int             `json:"stok" example:"50"`
+    StokMinimum    int             `json:"stok_minimum" example:"5"`
+    Berat          int             `json:"berat" example:"250"`
+    Panjang        int             `json:"panjang" example:"30"`
+    Lebar          int             `json:"lebar" example:"25"`
+    Tinggi         int             `json:"tinggi" example:"3"`
     Deskripsi      string          `json:"deskripsi" example:"Bahan katun, nyaman dipakai"`
+    Status         string          `json:"status" example:"published" enums:"draft,pending_review,published,rejected"`
+    AlasanPenolakan *string        `json:"alasan_penolakan" example:"Foto produk tidak sesuai"`
     Toko           ProductStore    `json:"toko"`
     Category       ProductCategory `json:"category"`
     Photos         []ProductPhoto  `json:"photos"`
+    Options        []ProductOption  `json:"options"`
+    Variants       []ProductVariant `json:"variants"`
+    Attributes     []ProductAttribute `json:"attributes"`
+    FlashSale      *ProductFlashSale  `json:"flash_sale"`
+}
+
+// swagger:model
+type ProductFlashSale struct {
+    ID           uint   `json:"id" example:"4"`
+    Nama         string `json:"nama" example:"Flash Sale 12.12"`
+    HargaNormal  int    `json:"harga_normal" example:"120000"`
+    HargaSale    int    `json:"harga_sale" example:"89000"`
+    DiskonPersen int    `json:"diskon_persen" example:"25"`
+    SisaKuota    int    `json:"sisa_kuota" example:"37"`
+    Selesai      string `json:"selesai" example:"2025-12-12T23:59:59+07:00"`
+    SisaDetik    int64  `json:"sisa_detik" example:"5400"`
+}
+
+// swagger:model
+type ProductAttribute struct {
+    Kode  string `json:"kode" example:"bahan"`
+    Nama  string `json:"nama" example:"Bahan"`
+    Nilai string `json:"nilai" example:"Katun"`
 }
 
 // swagger:model
@@ -179,6 +356,21 @@ type APIResponseString struct {
 // @Router /health [get]
 func SwaggerHealthCheck() {}
 
+// @Summary Get uploaded file
+// @Description File upload (driver local). File di bawah private/ hanya bisa diakses lewat signed URL (expires & sig). Mendukung Range, ETag/If-None-Match dan Cache-Control
+// @Tags Files
+// @Produce octet-stream
+// @Param path path string true "File key" example(products/1758869029454234400-IMG_2867.jpg)
+// @Param expires query integer false "Unix expiry of a signed URL"
+// @Param sig query string false "Signature of a signed URL"
+// @Success 200 {file} file "File content"
+// @Success 206 {file} file "Partial content (Range request)"
+// @Success 304 {string} string "Not modified"
+// @Failure 403 {object} ErrorResponse "Invalid or expired signature"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Router /uploads/{path} [get]
+func SwaggerFileGet() {}
+
 // @Summary Login user
 // @Description Authenticate user dengan nomor telepon dan kata sandi
 // @Tags Auth
@@ -202,13 +394,74 @@ func SwaggerAuthLogin() {}
 // @Router /auth/register [post]
 func SwaggerAuthRegister() {}
 
+// @Summary Refresh tokens
+// @Description Tukar refresh token dengan access token dan refresh token baru. Refresh token lama langsung hangus; memakainya lagi mencabut seluruh sesi
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body AuthRefreshRequest true "Refresh token"
+// @Success 200 {object} AuthTokensResponse "New tokens"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Invalid, expired or reused refresh token"
+// @Router /auth/refresh [post]
+func SwaggerAuthRefresh() {}
+
+// @Summary Logout
+// @Description Akhiri sesi dari refresh token; access token sesi itu langsung ditolak
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body AuthRefreshRequest true "Refresh token"
+// @Success 200 {object} AuthLogoutResponse "Logged out"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Invalid refresh token"
+// @Router /auth/logout [post]
+func SwaggerAuthLogout() {}
+
+// @Summary Logout all devices
+// @Description Akhiri semua sesi user di semua perangkat, termasuk sesi saat ini
+// @Tags Auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} AuthLogoutAllResponse "Sessions ended"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /auth/logout-all [post]
+func SwaggerAuthLogoutAll() {}
+
+// @Summary Forgot password
+// @Description Kirim kode OTP 6 digit ke no_telp (SMS) atau email akun. Respons sama untuk akun yang tidak terdaftar; kode baru paling cepat 1 menit setelah kode sebelumnya dan menggantikannya
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body AuthForgotPasswordRequest true "Phone or email"
+// @Success 200 {object} AuthForgotPasswordResponse "Code sent if the account exists"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Router /auth/password/forgot [post]
+func SwaggerAuthForgotPassword() {}
+
+// @Summary Reset password
+// @Description Atur kata sandi baru dengan kode OTP. Kode berlaku singkat, maksimal 5 percobaan dan hanya sekali pakai; semua sesi login akun diakhiri
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body AuthResetPasswordRequest true "Code and new password"
+// @Success 200 {object} AuthResetPasswordResponse "Password changed"
+// @Failure 400 {object} ErrorResponse "Bad request or wrong/expired code"
+// @Failure 429 {object} ErrorResponse "Too many attempts"
+// @Router /auth/password/reset [post]
+func SwaggerAuthResetPassword() {}
+
 // @Summary Get my store
-// @Description Get current user's store information
+// @Description Toko yang sedang dikelola user beserta role-nya: toko pada header X-Toko-ID, atau toko milik sendiri, atau satu-satunya toko tempat user menjadi anggota. data null bila user tidak punya toko
 // @Tags Toko
 // @Security BearerAuth
 // @Produce json
+// @Param X-Toko-ID header integer false "Store ID to act on" example(5)
 // @Success 200 {object} APIResponseString "Store information"
+// @Failure 400 {object} ErrorResponse "Member of several stores without X-Toko-ID"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not a member of the store"
+// @Failure 404 {object} ErrorResponse "Store not found"
 // @Router /toko/my [get]
 func SwaggerTokoGetMy() {}
 
@@ -220,7 +473,7 @@ func SwaggerTokoGetMy() {}
 // @Produce json
 // @Param id_toko path integer true "Store ID" example(5)
 // @Param nama_toko formData string false "Store name" example(Toko Budi)
-// @Param photo formData file false "Store photo (jpg, jpeg, png)"
+// @Param photo formData file false "Store photo (jpg, png, gif, webp; max 10MB)"
 // @Success 200 {object} APIResponseString "Update successful"
 // @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
@@ -229,198 +482,1563 @@ func SwaggerTokoGetMy() {}
 // @Router /toko/{id_toko} [put]
 func SwaggerTokoUpdate() {}
 
-// @Summary Get store by ID
-// @Description Get public store information by ID
-// @Tags Toko
+// Store team models
+// swagger:model
+type MyStore struct {
+    ID       uint   `json:"id" example:"5"`
+    NamaToko string `json:"nama_toko" example:"Toko Budi"`
+    Slug     string `json:"slug" example:"toko-budi"`
+    URLFoto  string `json:"url_foto" example:"https://files.local/uploads/stores/toko-1.jpg"`
+    Verified bool   `json:"verified" example:"false"`
+    Role     string `json:"role" example:"staff-orders" enums:"owner,manager,staff-orders,staff-catalog"`
+}
+
+// swagger:model
+type MyStoreListResponse struct {
+    Status  bool      `json:"status" example:"true"`
+    Message string    `json:"message" example:"Succeed to GET data"`
+    Errors  []string  `json:"errors" example:""`
+    Data    []MyStore `json:"data"`
+}
+
+// swagger:model
+type TokoMember struct {
+    IDUser    uint   `json:"id_user" example:"12"`
+    Nama      string `json:"nama" example:"Siti"`
+    Email     string `json:"email" example:"siti@example.com"`
+    NoTelp    string `json:"notelp" example:"081234567890"`
+    Role      string `json:"role" example:"staff-catalog" enums:"owner,manager,staff-orders,staff-catalog"`
+    CreatedAt string `json:"created_at" example:"2025-09-26T10:00:00Z"`
+}
+
+// swagger:model
+type TokoMemberListResponse struct {
+    Status  bool         `json:"status" example:"true"`
+    Message string       `json:"message" example:"Succeed to GET data"`
+    Errors  []string     `json:"errors" example:""`
+    Data    []TokoMember `json:"data"`
+}
+
+// swagger:model
+type TokoMemberRoleRequest struct {
+    Role string `json:"role" example:"manager" enums:"manager,staff-orders,staff-catalog"`
+}
+
+// swagger:model
+type TokoInvitationRequest struct {
+    Kontak string `json:"kontak" example:"siti@example.com"` // email or phone number
+    Role   string `json:"role" example:"staff-catalog" enums:"manager,staff-orders,staff-catalog"`
+}
+
+// swagger:model
+type TokoInvitation struct {
+    ID        uint     `json:"id" example:"3"`
+    Kontak    string   `json:"kontak" example:"siti@example.com"`
+    Role      string   `json:"role" example:"staff-catalog"`
+    Status    string   `json:"status" example:"pending" enums:"pending,accepted,declined,cancelled"`
+    ExpiresAt string   `json:"expires_at" example:"2025-10-03T10:00:00Z"`
+    CreatedAt string   `json:"created_at" example:"2025-09-26T10:00:00Z"`
+    Toko      *TrxToko `json:"toko,omitempty"` // set on the invitee's list
+}
+
+// swagger:model
+type TokoInvitationResponse struct {
+    Status  bool           `json:"status" example:"true"`
+    Message string         `json:"message" example:"Succeed to POST data"`
+    Errors  []string       `json:"errors" example:""`
+    Data    TokoInvitation `json:"data"`
+}
+
+// swagger:model
+type TokoInvitationListResponse struct {
+    Status  bool             `json:"status" example:"true"`
+    Message string           `json:"message" example:"Succeed to GET data"`
+    Errors  []string         `json:"errors" example:""`
+    Data    []TokoInvitation `json:"data"`
+}
+
+// @Summary List my stores
+// @Description Toko yang dimiliki user dan toko tempat user menjadi anggota, beserta role-nya. Pilih toko yang dikelola di endpoint /toko/my/... dan produk dengan header X-Toko-ID
+// @Tags Toko
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} MyStoreListResponse "Stores"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /toko/my/stores [get]
+func SwaggerTokoMyStores() {}
+
+// @Summary List store members
+// @Description Pemilik dan anggota toko beserta role-nya. Role: manager (semua kecuali mengelola manager lain), staff-orders (pesanan), staff-catalog (produk dan stok). Bisa dilihat semua anggota
+// @Tags Toko
+// @Security BearerAuth
+// @Produce json
+// @Param id_toko path integer true "Store ID" example(5)
+// @Success 200 {object} TokoMemberListResponse "Members"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not a member"
+// @Failure 404 {object} ErrorResponse "Store not found"
+// @Router /toko/{id_toko}/members [get]
+func SwaggerTokoListMembers() {}
+
+// @Summary Change member role
+// @Description Ubah role anggota toko (pemilik dan manager). Hanya pemilik yang bisa mengangkat atau mengubah manager; role pemilik tidak bisa diubah
+// @Tags Toko
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id_toko path integer true "Store ID" example(5)
+// @Param user_id path integer true "Member user ID" example(12)
+// @Param body body TokoMemberRoleRequest true "New role"
+// @Success 200 {object} APIResponseString "Role updated"
+// @Failure 400 {object} ErrorResponse "Invalid role"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Member not found"
+// @Router /toko/{id_toko}/members/{user_id} [put]
+func SwaggerTokoUpdateMember() {}
+
+// @Summary Remove member
+// @Description Keluarkan anggota dari toko (pemilik dan manager; hanya pemilik yang bisa mengeluarkan manager). Anggota bisa keluar sendiri dengan user_id miliknya. Pemilik tidak bisa dikeluarkan
+// @Tags Toko
+// @Security BearerAuth
+// @Produce json
+// @Param id_toko path integer true "Store ID" example(5)
+// @Param user_id path integer true "Member user ID" example(12)
+// @Success 200 {object} APIResponseString "Member removed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Member not found"
+// @Router /toko/{id_toko}/members/{user_id} [delete]
+func SwaggerTokoRemoveMember() {}
+
+// @Summary List store invitations
+// @Description Undangan toko yang masih menunggu jawaban (pemilik dan manager)
+// @Tags Toko
+// @Security BearerAuth
+// @Produce json
+// @Param id_toko path integer true "Store ID" example(5)
+// @Success 200 {object} TokoInvitationListResponse "Pending invitations"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Store not found"
+// @Router /toko/{id_toko}/invitations [get]
+func SwaggerTokoListInvitations() {}
+
+// @Summary Invite member
+// @Description Undang user lewat email atau no telp dengan role tertentu (pemilik dan manager; hanya pemilik yang bisa mengundang manager). Undangan berlaku 7 hari; user yang sudah terdaftar mendapat notifikasi
+// @Tags Toko
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id_toko path integer true "Store ID" example(5)
+// @Param body body TokoInvitationRequest true "Invitation"
+// @Success 200 {object} TokoInvitationResponse "Invitation created"
+// @Failure 400 {object} ErrorResponse "Invalid kontak or role"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Store not found"
+// @Failure 409 {object} ErrorResponse "Already a member or already invited"
+// @Router /toko/{id_toko}/invitations [post]
+func SwaggerTokoInvite() {}
+
+// @Summary Cancel invitation
+// @Description Batalkan undangan yang belum dijawab (pemilik dan manager)
+// @Tags Toko
+// @Security BearerAuth
+// @Produce json
+// @Param id_toko path integer true "Store ID" example(5)
+// @Param id path integer true "Invitation ID" example(3)
+// @Success 200 {object} APIResponseString "Invitation cancelled"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Invitation not found"
+// @Router /toko/{id_toko}/invitations/{id} [delete]
+func SwaggerTokoCancelInvitation() {}
+
+// @Summary List my invitations
+// @Description Undangan toko yang dikirim ke email atau no telp user dan masih menunggu jawaban
+// @Tags Toko
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} TokoInvitationListResponse "Invitations"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /toko/invitations [get]
+func SwaggerTokoMyInvitations() {}
+
+// @Summary Accept invitation
+// @Description Terima undangan; user menjadi anggota toko dengan role yang diundang
+// @Tags Toko
+// @Security BearerAuth
+// @Produce json
+// @Param id path integer true "Invitation ID" example(3)
+// @Success 200 {object} TokoInvitationResponse "Invitation accepted"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Invitation not found"
+// @Failure 409 {object} ErrorResponse "Invitation answered, cancelled or expired"
+// @Router /toko/invitations/{id}/accept [post]
+func SwaggerTokoAcceptInvitation() {}
+
+// @Summary Decline invitation
+// @Description Tolak undangan toko
+// @Tags Toko
+// @Security BearerAuth
+// @Produce json
+// @Param id path integer true "Invitation ID" example(3)
+// @Success 200 {object} TokoInvitationResponse "Invitation declined"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Invitation not found"
+// @Failure 409 {object} ErrorResponse "Invitation answered, cancelled or expired"
+// @Router /toko/invitations/{id}/decline [post]
+func SwaggerTokoDeclineInvitation() {}
+
+// Store operating hours models
+// swagger:model
+type StoreJam struct {
+    Hari  int    `json:"hari" example:"1"` // 0 = Minggu ... 6 = Sabtu
+    Buka  string `json:"buka" example:"08:00"`
+    Tutup string `json:"tutup" example:"17:00"`
+}
+
+// swagger:model
+type StoreStatus struct {
+    Buka     bool   `json:"buka" example:"false"`
+    Libur    bool   `json:"libur" example:"true"`
+    Pesan    string `json:"pesan" example:"Libur lebaran"`
+    BukaLagi string `json:"buka_lagi" example:"2025-04-07T08:00:00+07:00"` // null when open or on vacation without end date
+}
+
+// swagger:model
+type StoreOperasionalRequest struct {
+    JamOperasional []StoreJam `json:"jam_operasional"`
+    ZonaWaktu      string     `json:"zona_waktu" example:"WIB" enums:"WIB,WITA,WIT"`
+    Libur          bool       `json:"libur" example:"true"`
+    LiburSampai    string     `json:"libur_sampai" example:"2025-04-07T00:00:00+07:00"`
+    PesanLibur     string     `json:"pesan_libur" example:"Libur lebaran"`
+}
+
+// swagger:model
+type StoreOperasional struct {
+    JamOperasional []StoreJam  `json:"jam_operasional"`
+    ZonaWaktu      string      `json:"zona_waktu" example:"WIB"`
+    Libur          bool        `json:"libur" example:"true"`
+    LiburSampai    string      `json:"libur_sampai" example:"2025-04-07T00:00:00+07:00"`
+    PesanLibur     string      `json:"pesan_libur" example:"Libur lebaran"`
+    Status         StoreStatus `json:"status"`
+}
+
+// swagger:model
+type StoreOperasionalResponse struct {
+    Status  bool             `json:"status" example:"true"`
+    Message string           `json:"message" example:"Succeed to UPDATE data"`
+    Errors  []string         `json:"errors" example:""`
+    Data    StoreOperasional `json:"data"`
+}
+
+// @Summary Update store operating hours
+// @Description Atur jam operasional mingguan dan mode libur toko (pemilik dan manager). jam_operasional kosong = selalu buka; hari tanpa jam = tutup. Jam dalam zona_waktu toko. Mode libur dengan libur_sampai berakhir otomatis pada waktu itu. Toko yang tutup atau libur tidak bisa dipesan
+// @Tags Toko
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id_toko path integer true "Store ID" example(5)
+// @Param body body StoreOperasionalRequest true "Operating hours and vacation mode"
+// @Success 200 {object} StoreOperasionalResponse "Settings saved"
+// @Failure 400 {object} ErrorResponse "Invalid hours"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Store not found"
+// @Router /toko/{id_toko}/operasional [put]
+func SwaggerTokoUpdateOperasional() {}
+
+// Store origin address models
+// swagger:model
+type StoreOrigin struct {
+    IDKota       string `json:"id_kota" example:"3273"`
+    NamaKota     string `json:"nama_kota" example:"KOTA BANDUNG"`
+    IDProvinsi   string `json:"id_provinsi" example:"32"`
+    NamaProvinsi string `json:"nama_provinsi" example:"JAWA BARAT"`
+}
+
+// swagger:model
+type StoreAlamatAsalRequest struct {
+    IDProvinsi   string `json:"id_provinsi" example:"32"`
+    IDKota       string `json:"id_kota" example:"3273"`
+    DetailAlamat string `json:"detail_alamat" example:"Jl. Merdeka No. 10, Sumur Bandung"`
+    KodePos      string `json:"kode_pos" example:"40111"`
+}
+
+// swagger:model
+type StoreAlamatAsal struct {
+    IDKota       string `json:"id_kota" example:"3273"`
+    NamaKota     string `json:"nama_kota" example:"KOTA BANDUNG"`
+    IDProvinsi   string `json:"id_provinsi" example:"32"`
+    NamaProvinsi string `json:"nama_provinsi" example:"JAWA BARAT"`
+    DetailAlamat string `json:"detail_alamat" example:"Jl. Merdeka No. 10, Sumur Bandung"`
+    KodePos      string `json:"kode_pos" example:"40111"`
+}
+
+// swagger:model
+type StoreAlamatAsalResponse struct {
+    Status  bool            `json:"status" example:"true"`
+    Message string          `json:"message" example:"Succeed to UPDATE data"`
+    Errors  []string        `json:"errors" example:""`
+    Data    StoreAlamatAsal `json:"data"`
+}
+
+// @Summary Set store origin address
+// @Description Atur alamat asal/pickup toko (pemilik dan manager). id_provinsi dan id_kota dicek ke data wilayah (GET /provcity/...) dan kota harus berada di provinsi tersebut. Wajib diatur sebelum toko bisa mengajukan produk. Publik hanya melihat kota dan provinsi (dikirim_dari); alamat lengkap tampil di GET /toko/my
+// @Tags Toko
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id_toko path integer true "Store ID" example(5)
+// @Param body body StoreAlamatAsalRequest true "Origin address"
+// @Success 200 {object} StoreAlamatAsalResponse "Address saved"
+// @Failure 400 {object} ErrorResponse "Invalid address"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Store not found"
+// @Failure 502 {object} ErrorResponse "Address service unavailable"
+// @Router /toko/{id_toko}/alamat [put]
+func SwaggerTokoUpdateAlamatAsal() {}
+
+// Store page models
+// swagger:model
+type StoreStats struct {
+    JumlahProduk int64    `json:"jumlah_produk" example:"24"`
+    TotalTerjual int64    `json:"total_terjual" example:"310"`
+    Rating       *float64 `json:"rating" example:"4.7"` // null when the store has no ratings
+    JumlahUlasan int64    `json:"jumlah_ulasan" example:"58"`
+    Pengikut     int64    `json:"pengikut" example:"120"`
+}
+
+// swagger:model
+type StoreProductListData struct {
+    Items     []Product `json:"items"`
+    Total     int64     `json:"total" example:"24"`
+    Page      int       `json:"page" example:"1"`
+    Limit     int       `json:"limit" example:"10"`
+    TotalPage int64     `json:"total_page" example:"3"`
+}
+
+// swagger:model
+type StoreProductListResponse struct {
+    Status  bool                 `json:"status" example:"true"`
+    Message string               `json:"message" example:"Succeed to GET data"`
+    Errors  []string             `json:"errors" example:""`
+    Data    StoreProductListData `json:"data"`
+}
+
+// swagger:model
+type StorePage struct {
+    ID          uint                 `json:"id" example:"5"`
+    NamaToko    string               `json:"nama_toko" example:"Toko Budi"`
+    Slug        string               `json:"slug" example:"toko-budi"`
+    URLFoto     string               `json:"url_foto" example:"https://files.local/uploads/stores/toko-1.jpg"`
+    FotoSizes   map[string]ImageSize `json:"foto_sizes"`
+    Bergabung   string               `json:"bergabung" example:"2025-01-01T10:00:00+07:00"`
+    Stats       StoreStats           `json:"stats"`
+    Operasional StoreOperasional     `json:"operasional"`
+    DikirimDari *StoreOrigin         `json:"dikirim_dari"`
+    Verified    bool                 `json:"verified" example:"true"`
+    Products    StoreProductListData `json:"products"`
+    Diikuti     *bool                `json:"diikuti,omitempty" example:"true"` // only with a token
+}
+
+// swagger:model
+type StorePageResponse struct {
+    Status  bool      `json:"status" example:"true"`
+    Message string    `json:"message" example:"Succeed to GET data"`
+    Errors  []string  `json:"errors" example:""`
+    Data    StorePage `json:"data"`
+}
+
+// @Summary Get store page by ID
+// @Description Halaman toko: info toko, tanggal bergabung, statistik (jumlah produk published, total terjual, rata-rata rating dari ulasan pembeli) dan satu halaman produk toko dengan filter yang sama seperti GET /product. Dengan token, pemilik toko juga melihat produknya yang belum published dan diikuti menunjukkan apakah user mengikuti toko
+// @Tags Toko
+// @Produce json
+// @Param id_toko path integer true "Store ID" example(5)
+// @Param limit query integer false "Results per page" default(10) example(10)
+// @Param page query integer false "Page number" default(1) example(1)
+// @Param nama_produk query string false "Filter by product name" example(Kemeja)
+// @Param category_id query integer false "Filter by category ID" example(2)
+// @Param min_harga query integer false "Minimum price filter" example(50000)
+// @Param max_harga query integer false "Maximum price filter" example(150000)
+// @Param attr[kode] query string false "Filter by attribute of the category schema, e.g. attr[bahan]=Katun,Linen (any of the comma separated values; max 10 attr filters)"
+// @Success 200 {object} StorePageResponse "Store page"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Router /toko/{id_toko} [get]
+func SwaggerTokoGetByID() {}
+
+// @Summary List store products
+// @Description Produk sebuah toko dengan filter yang sama seperti GET /product, dengan pagination. Dengan token, pemilik toko juga melihat produknya yang belum published
+// @Tags Toko
+// @Produce json
+// @Param id_toko path integer true "Store ID" example(5)
+// @Param limit query integer false "Results per page" default(10) example(10)
+// @Param page query integer false "Page number" default(1) example(1)
+// @Param nama_produk query string false "Filter by product name" example(Kemeja)
+// @Param category_id query integer false "Filter by category ID" example(2)
+// @Param min_harga query integer false "Minimum price filter" example(50000)
+// @Param max_harga query integer false "Maximum price filter" example(150000)
+// @Param attr[kode] query string false "Filter by attribute of the category schema, e.g. attr[bahan]=Katun,Linen (any of the comma separated values; max 10 attr filters)"
+// @Success 200 {object} StoreProductListResponse "Store products"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Router /toko/{id_toko}/products [get]
+func SwaggerTokoListProducts() {}
+
+// @Summary Get store page by slug
+// @Description Halaman toko berdasarkan slug, sama seperti GET /toko/{id_toko}. Slug lama (sebelum toko di-rename) dialihkan dengan 301 ke slug saat ini
+// @Tags Toko
+// @Produce json
+// @Param slug path string true "Store slug" example(toko-budi)
+// @Param limit query integer false "Results per page" default(10) example(10)
+// @Param page query integer false "Page number" default(1) example(1)
+// @Param nama_produk query string false "Filter by product name" example(Kemeja)
+// @Param category_id query integer false "Filter by category ID" example(2)
+// @Param min_harga query integer false "Minimum price filter" example(50000)
+// @Param max_harga query integer false "Maximum price filter" example(150000)
+// @Param attr[kode] query string false "Filter by attribute of the category schema, e.g. attr[bahan]=Katun,Linen (any of the comma separated values; max 10 attr filters)"
+// @Success 200 {object} StorePageResponse "Store page"
+// @Success 301 {string} string "Redirect to the current slug"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Router /toko/slug/{slug} [get]
+func SwaggerTokoGetBySlug() {}
+
+// swagger:model
+type StoreFollow struct {
+    IDToko   uint  `json:"id_toko" example:"5"`
+    Diikuti  bool  `json:"diikuti" example:"true"`
+    Pengikut int64 `json:"pengikut" example:"121"`
+}
+
+// swagger:model
+type StoreFollowResponse struct {
+    Status  bool        `json:"status" example:"true"`
+    Message string      `json:"message" example:"Succeed to POST data"`
+    Errors  []string    `json:"errors" example:""`
+    Data    StoreFollow `json:"data"`
+}
+
+// @Summary Follow store
+// @Description Ikuti toko; produk baru toko yang diikuti tampil di GET /user/feed. Mengikuti toko yang sudah diikuti tidak error
+// @Tags Toko
+// @Security BearerAuth
+// @Produce json
+// @Param id_toko path integer true "Store ID" example(5)
+// @Success 200 {object} StoreFollowResponse "Following"
+// @Failure 400 {object} ErrorResponse "Invalid id_toko"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Store not found"
+// @Router /toko/{id_toko}/follow [post]
+func SwaggerTokoFollow() {}
+
+// @Summary Unfollow store
+// @Description Berhenti mengikuti toko
+// @Tags Toko
+// @Security BearerAuth
+// @Produce json
+// @Param id_toko path integer true "Store ID" example(5)
+// @Success 200 {object} StoreFollowResponse "Not following"
+// @Failure 400 {object} ErrorResponse "Invalid id_toko"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Store not found"
+// @Router /toko/{id_toko}/follow [delete]
+func SwaggerTokoUnfollow() {}
+
+// @Summary List stores
+// @Description Get list of all stores dengan pagination dan pencarian
+// @Tags Toko
+// @Produce json
+// @Param limit query integer false "Results per page" default(10) example(10)
+// @Param page query integer false "Page number" default(1) example(1)
+// @Param nama query string false "Filter by store name" example(Budi)
+// @Param verified query boolean false "Only verified (true) or unverified (false) stores" example(true)
+// @Success 200 {object} APIResponseString "List of stores"
+// @Failure 400 {object} ErrorResponse "Invalid verified"
+// @Router /toko [get]
+func SwaggerTokoList() {}
+
+// @Summary Get user profile
+// @Description Get current user's profile information
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} UserProfileResponse "User profile"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /user [get]
+func SwaggerUserGetProfile() {}
+
+// @Summary Update user profile
+// @Description Update current user's profile information. Mengganti kata_sandi mengakhiri semua sesi login lain milik user
+// @Tags Users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body AuthRegisterRequest true "Profile update data"
+// @Success 200 {object} APIResponseString "Update successful"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /user [put]
+func SwaggerUserUpdateProfile() {}
+
+// @Summary List user addresses
+// @Description Get list of user's delivery addresses
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Param judul_alamat query string false "Filter by address title" example(Home)
+// @Success 200 {object} APIResponseString "List of addresses"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /user/alamat [get]
+func SwaggerUserListAlamat() {}
+
+// @Summary Create address
+// @Description Create new delivery address
+// @Tags Users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body object true "Address data" SchemaExample({"judul_alamat":"Home","nama_penerima":"John Doe","no_telp":"08123456789","detail_alamat":"Jl. Sudirman No. 1"})
+// @Success 200 {object} APIResponseID "Address created"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /user/alamat [post]
+func SwaggerUserCreateAlamat() {}
+
+// @Summary Get address by ID
+// @Description Get specific address by ID
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Param id path integer true "Address ID" example(1)
+// @Success 200 {object} APIResponseString "Address details"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Router /user/alamat/{id} [get]
+func SwaggerUserGetAlamat() {}
+
+// @Summary Update address
+// @Description Update delivery address by ID
+// @Tags Users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path integer true "Address ID" example(1)
+// @Param body body object true "Address update data" SchemaExample({"judul_alamat":"Office","nama_penerima":"John Doe","no_telp":"08123456789","detail_alamat":"Jl. Thamrin No. 2"})
+// @Success 200 {object} APIResponseString "Address updated"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Router /user/alamat/{id} [put]
+func SwaggerUserUpdateAlamat() {}
+
+// @Summary Delete address
+// @Description Delete delivery address by ID
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Param id path integer true "Address ID" example(1)
+// @Success 200 {object} APIResponseString "Address deleted"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Router /user/alamat/{id} [delete]
+func SwaggerUserDeleteAlamat() {}
+
+// @Summary List login sessions
+// @Description Sesi login aktif user: perangkat, IP, user agent dan aktivitas terakhir. sesi_ini menandai sesi token yang dipakai
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} UserSessionListResponse "Sessions"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /user/sessions [get]
+func SwaggerUserListSessions() {}
+
+// @Summary Sign out a session
+// @Description Keluarkan satu perangkat; access token dan refresh token sesi itu langsung ditolak
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Param id path integer true "Session ID" example(12)
+// @Success 200 {object} AuthLogoutResponse "Session ended"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Session not found"
+// @Router /user/sessions/{id} [delete]
+func SwaggerUserRevokeSession() {}
+
+// @Summary List products
+// @Description Get list of products dengan filtering dan pagination. Hanya produk published; dengan token, produk toko sendiri (draft, pending_review, rejected) ikut tampil
+// @Tags Product
+// @Produce json
+// @Param limit query integer false "Results per page" default(10) example(10)
+// @Param page query integer false "Page number" default(1) example(1)
+// @Param nama_produk query string false "Filter by product name" example(Kemeja)
+// @Param category_id query integer false "Filter by category ID" example(2)
+// @Param toko_id query integer false "Filter by store ID" example(5)
+// @Param min_harga query integer false "Minimum price filter" example(50000)
+// @Param max_harga query integer false "Maximum price filter" example(150000)
+// @Param attr[kode] query string false "Filter by attribute of the category schema, e.g. attr[bahan]=Katun,Linen (any of the comma separated values; max 10 attr filters)"
+// @Param verified query boolean false "Only products of verified (true) or unverified (false) stores" example(true)
+// @Success 200 {object} ProductListResponse "List of products"
+// @Router /product [get]
+func SwaggerProductList() {}
+
+// @Summary Get product by ID
+// @Description Get specific product details by ID. Produk yang belum published hanya terlihat oleh pemiliknya (kirim token)
+// @Tags Product
+// @Produce json
+// @Param id path integer true "Product ID" example(10)
+// @Success 200 {object} ProductDetailResponse "Product details"
+// @Failure 404 {object} ErrorResponse "Product not found"
+// @Router /product/{id} [get]
+func SwaggerProductGetByID() {}
+
+// @Summary Get product by slug
+// @Description Get product details by slug. Slug lama (sebelum produk di-rename) dialihkan dengan 301 ke slug saat ini. Produk yang belum published hanya terlihat oleh pemiliknya (kirim token)
+// @Tags Product
+// @Produce json
+// @Param slug path string true "Product slug" example(kemeja-pria-lengan-panjang)
+// @Success 200 {object} ProductDetailResponse "Product details"
+// @Success 301 {string} string "Redirect to the current slug"
+// @Failure 404 {object} ErrorResponse "Product not found"
+// @Router /product/slug/{slug} [get]
+func SwaggerProductGetBySlug() {}
+
+// @Summary Create product
+// @Description Create new product dengan upload foto. Produk baru tidak langsung tayang: status pending_review (menunggu persetujuan admin) atau draft. Selain draft, toko harus sudah mengatur alamat asal pengiriman
+// @Tags Product
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param nama_produk formData string true "Product name" example(Kemeja Pria Lengan Panjang)
+// @Param category_id formData integer true "Category ID" example(2)
+// @Param harga_reseller formData integer true "Reseller price" example(90000)
+// @Param harga_konsumen formData integer true "Consumer price" example(120000)
+// @Param stok formData integer true "Stock quantity" example(50)
+// @Param stok_minimum formData integer false "Low stock threshold; an alert is raised when stock reaches it" default(0) example(5)
+// @Param berat formData integer true "Shipping weight per unit in grams (1-500000)" example(250)
+// @Param panjang formData integer true "Packed length in cm (1-500)" example(30)
+// @Param lebar formData integer true "Packed width in cm (1-500)" example(25)
+// @Param tinggi formData integer true "Packed height in cm (1-500)" example(3)
+// @Param deskripsi formData string false "Product description" example(Bahan katun, nyaman dipakai)
+// @Param photos formData file false "Product photos (multiple files supported; jpg, png, gif, webp; max 10MB each)"
+// @Param options formData string false "Variant options as JSON array of {nama, nilai[]}"
+// @Param variants formData string false "Variants as JSON array of {sku, nama, opsi, harga_reseller, harga_konsumen, stok, berat, panjang, lebar, tinggi}; berat/dimensi varian opsional (default ikut produk). Photos per variant: file field variant_photos[<sku>]"
+// @Param status formData string false "Moderation status" Enums(draft, pending_review) default(pending_review)
+// @Param attributes formData string false "Attributes as JSON object of kode -> nilai, validated against the category schema (GET /category/{id}/attributes)"
+// @Param X-Toko-ID header integer false "Store ID to act on" example(5)
+// @Success 200 {object} APIResponseID "Product created"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /product [post]
+func SwaggerProductCreate() {}
+
+// @Summary Update product
+// @Description Update product information dengan optional upload foto
+// @Tags Product
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path integer true "Product ID" example(10)
+// @Param nama_produk formData string false "Product name" example(Kemeja Pria Premium)
+// @Param category_id formData integer false "Category ID" example(3)
+// @Param harga_reseller formData integer false "Reseller price" example(95000)
+// @Param harga_konsumen formData integer false "Consumer price" example(125000)
+// @Param stok_minimum formData integer false "Low stock threshold" example(5)
+// @Param berat formData integer false "Shipping weight per unit in grams (1-500000)" example(250)
+// @Param panjang formData integer false "Packed length in cm (1-500)" example(30)
+// @Param lebar formData integer false "Packed width in cm (1-500)" example(25)
+// @Param tinggi formData integer false "Packed height in cm (1-500)" example(3)
+// @Param deskripsi formData string false "Product description" example(Bahan katun premium)
+// @Param photos formData file false "Product photos (multiple files supported; jpg, png, gif, webp; max 10MB each)"
+// @Param options formData string false "Variant options as JSON array (replaces current options)"
+// @Param variants formData string false "Variants as JSON array (replaces current variants, matched by sku). Photos per variant: file field variant_photos[<sku>]"
+// @Param attributes formData string false "Attributes as JSON object of kode -> nilai (replaces current attributes)"
+// @Success 200 {object} APIResponseString "Product updated"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Router /product/{id} [put]
+func SwaggerProductUpdate() {}
+
+// @Summary Delete product
+// @Description Pindahkan produk ke tempat sampah (soft delete). Bisa dipulihkan sebelum dihapus permanen setelah masa retensi
+// @Tags Product
+// @Security BearerAuth
+// @Produce json
+// @Param id path integer true "Product ID" example(10)
+// @Success 200 {object} APIResponseString "Product deleted"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Router /product/{id} [delete]
+func SwaggerProductDelete() {}
+
+// swagger:model
+type PhotoOrderRequest struct {
+    PhotoIDs       []uint `json:"photo_ids" example:"3,1,2"`
+    PrimaryPhotoID *uint  `json:"primary_photo_id" example:"3"`
+}
+
+// swagger:model
+type PhotoListResponse struct {
+    Status  bool           `json:"status" example:"true"`
+    Message string         `json:"message" example:"Succeed to PUT data"`
+    Errors  []string       `json:"errors" example:""`
+    Data    []ProductPhoto `json:"data"`
+}
+
+// @Summary Delete product photo
+// @Description Hapus satu foto produk/varian beserta filenya. Jika foto utama dihapus, foto berikutnya menjadi foto utama
+// @Tags Product
+// @Security BearerAuth
+// @Produce json
+// @Param id path integer true "Product ID" example(10)
+// @Param photo_id path integer true "Photo ID" example(3)
+// @Success 200 {object} APIResponseString "Photo deleted"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Router /product/{id}/photos/{photo_id} [delete]
+func SwaggerProductPhotoDelete() {}
+
+// @Summary Reorder product photos
+// @Description Atur urutan foto produk (photo_ids berisi semua foto produk) dan/atau foto utama (primary_photo_id)
+// @Tags Product
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path integer true "Product ID" example(10)
+// @Param body body PhotoOrderRequest true "New order and primary photo"
+// @Success 200 {object} PhotoListResponse "Photos in display order, primary first"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Router /product/{id}/photos/order [put]
+func SwaggerProductPhotoOrder() {}
+
+// swagger:model
+type TrashedProduct struct {
+    Product
+    DeletedAt string `json:"deleted_at" example:"2025-01-01T10:00:00+07:00"`
+    PurgeAt   string `json:"purge_at" example:"2025-01-31T10:00:00+07:00"`
+}
+
+// swagger:model
+type TrashListData struct {
+    Items     []TrashedProduct `json:"items"`
+    Total     int64            `json:"total" example:"1"`
+    Page      int              `json:"page" example:"1"`
+    Limit     int              `json:"limit" example:"10"`
+    TotalPage int64            `json:"total_page" example:"1"`
+}
+
+// swagger:model
+type TrashListResponse struct {
+    Status  bool          `json:"status" example:"true"`
+    Message string        `json:"message" example:"Succeed to GET data"`
+    Errors  []string      `json:"errors" example:""`
+    Data    TrashListData `json:"data"`
+}
+
+// swagger:model
+type StockAlert struct {
+    ID           uint    `json:"id" example:"4"`
+    ProductID    uint    `json:"product_id" example:"10"`
+    NamaProduk   string  `json:"nama_produk" example:"Kemeja Pria Lengan Panjang"`
+    Slug         string  `json:"slug" example:"kemeja-pria-lengan-panjang"`
+    VariantID    *uint   `json:"variant_id" example:"7"`
+    SKU          *string `json:"sku" example:"KMJ-M-PTH"`
+    NamaVarian   *string `json:"nama_varian" example:"M / Putih"`
+    Stok         int     `json:"stok" example:"2"`
+    Batas        int     `json:"batas" example:"5"`
+    StokSekarang int     `json:"stok_sekarang" example:"2"`
+    StokMinimum  int     `json:"stok_minimum" example:"5"`
+    Status       string  `json:"status" example:"open"`
+    CreatedAt    string  `json:"created_at" example:"2025-09-26T10:00:00Z"`
+    ResolvedAt   *string `json:"resolved_at"`
+}
+
+// swagger:model
+type StockAlertListData struct {
+    Items     []StockAlert `json:"items"`
+    Total     int64        `json:"total" example:"1"`
+    Page      int          `json:"page" example:"1"`
+    Limit     int          `json:"limit" example:"20"`
+    TotalPage int64        `json:"total_page" example:"1"`
+}
+
+// swagger:model
+type StockAlertListResponse struct {
+    Status  bool               `json:"status" example:"true"`
+    Message string             `json:"message" example:"Succeed to GET data"`
+    Errors  []string           `json:"errors" example:""`
+    Data    StockAlertListData `json:"data"`
+}
+
+// @Summary List low stock alerts
+// @Description Alert stok rendah toko milik user, terbaru dulu. Alert terbuka saat stok produk/varian turun ke atau di bawah stok_minimum (lewat transaksi atau penyesuaian stok) dan selesai saat stok naik lagi; satu alert per kejadian
+// @Tags Product
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "Alert status" Enums(open, resolved, all) default(open)
+// @Param limit query integer false "Results per page" default(20)
+// @Param page query integer false "Page number" default(1)
+// @Param X-Toko-ID header integer false "Store ID to act on" example(5)
+// @Success 200 {object} StockAlertListResponse "Alerts"
+// @Failure 400 {object} ErrorResponse "Invalid status"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Toko not found"
+// @Router /toko/my/alerts [get]
+func SwaggerTokoAlerts() {}
+
+// swagger:model
+type Notification struct {
+    ID        uint        `json:"id" example:"31"`
+    Tipe      string      `json:"tipe" example:"low_stock"`
+    Judul     string      `json:"judul" example:"Stok menipis: Kemeja Pria Lengan Panjang (M / Putih)"`
+    Pesan     string      `json:"pesan" example:"Stok Kemeja Pria Lengan Panjang (M / Putih) tinggal 2 (batas minimum 5)."`
+    Data      interface{} `json:"data"`
+    Dibaca    bool        `json:"dibaca" example:"false"`
+    DibacaAt  *string     `json:"dibaca_at"`
+    CreatedAt string      `json:"created_at" example:"2025-09-26T10:00:00Z"`
+}
+
+// swagger:model
+type NotificationListData struct {
+    Items     []Notification `json:"items"`
+    Total     int64          `json:"total" example:"1"`
+    Unread    int64          `json:"unread" example:"1"`
+    Page      int            `json:"page" example:"1"`
+    Limit     int            `json:"limit" example:"20"`
+    TotalPage int64          `json:"total_page" example:"1"`
+}
+
+// swagger:model
+type NotificationListResponse struct {
+    Status  bool                 `json:"status" example:"true"`
+    Message string               `json:"message" example:"Succeed to GET data"`
+    Errors  []string             `json:"errors" example:""`
+    Data    NotificationListData `json:"data"`
+}
+
+// @Summary List notifications
+// @Description Notifikasi user yang login, terbaru dulu, beserta jumlah yang belum dibaca
+// @Tags Notifications
+// @Security BearerAuth
+// @Produce json
+// @Param unread query boolean false "Only unread notifications"
+// @Param limit query integer false "Results per page" default(20)
+// @Param page query integer false "Page number" default(1)
+// @Success 200 {object} NotificationListResponse "Notifications"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /notifications [get]
+func SwaggerNotificationList() {}
+
+// @Summary Mark notification as read
+// @Tags Notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path integer true "Notification ID" example(31)
+// @Success 200 {object} APIResponseString "Marked as read"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Router /notifications/{id}/read [put]
+func SwaggerNotificationRead() {}
+
+// @Summary Mark all notifications as read
+// @Tags Notifications
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} APIResponseString "Marked as read"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /notifications/read [put]
+func SwaggerNotificationReadAll() {}
+
+// @Summary List trashed products
+// @Description Produk toko milik user yang sudah dihapus, terbaru dulu, beserta waktu penghapusan permanen
+// @Tags Product
+// @Security BearerAuth
+// @Produce json
+// @Param limit query integer false "Results per page" default(10)
+// @Param page query integer false "Page number" default(1)
+// @Param X-Toko-ID header integer false "Store ID to act on" example(5)
+// @Success 200 {object} TrashListResponse "Trashed products"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Toko not found"
+// @Router /toko/my/products/trash [get]
+func SwaggerProductTrash() {}
+
+// swagger:model
+type FeedProduct struct {
+    Product
+    CreatedAt string `json:"created_at" example:"2025-01-01T10:00:00+07:00"`
+}
+
+// swagger:model
+type FeedListData struct {
+    Items     []FeedProduct `json:"items"`
+    Total     int64         `json:"total" example:"12"`
+    Page      int           `json:"page" example:"1"`
+    Limit     int           `json:"limit" example:"10"`
+    TotalPage int64         `json:"total_page" example:"2"`
+}
+
+// swagger:model
+type FeedListResponse struct {
+    Status  bool         `json:"status" example:"true"`
+    Message string       `json:"message" example:"Succeed to GET data"`
+    Errors  []string     `json:"errors" example:""`
+    Data    FeedListData `json:"data"`
+}
+
+// @Summary Followed stores feed
+// @Description Produk published dari toko yang diikuti user, terbaru (created_at) dulu
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Param limit query integer false "Results per page" default(10)
+// @Param page query integer false "Page number" default(1)
+// @Success 200 {object} FeedListResponse "Feed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /user/feed [get]
+func SwaggerUserFeed() {}
+
+// @Summary Restore product
+// @Description Pulihkan produk dari tempat sampah
+// @Tags Product
+// @Security BearerAuth
+// @Produce json
+// @Param id path integer true "Product ID" example(10)
+// @Success 200 {object} ProductDetailResponse "Restored product"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not in trash"
+// @Router /product/{id}/restore [post]
+func SwaggerProductRestore() {}
+
+// swagger:model
+type ReviewedProduct struct {
+    Product
+    ReviewedBy *uint   `json:"reviewed_by" example:"1"`
+    ReviewedAt *string `json:"reviewed_at" example:"2025-01-02T09:00:00+07:00"`
+    UpdatedAt  string  `json:"updated_at" example:"2025-01-01T10:00:00+07:00"`
+}
+
+// swagger:model
+type ReviewListData struct {
+    Items     []ReviewedProduct `json:"items"`
+    Total     int64             `json:"total" example:"1"`
+    Page      int               `json:"page" example:"1"`
+    Limit     int               `json:"limit" example:"20"`
+    TotalPage int64             `json:"total_page" example:"1"`
+}
+
+// swagger:model
+type ReviewListResponse struct {
+    Status  bool           `json:"status" example:"true"`
+    Message string         `json:"message" example:"Succeed to GET data"`
+    Errors  []string       `json:"errors" example:""`
+    Data    ReviewListData `json:"data"`
+}
+
+// swagger:model
+type ReviewResponse struct {
+    Status  bool            `json:"status" example:"true"`
+    Message string          `json:"message" example:"Succeed to POST data"`
+    Errors  []string        `json:"errors" example:""`
+    Data    ReviewedProduct `json:"data"`
+}
+
+// swagger:model
+type ProductRejectRequest struct {
+    Alasan string `json:"alasan" example:"Foto produk tidak sesuai"`
+}
+
+// @Summary Submit product for review
+// @Description Ajukan produk draft atau rejected ke antrean review admin (status menjadi pending_review). Toko harus sudah mengatur alamat asal pengiriman
+// @Tags Product
+// @Security BearerAuth
+// @Produce json
+// @Param id path integer true "Product ID" example(10)
+// @Success 200 {object} ProductDetailResponse "Submitted product"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 409 {object} ErrorResponse "Not a draft or rejected product, or store has no origin address"
+// @Router /product/{id}/submit [post]
+func SwaggerProductSubmit() {}
+
+// @Summary List products for review
+// @Description Antrean moderasi produk (Admin only), terbaru dulu
+// @Tags Admin
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "Moderation status" Enums(pending_review, draft, published, rejected, all) default(pending_review)
+// @Param limit query integer false "Results per page" default(20)
+// @Param page query integer false "Page number" default(1)
+// @Success 200 {object} ReviewListResponse "Products"
+// @Failure 400 {object} ErrorResponse "Invalid status"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin only"
+// @Router /admin/products [get]
+func SwaggerAdminProductList() {}
+
+// @Summary Approve product
+// @Description Setujui produk pending_review sehingga tayang (published); penjual mendapat notifikasi (Admin only)
+// @Tags Admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path integer true "Product ID" example(10)
+// @Success 200 {object} ReviewResponse "Published product"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 409 {object} ErrorResponse "Not pending review"
+// @Router /admin/products/{id}/approve [post]
+func SwaggerAdminProductApprove() {}
+
+// @Summary Reject product
+// @Description Tolak produk pending_review, atau turunkan produk published, dengan alasan; penjual mendapat notifikasi dan dapat mengajukan ulang (Admin only)
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path integer true "Product ID" example(10)
+// @Param body body ProductRejectRequest true "Reason (5-500 chars)"
+// @Success 200 {object} ReviewResponse "Rejected product"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 409 {object} ErrorResponse "Not pending review or published"
+// @Router /admin/products/{id}/reject [post]
+func SwaggerAdminProductReject() {}
+
+// Store verification models
+// swagger:model
+type StoreVerificationDokumen struct {
+    Nama        string `json:"nama" example:"nib.pdf"`
+    ContentType string `json:"content_type" example:"application/pdf"`
+    Ukuran      int64  `json:"ukuran" example:"184320"`
+    URL         string `json:"url" example:"https://files.local/uploads/private/toko-verifikasi/5/1758868233503052000-0.pdf?expires=1758869133&sig=3f2a"` // expires after 15 minutes
+}
+
+// swagger:model
+type StoreVerificationToko struct {
+    ID       uint   `json:"id" example:"5"`
+    NamaToko string `json:"nama_toko" example:"Toko Budi"`
+    Slug     string `json:"slug" example:"toko-budi"`
+    URLFoto  string `json:"url_foto" example:"https://files.local/uploads/stores/toko-1.jpg"`
+    Verified bool   `json:"verified" example:"false"`
+}
+
+// swagger:model
+type StoreVerification struct {
+    ID         uint                       `json:"id" example:"3"`
+    Status     string                     `json:"status" example:"pending" enums:"pending,approved,rejected"`
+    Catatan    string                     `json:"catatan" example:"Distributor resmi merek Kemeja Nusantara"`
+    Alasan     string                     `json:"alasan" example:""` // rejection reason
+    Dokumen    []StoreVerificationDokumen `json:"dokumen"`
+    ReviewedAt *string                    `json:"reviewed_at" example:"2025-01-02T09:00:00+07:00"`
+    CreatedAt  string                     `json:"created_at" example:"2025-01-01T10:00:00+07:00"`
+    Toko       *StoreVerificationToko     `json:"toko,omitempty"` // admin queue only
+}
+
+// swagger:model
+type StoreVerificationResponse struct {
+    Status  bool              `json:"status" example:"true"`
+    Message string            `json:"message" example:"Succeed to POST data"`
+    Errors  []string          `json:"errors" example:""`
+    Data    StoreVerification `json:"data"`
+}
+
+// swagger:model
+type StoreVerificationState struct {
+    Verified   bool               `json:"verified" example:"false"`
+    VerifiedAt *string            `json:"verified_at" example:"2025-01-02T09:00:00+07:00"`
+    Pengajuan  *StoreVerification `json:"pengajuan"` // latest request, null when none
+}
+
+// swagger:model
+type StoreVerificationStateResponse struct {
+    Status  bool                   `json:"status" example:"true"`
+    Message string                 `json:"message" example:"Succeed to GET data"`
+    Errors  []string               `json:"errors" example:""`
+    Data    StoreVerificationState `json:"data"`
+}
+
+// swagger:model
+type StoreVerificationListData struct {
+    Items     []StoreVerification `json:"items"`
+    Total     int64               `json:"total" example:"1"`
+    Page      int                 `json:"page" example:"1"`
+    Limit     int                 `json:"limit" example:"20"`
+    TotalPage int64               `json:"total_page" example:"1"`
+}
+
+// swagger:model
+type StoreVerificationListResponse struct {
+    Status  bool                      `json:"status" example:"true"`
+    Message string                    `json:"message" example:"Succeed to GET data"`
+    Errors  []string                  `json:"errors" example:""`
+    Data    StoreVerificationListData `json:"data"`
+}
+
+// @Summary Request store verification
+// @Description Ajukan badge toko terverifikasi dengan dokumen pendukung (pemilik dan manager). Dokumen disimpan sebagai file private dan hanya bisa dibuka lewat link sementara. Satu toko hanya punya satu pengajuan yang menunggu review
+// @Tags Toko
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id_toko path integer true "Store ID" example(5)
+// @Param dokumen formData file true "Documents, e.g. KTP, NIB, certificate of brand ownership (multiple files supported, max 5; jpg, png or pdf; max 10MB each)"
+// @Param catatan formData string false "Note for the reviewer (max 500 chars)"
+// @Success 200 {object} StoreVerificationResponse "Request sent"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Store not found"
+// @Failure 409 {object} ErrorResponse "Already verified or a request is pending"
+// @Router /toko/{id_toko}/verifikasi [post]
+func SwaggerTokoRequestVerification() {}
+
+// @Summary Get store verification
+// @Description Status badge terverifikasi toko dan pengajuan verifikasi terakhirnya (semua anggota toko)
+// @Tags Toko
+// @Security BearerAuth
+// @Produce json
+// @Param id_toko path integer true "Store ID" example(5)
+// @Success 200 {object} StoreVerificationStateResponse "Verification"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Store not found"
+// @Router /toko/{id_toko}/verifikasi [get]
+func SwaggerTokoVerificationStatus() {}
+
+// @Summary List store verification requests
+// @Description Antrean verifikasi toko (Admin only). Pengajuan pending terlama dulu, status lain terbaru dulu
+// @Tags Admin
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "Request status" Enums(pending, approved, rejected, all) default(pending)
+// @Param limit query integer false "Results per page" default(20)
+// @Param page query integer false "Page number" default(1)
+// @Success 200 {object} StoreVerificationListResponse "Requests"
+// @Failure 400 {object} ErrorResponse "Invalid status"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin only"
+// @Router /admin/toko/verifikasi [get]
+func SwaggerAdminTokoVerificationList() {}
+
+// @Summary Approve store verification
+// @Description Setujui pengajuan verifikasi; toko mendapat badge verified dan pemilik mendapat notifikasi (Admin only)
+// @Tags Admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path integer true "Verification request ID" example(3)
+// @Success 200 {object} StoreVerificationResponse "Approved request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 409 {object} ErrorResponse "Already reviewed"
+// @Router /admin/toko/verifikasi/{id}/approve [post]
+func SwaggerAdminTokoVerificationApprove() {}
+
+// @Summary Reject store verification
+// @Description Tolak pengajuan verifikasi dengan alasan; pemilik mendapat notifikasi dan dapat mengajukan ulang (Admin only)
+// @Tags Admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path integer true "Verification request ID" example(3)
+// @Param body body ProductRejectRequest true "Reason (5-500 chars)"
+// @Success 200 {object} StoreVerificationResponse "Rejected request"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 409 {object} ErrorResponse "Already reviewed"
+// @Router /admin/toko/verifikasi/{id}/reject [post]
+func SwaggerAdminTokoVerificationReject() {}
+
+// swagger:model
+type FlashSaleProduct struct {
+    ID         uint   `json:"id" example:"10"`
+    NamaProduk string `json:"nama_produk" example:"Kemeja Pria Lengan Panjang"`
+    Slug       string `json:"slug" example:"kemeja-pria-lengan-panjang"`
+}
+
+// swagger:model
+type FlashSaleVariant struct {
+    ID   uint   `json:"id" example:"7"`
+    SKU  string `json:"sku" example:"KMJ-M-PTH"`
+    Nama string `json:"nama" example:"M / Putih"`
+}
+
+// swagger:model
+type FlashSaleItem struct {
+    ID           uint              `json:"id" example:"1"`
+    Product      FlashSaleProduct  `json:"product"`
+    Variant      *FlashSaleVariant `json:"variant"`
+    HargaNormal  int               `json:"harga_normal" example:"120000"`
+    HargaSale    int               `json:"harga_sale" example:"89000"`
+    DiskonPersen int               `json:"diskon_persen" example:"25"`
+    Kuota        int               `json:"kuota" example:"50"`
+    Terjual      int               `json:"terjual" example:"13"`
+    SisaKuota    int               `json:"sisa_kuota" example:"37"`
+}
+
+// swagger:model
+type FlashSale struct {
+    ID        uint            `json:"id" example:"4"`
+    Nama      string          `json:"nama" example:"Flash Sale 12.12"`
+    Mulai     string          `json:"mulai" example:"2025-12-12T12:00:00+07:00"`
+    Selesai   string          `json:"selesai" example:"2025-12-12T23:59:59+07:00"`
+    Status    string          `json:"status" example:"active" enums:"upcoming,active,ended"`
+    SisaDetik int64           `json:"sisa_detik" example:"5400"`
+    Items     []FlashSaleItem `json:"items"`
+}
+
+// swagger:model
+type FlashSaleResponse struct {
+    Status  bool      `json:"status" example:"true"`
+    Message string    `json:"message" example:"Succeed to GET data"`
+    Errors  []string  `json:"errors" example:""`
+    Data    FlashSale `json:"data"`
+}
+
+// swagger:model
+type FlashSaleListData struct {
+    Items     []FlashSale `json:"items"`
+    Total     int64       `json:"total" example:"1"`
+    Page      int         `json:"page" example:"1"`
+    Limit     int         `json:"limit" example:"20"`
+    TotalPage int64       `json:"total_page" example:"1"`
+}
+
+// swagger:model
+type FlashSaleListResponse struct {
+    Status  bool              `json:"status" example:"true"`
+    Message string            `json:"message" example:"Succeed to GET data"`
+    Errors  []string          `json:"errors" example:""`
+    Data    FlashSaleListData `json:"data"`
+}
+
+// swagger:model
+type FlashSaleItemInput struct {
+    ProductID uint `json:"product_id" example:"10"`
+    VariantID uint `json:"variant_id" example:"7"`
+    HargaSale int  `json:"harga_sale" example:"89000"`
+    Kuota     int  `json:"kuota" example:"50"`
+}
+
+// swagger:model
+type FlashSaleRequest struct {
+    Nama    string               `json:"nama" example:"Flash Sale 12.12"`
+    Mulai   string               `json:"mulai" example:"2025-12-12T12:00:00+07:00"`
+    Selesai string               `json:"selesai" example:"2025-12-12T23:59:59+07:00"`
+    Items   []FlashSaleItemInput `json:"items"`
+}
+
+// @Summary List flash sales
+// @Description Kampanye flash sale beserta produk, harga normal vs harga sale, sisa kuota dan hitung mundur (sisa_detik ke mulai untuk upcoming, ke selesai untuk active)
+// @Tags FlashSale
 // @Produce json
-// @Param id_toko path integer true "Store ID" example(5)
-// @Success 200 {object} APIResponseString "Store information"
-// @Failure 400 {object} ErrorResponse "Bad request"
-// @Failure 404 {object} ErrorResponse "Not found"
-// @Router /toko/{id_toko} [get]
-func SwaggerTokoGetByID() {}
-
-// @Summary List stores
-// @Description Get list of all stores dengan pagination dan pencarian
-// @Tags Toko
+// @Param status query string false "Campaign status" Enums(active, upcoming, ended, all) default(active)
+// @Param limit query integer false "Results per page" default(20)
+// @Param page query integer false "Page number" default(1)
+// @Success 200 {object} FlashSaleListResponse "Flash sales"
+// @Failure 400 {object} ErrorResponse "Invalid status"
+// @Router /flash-sales [get]
+func SwaggerFlashSaleList() {}
+
+// @Summary Get flash sale
+// @Description Detail kampanye flash sale
+// @Tags FlashSale
 // @Produce json
-// @Param limit query integer false "Results per page" default(10) example(10)
-// @Param page query integer false "Page number" default(1) example(1)
-// @Param nama query string false "Filter by store name" example(Budi)
-// @Success 200 {object} APIResponseString "List of stores"
-// @Router /toko [get]
-func SwaggerTokoList() {}
+// @Param id path integer true "Flash sale ID" example(4)
+// @Success 200 {object} FlashSaleResponse "Flash sale"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Router /flash-sales/{id} [get]
+func SwaggerFlashSaleGet() {}
 
-// @Summary Get user profile
-// @Description Get current user's profile information
-// @Tags Users
+// @Summary List flash sales (admin)
+// @Description Semua kampanye flash sale (Admin only)
+// @Tags Admin
 // @Security BearerAuth
 // @Produce json
-// @Success 200 {object} UserProfileResponse "User profile"
+// @Param status query string false "Campaign status" Enums(all, upcoming, active, ended) default(all)
+// @Param limit query integer false "Results per page" default(20)
+// @Param page query integer false "Page number" default(1)
+// @Success 200 {object} FlashSaleListResponse "Flash sales"
+// @Failure 400 {object} ErrorResponse "Invalid status"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
-// @Router /user [get]
-func SwaggerUserGetProfile() {}
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin only"
+// @Router /admin/flash-sales [get]
+func SwaggerAdminFlashSaleList() {}
 
-// @Summary Update user profile
-// @Description Update current user's profile information
-// @Tags Users
+// @Summary Create flash sale
+// @Description Jadwalkan kampanye flash sale (Admin only). Maks 7 hari dan 200 item; produk bervarian wajib per variant_id; harga_sale di bawah harga konsumen; satu produk/varian hanya di satu kampanye pada waktu yang sama
+// @Tags Admin
 // @Security BearerAuth
 // @Accept json
 // @Produce json
-// @Param body body AuthRegisterRequest true "Profile update data"
-// @Success 200 {object} APIResponseString "Update successful"
+// @Param body body FlashSaleRequest true "Campaign (mulai/selesai RFC3339)"
+// @Success 200 {object} FlashSaleResponse "Created flash sale"
 // @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
-// @Router /user [put]
-func SwaggerUserUpdateProfile() {}
-
-// @Summary List user addresses
-// @Description Get list of user's delivery addresses
-// @Tags Users
-// @Security BearerAuth
-// @Produce json
-// @Param judul_alamat query string false "Filter by address title" example(Home)
-// @Success 200 {object} APIResponseString "List of addresses"
-// @Failure 401 {object} ErrorResponse "Unauthorized"
-// @Router /user/alamat [get]
-func SwaggerUserListAlamat() {}
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin only"
+// @Router /admin/flash-sales [post]
+func SwaggerAdminFlashSaleCreate() {}
 
-// @Summary Create address
-// @Description Create new delivery address
-// @Tags Users
+// @Summary Update flash sale
+// @Description Ganti seluruh definisi kampanye yang belum dimulai (Admin only)
+// @Tags Admin
 // @Security BearerAuth
 // @Accept json
 // @Produce json
-// @Param body body object true "Address data" SchemaExample({"judul_alamat":"Home","nama_penerima":"John Doe","no_telp":"08123456789","detail_alamat":"Jl. Sudirman No. 1"})
-// @Success 200 {object} APIResponseID "Address created"
+// @Param id path integer true "Flash sale ID" example(4)
+// @Param body body FlashSaleRequest true "Campaign (mulai/selesai RFC3339)"
+// @Success 200 {object} FlashSaleResponse "Updated flash sale"
 // @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
-// @Router /user/alamat [post]
-func SwaggerUserCreateAlamat() {}
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 409 {object} ErrorResponse "Already started"
+// @Router /admin/flash-sales/{id} [put]
+func SwaggerAdminFlashSaleUpdate() {}
 
-// @Summary Get address by ID
-// @Description Get specific address by ID
-// @Tags Users
+// @Summary Delete or end flash sale
+// @Description Hapus kampanye yang belum dimulai, atau hentikan kampanye yang sedang berjalan (selesai = sekarang) (Admin only)
+// @Tags Admin
 // @Security BearerAuth
 // @Produce json
-// @Param id path integer true "Address ID" example(1)
-// @Success 200 {object} APIResponseString "Address details"
-// @Failure 400 {object} ErrorResponse "Bad request"
+// @Param id path integer true "Flash sale ID" example(4)
+// @Success 200 {object} APIResponseString "Deleted or ended"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
-// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin only"
 // @Failure 404 {object} ErrorResponse "Not found"
-// @Router /user/alamat/{id} [get]
-func SwaggerUserGetAlamat() {}
+// @Failure 409 {object} ErrorResponse "Already ended"
+// @Router /admin/flash-sales/{id} [delete]
+func SwaggerAdminFlashSaleDelete() {}
 
-// @Summary Update address
-// @Description Update delivery address by ID
-// @Tags Users
+// @Summary Export products
+// @Description Unduh seluruh katalog toko milik user (streaming). CSV berisi kolom import plus id, slug, created_at, updated_at dan bisa diedit lalu di-import ulang (baris dengan id memperbarui produk). JSON juga berisi opsi dan varian
+// @Tags Product
 // @Security BearerAuth
-// @Accept json
+// @Produce text/csv
 // @Produce json
-// @Param id path integer true "Address ID" example(1)
-// @Param body body object true "Address update data" SchemaExample({"judul_alamat":"Office","nama_penerima":"John Doe","no_telp":"08123456789","detail_alamat":"Jl. Thamrin No. 2"})
-// @Success 200 {object} APIResponseString "Address updated"
-// @Failure 400 {object} ErrorResponse "Bad request"
+// @Param format query string false "Export format" Enums(csv, json) default(csv)
+// @Param X-Toko-ID header integer false "Store ID to act on" example(5)
+// @Success 200 {file} file "Catalog file"
+// @Failure 400 {object} ErrorResponse "Invalid format"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
-// @Failure 403 {object} ErrorResponse "Forbidden"
-// @Failure 404 {object} ErrorResponse "Not found"
-// @Router /user/alamat/{id} [put]
-func SwaggerUserUpdateAlamat() {}
+// @Failure 404 {object} ErrorResponse "Toko not found"
+// @Router /toko/my/products/export [get]
+func SwaggerProductExport() {}
 
-// @Summary Delete address
-// @Description Delete delivery address by ID
-// @Tags Users
+// swagger:model
+type ImportStartData struct {
+    JobID  uint   `json:"job_id" example:"12"`
+    Status string `json:"status" example:"queued"`
+    Total  int    `json:"total" example:"250"`
+    DryRun bool   `json:"dry_run" example:"false"`
+}
+
+// swagger:model
+type ImportStartResponse struct {
+    Status  bool            `json:"status" example:"true"`
+    Message string          `json:"message" example:"Succeed to POST data"`
+    Errors  []string        `json:"errors" example:""`
+    Data    ImportStartData `json:"data"`
+}
+
+// @Summary Import products from CSV
+// @Description Import produk toko milik user dari file CSV secara asynchronous. Kolom: id (opsional, memperbarui produk), nama_produk, category (nama atau id), harga_reseller, harga_konsumen, stok, deskripsi, photo_urls (dipisah |). Maksimal 5000 baris. Pantau progres lewat GET /jobs/{id}
+// @Tags Product
 // @Security BearerAuth
+// @Accept multipart/form-data
 // @Produce json
-// @Param id path integer true "Address ID" example(1)
-// @Success 200 {object} APIResponseString "Address deleted"
-// @Failure 400 {object} ErrorResponse "Bad request"
+// @Param file formData file true "CSV file"
+// @Param dry_run query boolean false "Validate only, without creating products"
+// @Param X-Toko-ID header integer false "Store ID to act on" example(5)
+// @Success 202 {object} ImportStartResponse "Import job queued"
+// @Failure 400 {object} ErrorResponse "Invalid CSV"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
-// @Failure 403 {object} ErrorResponse "Forbidden"
-// @Failure 404 {object} ErrorResponse "Not found"
-// @Router /user/alamat/{id} [delete]
-func SwaggerUserDeleteAlamat() {}
+// @Router /toko/my/products/import [post]
+func SwaggerProductImport() {}
 
-// @Summary List products
-// @Description Get list of products dengan filtering dan pagination
-// @Tags Product
-// @Produce json
-// @Param limit query integer false "Results per page" default(10) example(10)
-// @Param page query integer false "Page number" default(1) example(1)
-// @Param nama_produk query string false "Filter by product name" example(Kemeja)
-// @Param category_id query integer false "Filter by category ID" example(2)
-// @Param toko_id query integer false "Filter by store ID" example(5)
-// @Param min_harga query integer false "Minimum price filter" example(50000)
-// @Param max_harga query integer false "Maximum price filter" example(150000)
-// @Success 200 {object} ProductListResponse "List of products"
-// @Router /product [get]
-func SwaggerProductList() {}
+// swagger:model
+type JobData struct {
+    ID             uint    `json:"id" example:"12"`
+    Tipe           string  `json:"tipe" example:"product_import"`
+    Status         string  `json:"status" example:"done"`
+    DryRun         bool    `json:"dry_run" example:"false"`
+    Total          int     `json:"total" example:"250"`
+    Processed      int     `json:"processed" example:"250"`
+    Succeeded      int     `json:"succeeded" example:"247"`
+    Failed         int     `json:"failed" example:"3"`
+    Progress       int     `json:"progress" example:"100"`
+    ErrorMessage   *string `json:"error_message"`
+    ErrorReportURL *string `json:"error_report_url" example:"http://localhost:8000/uploads/private/jobs/12/import-errors.csv?expires=1758872629&sig=Zk3..."`
+    CreatedAt      string  `json:"created_at" example:"2025-09-26T10:00:00Z"`
+    FinishedAt     *string `json:"finished_at" example:"2025-09-26T10:01:12Z"`
+}
 
-// @Summary Get product by ID
-// @Description Get specific product details by ID
-// @Tags Product
-// @Produce json
-// @Param id path integer true "Product ID" example(10)
-// @Success 200 {object} ProductDetailResponse "Product details"
-// @Failure 404 {object} ErrorResponse "Product not found"
-// @Router /product/{id} [get]
-func SwaggerProductGetByID() {}
+// swagger:model
+type JobResponse struct {
+    Status  bool     `json:"status" example:"true"`
+    Message string   `json:"message" example:"Succeed to GET data"`
+    Errors  []string `json:"errors" example:""`
+    Data    JobData  `json:"data"`
+}
 
-// @Summary Create product
-// @Description Create new product dengan upload foto
-// @Tags Product
+// @Summary Get job
+// @Description Status dan progres job background milik user. error_report_url adalah link sementara (1 jam) ke CSV baris yang gagal
+// @Tags Jobs
 // @Security BearerAuth
-// @Accept multipart/form-data
 // @Produce json
-// @Param nama_produk formData string true "Product name" example(Kemeja Pria Lengan Panjang)
-// @Param category_id formData integer true "Category ID" example(2)
-// @Param harga_reseller formData integer true "Reseller price" example(90000)
-// @Param harga_konsumen formData integer true "Consumer price" example(120000)
-// @Param stok formData integer true "Stock quantity" example(50)
-// @Param deskripsi formData string false "Product description" example(Bahan katun, nyaman dipakai)
-// @Param photos formData file false "Product photos (multiple files supported)"
-// @Success 200 {object} APIResponseID "Product created"
-// @Failure 400 {object} ErrorResponse "Bad request"
+// @Param id path integer true "Job ID" example(12)
+// @Success 200 {object} JobResponse "Job"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
-// @Router /product [post]
-func SwaggerProductCreate() {}
+// @Failure 404 {object} ErrorResponse "Job not found"
+// @Router /jobs/{id} [get]
+func SwaggerJobGet() {}
 
-// @Summary Update product
-// @Description Update product information dengan optional upload foto
+// swagger:model
+type StockAdjustRequest struct {
+    VariantID uint   `json:"variant_id" example:"7"`
+    Jumlah    *int   `json:"jumlah" example:"-2"`
+    StokBaru  *int   `json:"stok_baru" example:"40"`
+    Alasan    string `json:"alasan" example:"Barang rusak saat stock opname"`
+}
+
+// swagger:model
+type StockMovement struct {
+    ID          uint   `json:"id" example:"31"`
+    ProductID   uint   `json:"product_id" example:"10"`
+    VariantID   *uint  `json:"variant_id" example:"7"`
+    SKU         string `json:"sku" example:"KMJ-M-PTH"`
+    NamaVarian  string `json:"nama_varian" example:"M / Putih"`
+    Tipe        string `json:"tipe" example:"adjustment" enums:"initial,sale,cancel,adjustment,import"`
+    Jumlah      int    `json:"jumlah" example:"-2"`
+    StokSebelum int    `json:"stok_sebelum" example:"42"`
+    StokSesudah int    `json:"stok_sesudah" example:"40"`
+    Alasan      string `json:"alasan" example:"Barang rusak saat stock opname"`
+    UserID      *uint  `json:"user_id" example:"3"`
+    TrxID       *uint  `json:"trx_id"`
+    CreatedAt   string `json:"created_at" example:"2025-01-01T10:00:00+07:00"`
+}
+
+// swagger:model
+type StockMovementResponse struct {
+    Status  bool          `json:"status" example:"true"`
+    Message string        `json:"message" example:"Succeed to POST data"`
+    Errors  []string      `json:"errors" example:""`
+    Data    StockMovement `json:"data"`
+}
+
+// swagger:model
+type StockHistoryData struct {
+    Items     []StockMovement `json:"items"`
+    Total     int64           `json:"total" example:"1"`
+    Page      int             `json:"page" example:"1"`
+    Limit     int             `json:"limit" example:"20"`
+    TotalPage int64           `json:"total_page" example:"1"`
+}
+
+// swagger:model
+type StockHistoryResponse struct {
+    Status  bool             `json:"status" example:"true"`
+    Message string           `json:"message" example:"Succeed to GET data"`
+    Errors  []string         `json:"errors" example:""`
+    Data    StockHistoryData `json:"data"`
+}
+
+// @Summary Adjust product stock
+// @Description Ubah stok produk/varian dengan mencatat riwayat. Isi salah satu: jumlah (delta) atau stok_baru (nilai akhir)
 // @Tags Product
 // @Security BearerAuth
-// @Accept multipart/form-data
+// @Accept json
 // @Produce json
 // @Param id path integer true "Product ID" example(10)
-// @Param nama_produk formData string false "Product name" example(Kemeja Pria Premium)
-// @Param category_id formData integer false "Category ID" example(3)
-// @Param harga_reseller formData integer false "Reseller price" example(95000)
-// @Param harga_konsumen formData integer false "Consumer price" example(125000)
-// @Param stok formData integer false "Stock quantity" example(60)
-// @Param deskripsi formData string false "Product description" example(Bahan katun premium)
-// @Param photos formData file false "Product photos (multiple files supported)"
-// @Success 200 {object} APIResponseString "Product updated"
+// @Param body body StockAdjustRequest true "Stock adjustment"
+// @Success 200 {object} StockMovementResponse "Recorded movement"
 // @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Failure 403 {object} ErrorResponse "Forbidden"
 // @Failure 404 {object} ErrorResponse "Not found"
-// @Router /product/{id} [put]
-func SwaggerProductUpdate() {}
+// @Router /product/{id}/stock/adjust [post]
+func SwaggerProductStockAdjust() {}
 
-// @Summary Delete product
-// @Description Delete product by ID
+// @Summary Product stock history
+// @Description Riwayat perubahan stok produk (terbaru dulu), hanya pemilik toko
 // @Tags Product
 // @Security BearerAuth
 // @Produce json
 // @Param id path integer true "Product ID" example(10)
-// @Success 200 {object} APIResponseString "Product deleted"
-// @Failure 400 {object} ErrorResponse "Bad request"
+// @Param variant_id query integer false "Filter by variant ID"
+// @Param tipe query string false "Filter by movement type" Enums(initial,sale,cancel,adjustment,import)
+// @Param limit query integer false "Results per page" default(20)
+// @Param page query integer false "Page number" default(1)
+// @Success 200 {object} StockHistoryResponse "Stock movements"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Failure 403 {object} ErrorResponse "Forbidden"
 // @Failure 404 {object} ErrorResponse "Not found"
-// @Router /product/{id} [delete]
-func SwaggerProductDelete() {}
+// @Router /product/{id}/stock/history [get]
+func SwaggerProductStockHistory() {}
+
+// swagger:model
+type PricePoint struct {
+    HargaReseller int    `json:"harga_reseller" example:"80000"`
+    HargaKonsumen int    `json:"harga_konsumen" example:"95000"`
+    Sumber        string `json:"sumber" example:"update"`
+    BerlakuSejak  string `json:"berlaku_sejak" example:"2025-09-20T08:15:00Z"`
+}
+
+// swagger:model
+type PriceHistoryData struct {
+    Days             int          `json:"days" example:"90"`
+    HargaReseller    int          `json:"harga_reseller" example:"80000"`
+    HargaKonsumen    int          `json:"harga_konsumen" example:"95000"`
+    HargaKonsumenMin int          `json:"harga_konsumen_min" example:"89000"`
+    HargaKonsumenMax int          `json:"harga_konsumen_max" example:"120000"`
+    Items            []PricePoint `json:"items"`
+}
+
+// swagger:model
+type PriceHistoryResponse struct {
+    Status  bool             `json:"status" example:"true"`
+    Message string           `json:"message" example:"Succeed to GET data"`
+    Errors  []string         `json:"errors" example:""`
+    Data    PriceHistoryData `json:"data"`
+}
+
+// @Summary Product price history
+// @Description Perubahan harga produk (atau satu varian) dalam periode tertentu, terlama dulu. Item pertama adalah harga yang berlaku di awal periode. sumber: initial, update, order (dari snapshot transaksi), snapshot
+// @Tags Product
+// @Produce json
+// @Param id path integer true "Product ID" example(10)
+// @Param variant_id query integer false "Variant ID"
+// @Param days query integer false "Period in days (max 365)" default(90)
+// @Success 200 {object} PriceHistoryResponse "Price history"
+// @Failure 400 {object} ErrorResponse "Invalid variant"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Router /product/{id}/price-history [get]
+func SwaggerProductPriceHistory() {}
 
 // --- Address Swagger models ---
 // swagger:model
@@ -475,7 +2093,46 @@ type CategoryDetailResponse struct {
     Status  bool         `json:"status" example:"true"`
     Message string       `json:"message" example:"Succeed to GET data"`
     Errors  []string     `json:"errors" example:""`
-    Data    CategoryItem `json:"data"`
+    Data    CategoryDetail `json:"data"`
+}
+
+// swagger:model
+type CategoryDetail struct {
+    ID           uint                `json:"id" example:"1"`
+    NamaCategory string              `json:"nama_category" example:"Fashion"`
+    Attributes   []CategoryAttribute `json:"attributes"`
+}
+
+// swagger:model
+type CategoryAttribute struct {
+    ID    uint     `json:"id" example:"3"`
+    Kode  string   `json:"kode" example:"bahan"`
+    Nama  string   `json:"nama" example:"Bahan"`
+    Tipe  string   `json:"tipe" example:"enum" enums:"text,number,enum,boolean"`
+    Nilai []string `json:"nilai" example:"Katun,Linen,Polyester"`
+    Wajib bool     `json:"wajib" example:"true"`
+}
+
+// swagger:model
+type CategoryAttributeInput struct {
+    Kode  string   `json:"kode" example:"bahan"`
+    Nama  string   `json:"nama" example:"Bahan"`
+    Tipe  string   `json:"tipe" example:"enum" enums:"text,number,enum,boolean"`
+    Nilai []string `json:"nilai" example:"Katun,Linen,Polyester"`
+    Wajib bool     `json:"wajib" example:"true"`
+}
+
+// swagger:model
+type CategoryAttributesRequest struct {
+    Attributes []CategoryAttributeInput `json:"attributes"`
+}
+
+// swagger:model
+type CategoryAttributesResponse struct {
+    Status  bool                `json:"status" example:"true"`
+    Message string              `json:"message" example:"Succeed to GET data"`
+    Errors  []string            `json:"errors" example:""`
+    Data    []CategoryAttribute `json:"data"`
 }
 
 // swagger:model
@@ -520,6 +2177,26 @@ type TrxProduct struct {
     Toko          TrxToko     `json:"toko"`
     Category      TrxCategory `json:"category"`
     Photos        []TrxPhoto  `json:"photos"`
+    Variant       *TrxVariant `json:"variant,omitempty"`
+    Berat         int         `json:"berat" example:"250"`
+    Panjang       int         `json:"panjang" example:"30"`
+    Lebar         int         `json:"lebar" example:"25"`
+    Tinggi        int         `json:"tinggi" example:"3"`
+    FlashSale     *TrxFlashSale `json:"flash_sale,omitempty"`
+}
+
+// swagger:model
+type TrxFlashSale struct {
+    ID          uint `json:"id" example:"4"`
+    HargaNormal int  `json:"harga_normal" example:"120000"`
+}
+
+// swagger:model
+type TrxVariant struct {
+    ID   uint              `json:"id" example:"7"`
+    SKU  string            `json:"sku" example:"KMJ-M-PTH"`
+    Nama string            `json:"nama" example:"M / Putih"`
+    Opsi map[string]string `json:"opsi"`
 }
 
 // swagger:model
@@ -533,10 +2210,35 @@ type TrxAlamatKirim struct {
 
 // swagger:model
 type TrxDetailItem struct {
+    ID         uint       `json:"id" example:"12"`
     Product    TrxProduct `json:"product"`
     Toko       TrxToko    `json:"toko"`
     Kuantitas  int        `json:"kuantitas" example:"2"`
     HargaTotal int        `json:"harga_total" example:"240000"`
+    Ulasan     *TrxUlasan `json:"ulasan"` // null until the buyer rates the item
+}
+
+// swagger:model
+type TrxUlasan struct {
+    ID        uint   `json:"id" example:"3"`
+    Rating    int    `json:"rating" example:"5"`
+    Komentar  string `json:"komentar" example:"Bahannya adem, sesuai foto"`
+    CreatedAt string `json:"created_at" example:"2025-01-05T10:00:00+07:00"`
+}
+
+// swagger:model
+type TrxUlasanRequest struct {
+    IDDetailTrx uint   `json:"id_detail_trx" example:"12"`
+    Rating      int    `json:"rating" example:"5"`
+    Komentar    string `json:"komentar" example:"Bahannya adem, sesuai foto"`
+}
+
+// swagger:model
+type TrxUlasanResponse struct {
+    Status  bool      `json:"status" example:"true"`
+    Message string    `json:"message" example:"Succeed to POST data"`
+    Errors  []string  `json:"errors" example:""`
+    Data    TrxUlasan `json:"data"`
 }
 
 // swagger:model
@@ -547,6 +2249,19 @@ type TrxItem struct {
     MethodBayar string         `json:"method_bayar" example:"COD"`
     AlamatKirim TrxAlamatKirim `json:"alamat_kirim"`
     DetailTrx   []TrxDetailItem `json:"detail_trx"`
+    Paket       []TrxPaket      `json:"paket"`
+}
+
+// swagger:model
+type TrxPaket struct {
+    IDToko          uint   `json:"id_toko" example:"5"`
+    NamaToko        string `json:"nama_toko" example:"Toko Budi"`
+    AsalIDKota      string `json:"asal_id_kota" example:"3273"`
+    AsalKota        string `json:"asal_kota" example:"KOTA BANDUNG"`
+    Kuantitas       int    `json:"kuantitas" example:"2"`
+    Berat           int    `json:"berat" example:"500"`
+    BeratVolumetrik int    `json:"berat_volumetrik" example:"750"`
+    BeratTagihan    int    `json:"berat_tagihan" example:"750"`
 }
 
 // swagger:model
@@ -575,6 +2290,7 @@ type TransactionDetailResponse struct {
 // swagger:model
 type TransactionCreateItem struct {
     ProductID uint `json:"product_id" example:"10"`
+    VariantID uint `json:"variant_id" example:"7"`
     Kuantitas int  `json:"kuantitas" example:"2"`
 }
 
@@ -651,6 +2367,32 @@ func SwaggerCategoryList() {}
 // @Router /category/{id} [get]
 func SwaggerCategoryGetByID() {}
 
+// @Summary List category attributes
+// @Description Skema atribut produk sebuah kategori (urut tampilan)
+// @Tags Category
+// @Produce json
+// @Param id path integer true "Category ID" example(1)
+// @Success 200 {object} CategoryAttributesResponse "Attribute schema"
+// @Failure 404 {object} ErrorResponse "Category not found"
+// @Router /category/{id}/attributes [get]
+func SwaggerCategoryAttributes() {}
+
+// @Summary Replace category attributes
+// @Description Ganti seluruh skema atribut kategori (Admin only). Atribut dicocokkan lewat kode: yang ada diperbarui, yang baru ditambah, yang tidak dikirim dihapus beserta nilainya di produk. Nilai produk yang tidak lagi sesuai tipe/pilihan enum ikut dihapus
+// @Tags Category
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path integer true "Category ID" example(1)
+// @Param body body CategoryAttributesRequest true "Attribute schema (max 30)"
+// @Success 200 {object} CategoryAttributesResponse "Attribute schema"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden - Admin only"
+// @Failure 404 {object} ErrorResponse "Category not found"
+// @Router /category/{id}/attributes [put]
+func SwaggerCategoryReplaceAttributes() {}
+
 // @Summary Create category
 // @Description Create new product category (Admin only)
 // @Tags Category
@@ -708,6 +2450,22 @@ func SwaggerCategoryDelete() {}
 // @Router /trx [get]
 func SwaggerTransactionList() {}
 
+// @Summary List store orders
+// @Description Transaksi yang berisi produk toko yang dikelola user (header X-Toko-ID, atau toko milik sendiri), terbaru dulu. Hanya item toko tersebut yang ditampilkan dan harga_total adalah jumlah item itu. Untuk pemilik, manager dan staff-orders
+// @Tags Transaction
+// @Security BearerAuth
+// @Produce json
+// @Param X-Toko-ID header integer false "Store ID to act on" example(5)
+// @Param limit query integer false "Results per page" default(10) example(10)
+// @Param page query integer false "Page number" default(1) example(1)
+// @Success 200 {object} TransactionListResponse "Store orders"
+// @Failure 400 {object} ErrorResponse "Member of several stores without X-Toko-ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Role cannot handle orders"
+// @Failure 404 {object} ErrorResponse "Store not found"
+// @Router /toko/my/orders [get]
+func SwaggerTokoOrders() {}
+
 // @Summary Get transaction by ID
 // @Description Get specific transaction details by ID
 // @Tags Transaction
@@ -721,7 +2479,7 @@ func SwaggerTransactionList() {}
 func SwaggerTransactionGetByID() {}
 
 // @Summary Create transaction
-// @Description Create new transaction
+// @Description Create new transaction. Item yang sedang flash sale dibayar dengan harga sale selama kuota masih ada; kuota habis saat checkout menggagalkan transaksi (409). Produk dari toko yang sedang tutup atau libur ditolak (409) dengan keterangan kapan toko buka lagi. Kuantitas melebihi stok produk/varian juga ditolak (409)
 // @Tags Transaction
 // @Security BearerAuth
 // @Accept json
@@ -730,5 +2488,23 @@ func SwaggerTransactionGetByID() {}
 // @Success 200 {object} APIResponseID "Transaction created"
 // @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 409 {object} ErrorResponse "Flash sale quota exhausted, store closed or out of stock"
 // @Router /trx [post]
-func SwaggerTransactionCreate() {}
\ No newline at end of file
+func SwaggerTransactionCreate() {}
+
+// @Summary Rate transaction item
+// @Description Beri rating (1-5) dan komentar opsional untuk satu item transaksi milik sendiri. Setiap item hanya bisa diulas sekali; rating dihitung ke rata-rata rating toko
+// @Tags Transaction
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path integer true "Transaction ID" example(1)
+// @Param body body TrxUlasanRequest true "Rating data"
+// @Success 200 {object} TrxUlasanResponse "Rating saved"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Transaction not found"
+// @Failure 409 {object} ErrorResponse "Item already rated"
+// @Router /trx/{id}/ulasan [post]
+func SwaggerTransactionRate() {}
\ No newline at end of file
@@ -10,6 +10,8 @@ import (
     httpRouter "project-evermos/api/http"
     "project-evermos/internal/config"
     "project-evermos/internal/db"
+    "project-evermos/internal/notify"
+    "project-evermos/internal/storage"
 )
 
 // @title Evermos API Documentation
@@ -36,12 +38,23 @@ func main() {
         log.Fatal(err)
     }
 
-    app := fiber.New()
+    store, err := storage.New(cfg)
+    if err != nil {
+        log.Fatal(err)
+    }
+
+    sender, err := notify.New(cfg)
+    if err != nil {
+        log.Fatal(err)
+    }
+
+    // default body limit (4MB) is too small for photo uploads
+    app := fiber.New(fiber.Config{BodyLimit: cfg.BodyLimitMB * 1024 * 1024})
 
     // Swagger UI route
     app.Get("/swagger/*", swagger.HandlerDefault)
 
-    httpRouter.RegisterRoutes(app, gdb, cfg)
+    httpRouter.RegisterRoutes(app, gdb, cfg, store, sender)
 
     app.Get("/", func(c *fiber.Ctx) error { return c.SendString("hello world") })
 
@@ -15,39 +15,59 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Authenticate user dengan nomor telepon dan kata sandi",
-                "consumes": [
-                    "application/json"
+        "/admin/flash-sales": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Semua kampanye flash sale (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Login user",
+                "summary": "List flash sales (admin)",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.AuthLoginRequest"
-                        }
+                        "enum": [
+                            "all",
+                            "upcoming",
+                            "active",
+                            "ended"
+                        ],
+                        "type": "string",
+                        "default": "all",
+                        "description": "Campaign status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful",
+                        "description": "Flash sales",
                         "schema": {
-                            "$ref": "#/definitions/http.AuthLoginResponse"
+                            "$ref": "#/definitions/http.FlashSaleListResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid status",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
@@ -57,13 +77,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/auth/register": {
+            },
             "post": {
-                "description": "Register akun pengguna baru",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Jadwalkan kampanye flash sale (Admin only). Maks 7 hari dan 200 item; produk bervarian wajib per variant_id; harga_sale di bawah harga konsumen; satu produk/varian hanya di satu kampanye pada waktu yang sama",
                 "consumes": [
                     "application/json"
                 ],
@@ -71,25 +100,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Register user",
+                "summary": "Create flash sale",
                 "parameters": [
                     {
-                        "description": "Registration data",
+                        "description": "Campaign (mulai/selesai RFC3339)",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/http.AuthRegisterRequest"
+                            "$ref": "#/definitions/http.FlashSaleRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Registration successful",
+                        "description": "Created flash sale",
                         "schema": {
-                            "$ref": "#/definitions/http.AuthRegisterResponse"
+                            "$ref": "#/definitions/http.FlashSaleResponse"
                         }
                     },
                     "400": {
@@ -97,36 +126,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/category": {
-            "get": {
-                "description": "Get list of all product categories",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Category"
-                ],
-                "summary": "List categories",
-                "responses": {
-                    "200": {
-                        "description": "List of categories",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/http.CategoryListResponse"
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/flash-sales/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create new product category (Admin only)",
+                "description": "Ganti seluruh definisi kampanye yang belum dimulai (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -134,25 +157,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Category"
+                    "Admin"
                 ],
-                "summary": "Create category",
+                "summary": "Update flash sale",
                 "parameters": [
                     {
-                        "description": "Category data",
+                        "type": "integer",
+                        "example": 4,
+                        "description": "Flash sale ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campaign (mulai/selesai RFC3339)",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/http.CategoryCreateRequest"
+                            "$ref": "#/definitions/http.FlashSaleRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category created",
+                        "description": "Updated flash sale",
                         "schema": {
-                            "$ref": "#/definitions/http.APIResponseID"
+                            "$ref": "#/definitions/http.FlashSaleResponse"
                         }
                     },
                     "400": {
@@ -172,25 +203,40 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already started",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/category/{id}": {
-            "get": {
-                "description": "Get specific category details by ID",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hapus kampanye yang belum dimulai, atau hentikan kampanye yang sedang berjalan (selesai = sekarang) (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Category"
+                    "Admin"
                 ],
-                "summary": "Get category by ID",
+                "summary": "Delete or end flash sale",
                 "parameters": [
                     {
                         "type": "integer",
-                        "example": 1,
-                        "description": "Category ID",
+                        "example": 4,
+                        "description": "Flash sale ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -198,64 +244,92 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category details",
+                        "description": "Deleted or ended",
                         "schema": {
-                            "$ref": "#/definitions/http.CategoryDetailResponse"
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Category not found",
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already ended",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/products": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update category by ID (Admin only)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Antrean moderasi produk (Admin only), terbaru dulu",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Category"
+                    "Admin"
                 ],
-                "summary": "Update category",
+                "summary": "List products for review",
                 "parameters": [
+                    {
+                        "enum": [
+                            "pending_review",
+                            "draft",
+                            "published",
+                            "rejected",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "pending_review",
+                        "description": "Moderation status",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "example": 1,
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 20,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "Category update data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.CategoryUpdateRequest"
-                        }
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category updated",
+                        "description": "Products",
                         "schema": {
-                            "$ref": "#/definitions/http.APIResponseString"
+                            "$ref": "#/definitions/http.ReviewListResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid status",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
@@ -271,34 +345,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Category not found",
-                        "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
-                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/products/{id}/approve": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete category by ID (Admin only)",
+                "description": "Setujui produk pending_review sehingga tayang (published); penjual mendapat notifikasi (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Category"
+                    "Admin"
                 ],
-                "summary": "Delete category",
+                "summary": "Approve product",
                 "parameters": [
                     {
                         "type": "integer",
-                        "example": 1,
-                        "description": "Category ID",
+                        "example": 10,
+                        "description": "Product ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -306,15 +376,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category deleted",
-                        "schema": {
-                            "$ref": "#/definitions/http.APIResponseString"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
+                        "description": "Published product",
                         "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
+                            "$ref": "#/definitions/http.ReviewResponse"
                         }
                     },
                     "401": {
@@ -330,187 +394,150 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Category not found",
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "Health check endpoint to verify server status",
-                "produces": [
-                    "text/plain"
-                ],
-                "tags": [
-                    "Health"
-                ],
-                "summary": "Health check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "409": {
+                        "description": "Not pending review",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/http.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/product": {
-            "get": {
-                "description": "Get list of products dengan filtering dan pagination",
+        "/admin/products/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tolak produk pending_review, atau turunkan produk published, dengan alasan; penjual mendapat notifikasi dan dapat mengajukan ulang (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Admin"
                 ],
-                "summary": "List products",
+                "summary": "Reject product",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 10,
                         "example": 10,
-                        "description": "Results per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "example": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "Kemeja",
-                        "description": "Filter by product name",
-                        "name": "nama_produk",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "example": 2,
-                        "description": "Filter by category ID",
-                        "name": "category_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "example": 5,
-                        "description": "Filter by store ID",
-                        "name": "toko_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "example": 50000,
-                        "description": "Minimum price filter",
-                        "name": "min_harga",
-                        "in": "query"
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "example": 150000,
-                        "description": "Maximum price filter",
-                        "name": "max_harga",
-                        "in": "query"
+                        "description": "Reason (5-500 chars)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.ProductRejectRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of products",
+                        "description": "Rejected product",
                         "schema": {
-                            "$ref": "#/definitions/http.ProductListResponse"
+                            "$ref": "#/definitions/http.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Not pending review or published",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/toko/verifikasi": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create new product dengan upload foto",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Antrean verifikasi toko (Admin only). Pengajuan pending terlama dulu, status lain terbaru dulu",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Admin"
                 ],
-                "summary": "Create product",
+                "summary": "List store verification requests",
                 "parameters": [
                     {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected",
+                            "all"
+                        ],
                         "type": "string",
-                        "example": "Kemeja Pria Lengan Panjang",
-                        "description": "Product name",
-                        "name": "nama_produk",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "example": 2,
-                        "description": "Category ID",
-                        "name": "category_id",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "example": 90000,
-                        "description": "Reseller price",
-                        "name": "harga_reseller",
-                        "in": "formData",
-                        "required": true
+                        "default": "pending",
+                        "description": "Request status",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "example": 120000,
-                        "description": "Consumer price",
-                        "name": "harga_konsumen",
-                        "in": "formData",
-                        "required": true
+                        "default": 20,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "example": 50,
-                        "description": "Stock quantity",
-                        "name": "stok",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "Bahan katun, nyaman dipakai",
-                        "description": "Product description",
-                        "name": "deskripsi",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Product photos (multiple files supported)",
-                        "name": "photos",
-                        "in": "formData"
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product created",
+                        "description": "Requests",
                         "schema": {
-                            "$ref": "#/definitions/http.APIResponseID"
+                            "$ref": "#/definitions/http.StoreVerificationListResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid status",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
@@ -520,25 +547,36 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/product/{id}": {
-            "get": {
-                "description": "Get specific product details by ID",
+        "/admin/toko/verifikasi/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Setujui pengajuan verifikasi; toko mendapat badge verified dan pemilik mendapat notifikasi (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Admin"
                 ],
-                "summary": "Get product by ID",
+                "summary": "Approve store verification",
                 "parameters": [
                     {
                         "type": "integer",
-                        "example": 10,
-                        "description": "Product ID",
+                        "example": 3,
+                        "description": "Verification request ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -546,99 +584,80 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product details",
+                        "description": "Approved request",
                         "schema": {
-                            "$ref": "#/definitions/http.ProductDetailResponse"
+                            "$ref": "#/definitions/http.StoreVerificationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Product not found",
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already reviewed",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/toko/verifikasi/{id}/reject": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update product information dengan optional upload foto",
+                "description": "Tolak pengajuan verifikasi dengan alasan; pemilik mendapat notifikasi dan dapat mengajukan ulang (Admin only)",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Admin"
                 ],
-                "summary": "Update product",
+                "summary": "Reject store verification",
                 "parameters": [
                     {
                         "type": "integer",
-                        "example": 10,
-                        "description": "Product ID",
+                        "example": 3,
+                        "description": "Verification request ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "example": "Kemeja Pria Premium",
-                        "description": "Product name",
-                        "name": "nama_produk",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "integer",
-                        "example": 3,
-                        "description": "Category ID",
-                        "name": "category_id",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "integer",
-                        "example": 95000,
-                        "description": "Reseller price",
-                        "name": "harga_reseller",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "integer",
-                        "example": 125000,
-                        "description": "Consumer price",
-                        "name": "harga_konsumen",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "integer",
-                        "example": 60,
-                        "description": "Stock quantity",
-                        "name": "stok",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "example": "Bahan katun premium",
-                        "description": "Product description",
-                        "name": "deskripsi",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Product photos (multiple files supported)",
-                        "name": "photos",
-                        "in": "formData"
+                        "description": "Reason (5-500 chars)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.ProductRejectRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product updated",
+                        "description": "Rejected request",
                         "schema": {
-                            "$ref": "#/definitions/http.APIResponseString"
+                            "$ref": "#/definitions/http.StoreVerificationResponse"
                         }
                     },
                     "400": {
@@ -654,7 +673,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Forbidden - Admin only",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
@@ -664,38 +683,45 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate user dengan nomor telepon dan kata sandi",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Delete product by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Auth"
                 ],
-                "summary": "Delete product",
+                "summary": "Login user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "example": 10,
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Login credentials",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.AuthLoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product deleted",
+                        "description": "Login successful",
                         "schema": {
-                            "$ref": "#/definitions/http.APIResponseString"
+                            "$ref": "#/definitions/http.AuthLoginResponse"
                         }
                     },
                     "400": {
@@ -709,46 +735,39 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not found",
-                        "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/provcity/detailcity/{city_id}": {
-            "get": {
-                "description": "Get detailed information about a city",
+        "/auth/logout": {
+            "post": {
+                "description": "Akhiri sesi dari refresh token; access token sesi itu langsung ditolak",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Address"
+                    "Auth"
                 ],
-                "summary": "Get city detail",
+                "summary": "Logout",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "City ID",
-                        "name": "city_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.AuthRefreshRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "City details",
+                        "description": "Logged out",
                         "schema": {
-                            "$ref": "#/definitions/http.AddressCityDetailResponse"
+                            "$ref": "#/definitions/http.AuthLogoutResponse"
                         }
                     },
                     "400": {
@@ -757,8 +776,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
-                    "502": {
-                        "description": "Bad gateway",
+                    "401": {
+                        "description": "Invalid refresh token",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
@@ -766,40 +785,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/provcity/detailprovince/{prov_id}": {
-            "get": {
-                "description": "Get detailed information about a province",
+        "/auth/logout-all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Akhiri semua sesi user di semua perangkat, termasuk sesi saat ini",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Address"
-                ],
-                "summary": "Get province detail",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Province ID",
-                        "name": "prov_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Auth"
                 ],
+                "summary": "Logout all devices",
                 "responses": {
                     "200": {
-                        "description": "Province details",
-                        "schema": {
-                            "$ref": "#/definitions/http.AddressProvinceDetailResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
+                        "description": "Sessions ended",
                         "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
+                            "$ref": "#/definitions/http.AuthLogoutAllResponse"
                         }
                     },
-                    "502": {
-                        "description": "Bad gateway",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
@@ -807,30 +816,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/provcity/listcities/{prov_id}": {
-            "get": {
-                "description": "Get list of cities in a specific province",
+        "/auth/password/forgot": {
+            "post": {
+                "description": "Kirim kode OTP 6 digit ke no_telp (SMS) atau email akun. Respons sama untuk akun yang tidak terdaftar; kode baru paling cepat 1 menit setelah kode sebelumnya dan menggantikannya",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Address"
+                    "Auth"
                 ],
-                "summary": "List cities by province",
+                "summary": "Forgot password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Province ID",
-                        "name": "prov_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Phone or email",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.AuthForgotPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of cities",
+                        "description": "Code sent if the account exists",
                         "schema": {
-                            "$ref": "#/definitions/http.AddressCitiesResponse"
+                            "$ref": "#/definitions/http.AuthForgotPasswordResponse"
                         }
                     },
                     "400": {
@@ -838,63 +852,49 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
-                    },
-                    "502": {
-                        "description": "Bad gateway",
-                        "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/provcity/listprovincies": {
-            "get": {
-                "description": "Get list of Indonesian provinces",
+        "/auth/password/reset": {
+            "post": {
+                "description": "Atur kata sandi baru dengan kode OTP. Kode berlaku singkat, maksimal 5 percobaan dan hanya sekali pakai; semua sesi login akun diakhiri",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Address"
+                    "Auth"
                 ],
-                "summary": "List provinces",
+                "summary": "Reset password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Search keyword",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "type": "integer",
-                        "description": "Results limit (0-100)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "Page number (\u003e=1)",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Code and new password",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.AuthResetPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of provinces",
+                        "description": "Password changed",
                         "schema": {
-                            "$ref": "#/definitions/http.AddressProvincesResponse"
+                            "$ref": "#/definitions/http.AuthResetPasswordResponse"
                         }
                     },
-                    "502": {
-                        "description": "Bad gateway",
+                    "400": {
+                        "description": "Bad request or wrong/expired code",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
-                    "504": {
-                        "description": "Gateway timeout",
+                    "429": {
+                        "description": "Too many attempts",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
@@ -902,75 +902,85 @@ const docTemplate = `{
                 }
             }
         },
-        "/toko": {
-            "get": {
-                "description": "Get list of all stores dengan pagination dan pencarian",
+        "/auth/refresh": {
+            "post": {
+                "description": "Tukar refresh token dengan access token dan refresh token baru. Refresh token lama langsung hangus; memakainya lagi mencabut seluruh sesi",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Toko"
+                    "Auth"
                 ],
-                "summary": "List stores",
+                "summary": "Refresh tokens",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "example": 10,
-                        "description": "Results per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "example": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "Budi",
-                        "description": "Filter by store name",
-                        "name": "nama",
-                        "in": "query"
+                        "description": "Refresh token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.AuthRefreshRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of stores",
+                        "description": "New tokens",
                         "schema": {
-                            "$ref": "#/definitions/http.APIResponseString"
+                            "$ref": "#/definitions/http.AuthTokensResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid, expired or reused refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/toko/my": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+        "/auth/register": {
+            "post": {
+                "description": "Register akun pengguna baru",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Get current user's store information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Toko"
+                    "Auth"
+                ],
+                "summary": "Register user",
+                "parameters": [
+                    {
+                        "description": "Registration data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.AuthRegisterRequest"
+                        }
+                    }
                 ],
-                "summary": "Get my store",
                 "responses": {
                     "200": {
-                        "description": "Store information",
+                        "description": "Registration successful",
                         "schema": {
-                            "$ref": "#/definitions/http.APIResponseString"
+                            "$ref": "#/definitions/http.AuthRegisterResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
@@ -978,92 +988,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/toko/{id_toko}": {
+        "/category": {
             "get": {
-                "description": "Get public store information by ID",
+                "description": "Get list of all product categories",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Toko"
-                ],
-                "summary": "Get store by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "example": 5,
-                        "description": "Store ID",
-                        "name": "id_toko",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Category"
                 ],
+                "summary": "List categories",
                 "responses": {
                     "200": {
-                        "description": "Store information",
-                        "schema": {
-                            "$ref": "#/definitions/http.APIResponseString"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not found",
+                        "description": "List of categories",
                         "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
+                            "$ref": "#/definitions/http.CategoryListResponse"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update store information dengan optional photo upload",
+                "description": "Create new product category (Admin only)",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Toko"
+                    "Category"
                 ],
-                "summary": "Update store",
+                "summary": "Create category",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "example": 5,
-                        "description": "Store ID",
-                        "name": "id_toko",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "Toko Budi",
-                        "description": "Store name",
-                        "name": "nama_toko",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Store photo (jpg, jpeg, png)",
-                        "name": "photo",
-                        "in": "formData"
+                        "description": "Category data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.CategoryCreateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Update successful",
+                        "description": "Category created",
                         "schema": {
-                            "$ref": "#/definitions/http.APIResponseString"
+                            "$ref": "#/definitions/http.APIResponseID"
                         }
                     },
                     "400": {
@@ -1079,13 +1055,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not found",
+                        "description": "Forbidden - Admin only",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
@@ -1093,61 +1063,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/trx": {
+        "/category/{id}": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get list of user's transactions with pagination",
+                "description": "Get specific category details by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transaction"
+                    "Category"
                 ],
-                "summary": "List transactions",
+                "summary": "Get category by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 10,
-                        "example": 10,
-                        "description": "Results per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
                         "example": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of transactions",
+                        "description": "Category details",
                         "schema": {
-                            "$ref": "#/definitions/http.TransactionListResponse"
+                            "$ref": "#/definitions/http.CategoryDetailResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Category not found",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create new transaction",
+                "description": "Update category by ID (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1155,25 +1112,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transaction"
+                    "Category"
                 ],
-                "summary": "Create transaction",
+                "summary": "Update category",
                 "parameters": [
                     {
-                        "description": "Transaction data",
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category update data",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/http.TransactionCreateRequest"
+                            "$ref": "#/definitions/http.CategoryUpdateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Transaction created",
+                        "description": "Category updated",
                         "schema": {
-                            "$ref": "#/definitions/http.APIResponseID"
+                            "$ref": "#/definitions/http.APIResponseString"
                         }
                     },
                     "400": {
@@ -1187,30 +1152,40 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/trx/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get specific transaction details by ID",
+                "description": "Delete category by ID (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transaction"
+                    "Category"
                 ],
-                "summary": "Get transaction by ID",
+                "summary": "Delete category",
                 "parameters": [
                     {
                         "type": "integer",
                         "example": 1,
-                        "description": "Transaction ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1218,9 +1193,15 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Transaction details",
+                        "description": "Category deleted",
                         "schema": {
-                            "$ref": "#/definitions/http.TransactionDetailResponse"
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
                     "401": {
@@ -1229,8 +1210,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Transaction not found",
+                        "description": "Category not found",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
@@ -1238,30 +1225,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/user": {
+        "/category/{id}/attributes": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get current user's profile information",
+                "description": "Skema atribut produk sebuah kategori (urut tampilan)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Category"
+                ],
+                "summary": "List category attributes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
-                        "description": "User profile",
+                        "description": "Attribute schema",
                         "schema": {
-                            "$ref": "#/definitions/http.UserProfileResponse"
+                            "$ref": "#/definitions/http.CategoryAttributesResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Category not found",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
@@ -1274,7 +1266,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update current user's profile information",
+                "description": "Ganti seluruh skema atribut kategori (Admin only). Atribut dicocokkan lewat kode: yang ada diperbarui, yang baru ditambah, yang tidak dikirim dihapus beserta nilainya di produk. Nilai produk yang tidak lagi sesuai tipe/pilihan enum ikut dihapus",
                 "consumes": [
                     "application/json"
                 ],
@@ -1282,25 +1274,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Category"
                 ],
-                "summary": "Update user profile",
+                "summary": "Replace category attributes",
                 "parameters": [
                     {
-                        "description": "Profile update data",
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Attribute schema (max 30)",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/http.AuthRegisterRequest"
+                            "$ref": "#/definitions/http.CategoryAttributesRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Update successful",
+                        "description": "Attribute schema",
                         "schema": {
-                            "$ref": "#/definitions/http.APIResponseString"
+                            "$ref": "#/definitions/http.CategoryAttributesResponse"
                         }
                     },
                     "400": {
@@ -1314,119 +1314,153 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/user/alamat": {
+        "/flash-sales": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get list of user's delivery addresses",
+                "description": "Kampanye flash sale beserta produk, harga normal vs harga sale, sisa kuota dan hitung mundur (sisa_detik ke mulai untuk upcoming, ke selesai untuk active)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "FlashSale"
                 ],
-                "summary": "List user addresses",
+                "summary": "List flash sales",
                 "parameters": [
                     {
+                        "enum": [
+                            "active",
+                            "upcoming",
+                            "ended",
+                            "all"
+                        ],
                         "type": "string",
-                        "example": "Home",
-                        "description": "Filter by address title",
-                        "name": "judul_alamat",
+                        "default": "active",
+                        "description": "Campaign status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of addresses",
+                        "description": "Flash sales",
                         "schema": {
-                            "$ref": "#/definitions/http.APIResponseString"
+                            "$ref": "#/definitions/http.FlashSaleListResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid status",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create new delivery address",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/flash-sales/{id}": {
+            "get": {
+                "description": "Detail kampanye flash sale",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "FlashSale"
                 ],
-                "summary": "Create address",
+                "summary": "Get flash sale",
                 "parameters": [
                     {
-                        "description": "Address data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "integer",
+                        "example": 4,
+                        "description": "Flash sale ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Address created",
+                        "description": "Flash sale",
                         "schema": {
-                            "$ref": "#/definitions/http.APIResponseID"
+                            "$ref": "#/definitions/http.FlashSaleResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "404": {
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Health check endpoint to verify server status",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/user/alamat/{id}": {
+        "/jobs/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get specific address by ID",
+                "description": "Status dan progres job background milik user. error_report_url adalah link sementara (1 jam) ke CSV baris yang gagal",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Jobs"
                 ],
-                "summary": "Get address by ID",
+                "summary": "Get job",
                 "parameters": [
                     {
                         "type": "integer",
-                        "example": 1,
-                        "description": "Address ID",
+                        "example": 12,
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1434,15 +1468,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Address details",
-                        "schema": {
-                            "$ref": "#/definitions/http.APIResponseString"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
+                        "description": "Job",
                         "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
+                            "$ref": "#/definitions/http.JobResponse"
                         }
                     },
                     "401": {
@@ -1451,67 +1479,57 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
-                        }
-                    },
                     "404": {
-                        "description": "Not found",
+                        "description": "Job not found",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/notifications": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update delivery address by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Notifikasi user yang login, terbaru dulu, beserta jumlah yang belum dibaca",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Notifications"
                 ],
-                "summary": "Update address",
+                "summary": "List notifications",
                 "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "example": 1,
-                        "description": "Address ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 20,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "Address update data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Address updated",
-                        "schema": {
-                            "$ref": "#/definitions/http.APIResponseString"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
+                        "description": "Notifications",
                         "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
+                            "$ref": "#/definitions/http.NotificationListResponse"
                         }
                     },
                     "401": {
@@ -1519,40 +1537,59 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                    }
+                }
+            }
+        },
+        "/notifications/read": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark all notifications as read",
+                "responses": {
+                    "200": {
+                        "description": "Marked as read",
                         "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
+                            "$ref": "#/definitions/http.APIResponseString"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/notifications/{id}/read": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete delivery address by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Notifications"
                 ],
-                "summary": "Delete address",
+                "summary": "Mark notification as read",
                 "parameters": [
                     {
                         "type": "integer",
-                        "example": 1,
-                        "description": "Address ID",
+                        "example": 31,
+                        "description": "Notification ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1560,46 +1597,6213 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Address deleted",
+                        "description": "Marked as read",
                         "schema": {
                             "$ref": "#/definitions/http.APIResponseString"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/product": {
+            "get": {
+                "description": "Get list of products dengan filtering dan pagination. Hanya produk published; dengan token, produk toko sendiri (draft, pending_review, rejected) ikut tampil",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "List products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "example": 10,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
                     },
-                    "404": {
-                        "description": "Not found",
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "example": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Kemeja",
+                        "description": "Filter by product name",
+                        "name": "nama_produk",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 2,
+                        "description": "Filter by category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Filter by store ID",
+                        "name": "toko_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 50000,
+                        "description": "Minimum price filter",
+                        "name": "min_harga",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 150000,
+                        "description": "Maximum price filter",
+                        "name": "max_harga",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by attribute of the category schema, e.g. attr[bahan]=Katun,Linen (any of the comma separated values; max 10 attr filters)",
+                        "name": "attr[kode]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "Only products of verified (true) or unverified (false) stores",
+                        "name": "verified",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of products",
                         "schema": {
-                            "$ref": "#/definitions/http.ErrorResponse"
+                            "$ref": "#/definitions/http.ProductListResponse"
                         }
                     }
                 }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create new product dengan upload foto. Produk baru tidak langsung tayang: status pending_review (menunggu persetujuan admin) atau draft. Selain draft, toko harus sudah mengatur alamat asal pengiriman",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Create product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "Kemeja Pria Lengan Panjang",
+                        "description": "Product name",
+                        "name": "nama_produk",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 2,
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 90000,
+                        "description": "Reseller price",
+                        "name": "harga_reseller",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 120000,
+                        "description": "Consumer price",
+                        "name": "harga_konsumen",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 50,
+                        "description": "Stock quantity",
+                        "name": "stok",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "example": 5,
+                        "description": "Low stock threshold; an alert is raised when stock reaches it",
+                        "name": "stok_minimum",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 250,
+                        "description": "Shipping weight per unit in grams (1-500000)",
+                        "name": "berat",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 30,
+                        "description": "Packed length in cm (1-500)",
+                        "name": "panjang",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 25,
+                        "description": "Packed width in cm (1-500)",
+                        "name": "lebar",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "Packed height in cm (1-500)",
+                        "name": "tinggi",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "Bahan katun, nyaman dipakai",
+                        "description": "Product description",
+                        "name": "deskripsi",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Product photos (multiple files supported; jpg, png, gif, webp; max 10MB each)",
+                        "name": "photos",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variant options as JSON array of {nama, nilai[]}",
+                        "name": "options",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variants as JSON array of {sku, nama, opsi, harga_reseller, harga_konsumen, stok, berat, panjang, lebar, tinggi}; berat/dimensi varian opsional (default ikut produk). Photos per variant: file field variant_photos[\u003csku\u003e]",
+                        "name": "variants",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "pending_review"
+                        ],
+                        "type": "string",
+                        "default": "pending_review",
+                        "description": "Moderation status",
+                        "name": "status",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attributes as JSON object of kode -\u003e nilai, validated against the category schema (GET /category/{id}/attributes)",
+                        "name": "attributes",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID to act on",
+                        "name": "X-Toko-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product created",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseID"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/slug/{slug}": {
+            "get": {
+                "description": "Get product details by slug. Slug lama (sebelum produk di-rename) dialihkan dengan 301 ke slug saat ini. Produk yang belum published hanya terlihat oleh pemiliknya (kirim token)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get product by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "kemeja-pria-lengan-panjang",
+                        "description": "Product slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product details",
+                        "schema": {
+                            "$ref": "#/definitions/http.ProductDetailResponse"
+                        }
+                    },
+                    "301": {
+                        "description": "Redirect to the current slug",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}": {
+            "get": {
+                "description": "Get specific product details by ID. Produk yang belum published hanya terlihat oleh pemiliknya (kirim token)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get product by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product details",
+                        "schema": {
+                            "$ref": "#/definitions/http.ProductDetailResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update product information dengan optional upload foto",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Update product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "Kemeja Pria Premium",
+                        "description": "Product name",
+                        "name": "nama_produk",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 95000,
+                        "description": "Reseller price",
+                        "name": "harga_reseller",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 125000,
+                        "description": "Consumer price",
+                        "name": "harga_konsumen",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Low stock threshold",
+                        "name": "stok_minimum",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 250,
+                        "description": "Shipping weight per unit in grams (1-500000)",
+                        "name": "berat",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 30,
+                        "description": "Packed length in cm (1-500)",
+                        "name": "panjang",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 25,
+                        "description": "Packed width in cm (1-500)",
+                        "name": "lebar",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "Packed height in cm (1-500)",
+                        "name": "tinggi",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Bahan katun premium",
+                        "description": "Product description",
+                        "name": "deskripsi",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Product photos (multiple files supported; jpg, png, gif, webp; max 10MB each)",
+                        "name": "photos",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variant options as JSON array (replaces current options)",
+                        "name": "options",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variants as JSON array (replaces current variants, matched by sku). Photos per variant: file field variant_photos[\u003csku\u003e]",
+                        "name": "variants",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attributes as JSON object of kode -\u003e nilai (replaces current attributes)",
+                        "name": "attributes",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product updated",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pindahkan produk ke tempat sampah (soft delete). Bisa dipulihkan sebelum dihapus permanen setelah masa retensi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Delete product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product deleted",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/photos/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atur urutan foto produk (photo_ids berisi semua foto produk) dan/atau foto utama (primary_photo_id)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Reorder product photos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New order and primary photo",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.PhotoOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Photos in display order, primary first",
+                        "schema": {
+                            "$ref": "#/definitions/http.PhotoListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/photos/{photo_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hapus satu foto produk/varian beserta filenya. Jika foto utama dihapus, foto berikutnya menjadi foto utama",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Delete product photo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "Photo ID",
+                        "name": "photo_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Photo deleted",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/price-history": {
+            "get": {
+                "description": "Perubahan harga produk (atau satu varian) dalam periode tertentu, terlama dulu. Item pertama adalah harga yang berlaku di awal periode. sumber: initial, update, order (dari snapshot transaksi), snapshot",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Product price history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "variant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 90,
+                        "description": "Period in days (max 365)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price history",
+                        "schema": {
+                            "$ref": "#/definitions/http.PriceHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid variant",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pulihkan produk dari tempat sampah",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Restore product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored product",
+                        "schema": {
+                            "$ref": "#/definitions/http.ProductDetailResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not in trash",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/stock/adjust": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ubah stok produk/varian dengan mencatat riwayat. Isi salah satu: jumlah (delta) atau stok_baru (nilai akhir)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Adjust product stock",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock adjustment",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.StockAdjustRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recorded movement",
+                        "schema": {
+                            "$ref": "#/definitions/http.StockMovementResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/stock/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Riwayat perubahan stok produk (terbaru dulu), hanya pemilik toko",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Product stock history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by variant ID",
+                        "name": "variant_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "initial",
+                            "sale",
+                            "cancel",
+                            "adjustment",
+                            "import"
+                        ],
+                        "type": "string",
+                        "description": "Filter by movement type",
+                        "name": "tipe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock movements",
+                        "schema": {
+                            "$ref": "#/definitions/http.StockHistoryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ajukan produk draft atau rejected ke antrean review admin (status menjadi pending_review). Toko harus sudah mengatur alamat asal pengiriman",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Submit product for review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Submitted product",
+                        "schema": {
+                            "$ref": "#/definitions/http.ProductDetailResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Not a draft or rejected product, or store has no origin address",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/provcity/detailcity/{city_id}": {
+            "get": {
+                "description": "Get detailed information about a city",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Address"
+                ],
+                "summary": "Get city detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "City ID",
+                        "name": "city_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "City details",
+                        "schema": {
+                            "$ref": "#/definitions/http.AddressCityDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad gateway",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/provcity/detailprovince/{prov_id}": {
+            "get": {
+                "description": "Get detailed information about a province",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Address"
+                ],
+                "summary": "Get province detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Province ID",
+                        "name": "prov_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Province details",
+                        "schema": {
+                            "$ref": "#/definitions/http.AddressProvinceDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad gateway",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/provcity/listcities/{prov_id}": {
+            "get": {
+                "description": "Get list of cities in a specific province",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Address"
+                ],
+                "summary": "List cities by province",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Province ID",
+                        "name": "prov_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of cities",
+                        "schema": {
+                            "$ref": "#/definitions/http.AddressCitiesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad gateway",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/provcity/listprovincies": {
+            "get": {
+                "description": "Get list of Indonesian provinces",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Address"
+                ],
+                "summary": "List provinces",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "description": "Results limit (0-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Page number (\u003e=1)",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of provinces",
+                        "schema": {
+                            "$ref": "#/definitions/http.AddressProvincesResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad gateway",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway timeout",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko": {
+            "get": {
+                "description": "Get list of all stores dengan pagination dan pencarian",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "List stores",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "example": 10,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "example": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Budi",
+                        "description": "Filter by store name",
+                        "name": "nama",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "Only verified (true) or unverified (false) stores",
+                        "name": "verified",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of stores",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid verified",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Undangan toko yang dikirim ke email atau no telp user dan masih menunggu jawaban",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "List my invitations",
+                "responses": {
+                    "200": {
+                        "description": "Invitations",
+                        "schema": {
+                            "$ref": "#/definitions/http.TokoInvitationListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/invitations/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Terima undangan; user menjadi anggota toko dengan role yang diundang",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Accept invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation accepted",
+                        "schema": {
+                            "$ref": "#/definitions/http.TokoInvitationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invitation answered, cancelled or expired",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/invitations/{id}/decline": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tolak undangan toko",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Decline invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation declined",
+                        "schema": {
+                            "$ref": "#/definitions/http.TokoInvitationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invitation answered, cancelled or expired",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/my": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Toko yang sedang dikelola user beserta role-nya: toko pada header X-Toko-ID, atau toko milik sendiri, atau satu-satunya toko tempat user menjadi anggota. data null bila user tidak punya toko",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Get my store",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID to act on",
+                        "name": "X-Toko-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Store information",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "400": {
+                        "description": "Member of several stores without X-Toko-ID",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a member of the store",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/my/alerts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Alert stok rendah toko milik user, terbaru dulu. Alert terbuka saat stok produk/varian turun ke atau di bawah stok_minimum (lewat transaksi atau penyesuaian stok) dan selesai saat stok naik lagi; satu alert per kejadian",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "List low stock alerts",
+                "parameters": [
+                    {
+                        "enum": [
+                            "open",
+                            "resolved",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "open",
+                        "description": "Alert status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID to act on",
+                        "name": "X-Toko-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Alerts",
+                        "schema": {
+                            "$ref": "#/definitions/http.StockAlertListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Toko not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/my/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Transaksi yang berisi produk toko yang dikelola user (header X-Toko-ID, atau toko milik sendiri), terbaru dulu. Hanya item toko tersebut yang ditampilkan dan harga_total adalah jumlah item itu. Untuk pemilik, manager dan staff-orders",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "List store orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID to act on",
+                        "name": "X-Toko-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "example": 10,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "example": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Store orders",
+                        "schema": {
+                            "$ref": "#/definitions/http.TransactionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Member of several stores without X-Toko-ID",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Role cannot handle orders",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/my/products/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unduh seluruh katalog toko milik user (streaming). CSV berisi kolom import plus id, slug, created_at, updated_at dan bisa diedit lalu di-import ulang (baris dengan id memperbarui produk). JSON juga berisi opsi dan varian",
+                "produces": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Export products",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID to act on",
+                        "name": "X-Toko-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Catalog file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Toko not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/my/products/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import produk toko milik user dari file CSV secara asynchronous. Kolom: id (opsional, memperbarui produk), nama_produk, category (nama atau id), harga_reseller, harga_konsumen, stok, deskripsi, photo_urls (dipisah |). Maksimal 5000 baris. Pantau progres lewat GET /jobs/{id}",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Import products from CSV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate only, without creating products",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID to act on",
+                        "name": "X-Toko-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Import job queued",
+                        "schema": {
+                            "$ref": "#/definitions/http.ImportStartResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid CSV",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/my/products/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Produk toko milik user yang sudah dihapus, terbaru dulu, beserta waktu penghapusan permanen",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "List trashed products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID to act on",
+                        "name": "X-Toko-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trashed products",
+                        "schema": {
+                            "$ref": "#/definitions/http.TrashListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Toko not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/my/stores": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Toko yang dimiliki user dan toko tempat user menjadi anggota, beserta role-nya. Pilih toko yang dikelola di endpoint /toko/my/... dan produk dengan header X-Toko-ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "List my stores",
+                "responses": {
+                    "200": {
+                        "description": "Stores",
+                        "schema": {
+                            "$ref": "#/definitions/http.MyStoreListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/slug/{slug}": {
+            "get": {
+                "description": "Halaman toko berdasarkan slug, sama seperti GET /toko/{id_toko}. Slug lama (sebelum toko di-rename) dialihkan dengan 301 ke slug saat ini",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Get store page by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "toko-budi",
+                        "description": "Store slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "example": 10,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "example": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Kemeja",
+                        "description": "Filter by product name",
+                        "name": "nama_produk",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 2,
+                        "description": "Filter by category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 50000,
+                        "description": "Minimum price filter",
+                        "name": "min_harga",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 150000,
+                        "description": "Maximum price filter",
+                        "name": "max_harga",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by attribute of the category schema, e.g. attr[bahan]=Katun,Linen (any of the comma separated values; max 10 attr filters)",
+                        "name": "attr[kode]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Store page",
+                        "schema": {
+                            "$ref": "#/definitions/http.StorePageResponse"
+                        }
+                    },
+                    "301": {
+                        "description": "Redirect to the current slug",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/{id_toko}": {
+            "get": {
+                "description": "Halaman toko: info toko, tanggal bergabung, statistik (jumlah produk published, total terjual, rata-rata rating dari ulasan pembeli) dan satu halaman produk toko dengan filter yang sama seperti GET /product. Dengan token, pemilik toko juga melihat produknya yang belum published dan diikuti menunjukkan apakah user mengikuti toko",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Get store page by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "example": 10,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "example": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Kemeja",
+                        "description": "Filter by product name",
+                        "name": "nama_produk",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 2,
+                        "description": "Filter by category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 50000,
+                        "description": "Minimum price filter",
+                        "name": "min_harga",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 150000,
+                        "description": "Maximum price filter",
+                        "name": "max_harga",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by attribute of the category schema, e.g. attr[bahan]=Katun,Linen (any of the comma separated values; max 10 attr filters)",
+                        "name": "attr[kode]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Store page",
+                        "schema": {
+                            "$ref": "#/definitions/http.StorePageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update store information dengan optional photo upload",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Update store",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "Toko Budi",
+                        "description": "Store name",
+                        "name": "nama_toko",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Store photo (jpg, png, gif, webp; max 10MB)",
+                        "name": "photo",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Update successful",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/{id_toko}/alamat": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atur alamat asal/pickup toko (pemilik dan manager). id_provinsi dan id_kota dicek ke data wilayah (GET /provcity/...) dan kota harus berada di provinsi tersebut. Wajib diatur sebelum toko bisa mengajukan produk. Publik hanya melihat kota dan provinsi (dikirim_dari); alamat lengkap tampil di GET /toko/my",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Set store origin address",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Origin address",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.StoreAlamatAsalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Address saved",
+                        "schema": {
+                            "$ref": "#/definitions/http.StoreAlamatAsalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid address",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Address service unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/{id_toko}/follow": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ikuti toko; produk baru toko yang diikuti tampil di GET /user/feed. Mengikuti toko yang sudah diikuti tidak error",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Follow store",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Following",
+                        "schema": {
+                            "$ref": "#/definitions/http.StoreFollowResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id_toko",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Berhenti mengikuti toko",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Unfollow store",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Not following",
+                        "schema": {
+                            "$ref": "#/definitions/http.StoreFollowResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id_toko",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/{id_toko}/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Undangan toko yang masih menunggu jawaban (pemilik dan manager)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "List store invitations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pending invitations",
+                        "schema": {
+                            "$ref": "#/definitions/http.TokoInvitationListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Undang user lewat email atau no telp dengan role tertentu (pemilik dan manager; hanya pemilik yang bisa mengundang manager). Undangan berlaku 7 hari; user yang sudah terdaftar mendapat notifikasi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Invite member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invitation",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.TokoInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation created",
+                        "schema": {
+                            "$ref": "#/definitions/http.TokoInvitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid kontak or role",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already a member or already invited",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/{id_toko}/invitations/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Batalkan undangan yang belum dijawab (pemilik dan manager)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Cancel invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/{id_toko}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pemilik dan anggota toko beserta role-nya. Role: manager (semua kecuali mengelola manager lain), staff-orders (pesanan), staff-catalog (produk dan stok). Bisa dilihat semua anggota",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "List store members",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Members",
+                        "schema": {
+                            "$ref": "#/definitions/http.TokoMemberListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a member",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/{id_toko}/members/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ubah role anggota toko (pemilik dan manager). Hanya pemilik yang bisa mengangkat atau mengubah manager; role pemilik tidak bisa diubah",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Change member role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 12,
+                        "description": "Member user ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.TokoMemberRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role updated",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid role",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Member not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Keluarkan anggota dari toko (pemilik dan manager; hanya pemilik yang bisa mengeluarkan manager). Anggota bisa keluar sendiri dengan user_id miliknya. Pemilik tidak bisa dikeluarkan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Remove member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 12,
+                        "description": "Member user ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member removed",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Member not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/{id_toko}/operasional": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atur jam operasional mingguan dan mode libur toko (pemilik dan manager). jam_operasional kosong = selalu buka; hari tanpa jam = tutup. Jam dalam zona_waktu toko. Mode libur dengan libur_sampai berakhir otomatis pada waktu itu. Toko yang tutup atau libur tidak bisa dipesan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Update store operating hours",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Operating hours and vacation mode",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.StoreOperasionalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Settings saved",
+                        "schema": {
+                            "$ref": "#/definitions/http.StoreOperasionalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid hours",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/{id_toko}/products": {
+            "get": {
+                "description": "Produk sebuah toko dengan filter yang sama seperti GET /product, dengan pagination. Dengan token, pemilik toko juga melihat produknya yang belum published",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "List store products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "example": 10,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "example": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Kemeja",
+                        "description": "Filter by product name",
+                        "name": "nama_produk",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 2,
+                        "description": "Filter by category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 50000,
+                        "description": "Minimum price filter",
+                        "name": "min_harga",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 150000,
+                        "description": "Maximum price filter",
+                        "name": "max_harga",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by attribute of the category schema, e.g. attr[bahan]=Katun,Linen (any of the comma separated values; max 10 attr filters)",
+                        "name": "attr[kode]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Store products",
+                        "schema": {
+                            "$ref": "#/definitions/http.StoreProductListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/toko/{id_toko}/verifikasi": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Status badge terverifikasi toko dan pengajuan verifikasi terakhirnya (semua anggota toko)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Get store verification",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Verification",
+                        "schema": {
+                            "$ref": "#/definitions/http.StoreVerificationStateResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ajukan badge toko terverifikasi dengan dokumen pendukung (pemilik dan manager). Dokumen disimpan sebagai file private dan hanya bisa dibuka lewat link sementara. Satu toko hanya punya satu pengajuan yang menunggu review",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Toko"
+                ],
+                "summary": "Request store verification",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Store ID",
+                        "name": "id_toko",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Documents, e.g. KTP, NIB, certificate of brand ownership (multiple files supported, max 5; jpg, png or pdf; max 10MB each)",
+                        "name": "dokumen",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Note for the reviewer (max 500 chars)",
+                        "name": "catatan",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Request sent",
+                        "schema": {
+                            "$ref": "#/definitions/http.StoreVerificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already verified or a request is pending",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trx": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of user's transactions with pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "List transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "example": 10,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "example": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of transactions",
+                        "schema": {
+                            "$ref": "#/definitions/http.TransactionListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create new transaction. Item yang sedang flash sale dibayar dengan harga sale selama kuota masih ada; kuota habis saat checkout menggagalkan transaksi (409). Produk dari toko yang sedang tutup atau libur ditolak (409) dengan keterangan kapan toko buka lagi. Kuantitas melebihi stok produk/varian juga ditolak (409)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "Create transaction",
+                "parameters": [
+                    {
+                        "description": "Transaction data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.TransactionCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction created",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseID"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Flash sale quota exhausted, store closed or out of stock",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trx/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get specific transaction details by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "Get transaction by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction details",
+                        "schema": {
+                            "$ref": "#/definitions/http.TransactionDetailResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trx/{id}/ulasan": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Beri rating (1-5) dan komentar opsional untuk satu item transaksi milik sendiri. Setiap item hanya bisa diulas sekali; rating dihitung ke rata-rata rating toko",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "Rate transaction item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rating data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.TrxUlasanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rating saved",
+                        "schema": {
+                            "$ref": "#/definitions/http.TrxUlasanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Item already rated",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{path}": {
+            "get": {
+                "description": "File upload (driver local). File di bawah private/ hanya bisa diakses lewat signed URL (expires \u0026 sig). Mendukung Range, ETag/If-None-Match dan Cache-Control",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Get uploaded file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "products/1758869029454234400-IMG_2867.jpg",
+                        "description": "File key",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unix expiry of a signed URL",
+                        "name": "expires",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature of a signed URL",
+                        "name": "sig",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial content (Range request)",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid or expired signature",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get current user's profile information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "User profile",
+                        "schema": {
+                            "$ref": "#/definitions/http.UserProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update current user's profile information. Mengganti kata_sandi mengakhiri semua sesi login lain milik user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "description": "Profile update data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.AuthRegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Update successful",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/alamat": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of user's delivery addresses",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List user addresses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "Home",
+                        "description": "Filter by address title",
+                        "name": "judul_alamat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of addresses",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create new delivery address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create address",
+                "parameters": [
+                    {
+                        "description": "Address data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Address created",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseID"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/alamat/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get specific address by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get address by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Address ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Address details",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update delivery address by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update address",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Address ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Address update data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Address updated",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete delivery address by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete address",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Address ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Address deleted",
+                        "schema": {
+                            "$ref": "#/definitions/http.APIResponseString"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/feed": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Produk published dari toko yang diikuti user, terbaru (created_at) dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Followed stores feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Feed",
+                        "schema": {
+                            "$ref": "#/definitions/http.FeedListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sesi login aktif user: perangkat, IP, user agent dan aktivitas terakhir. sesi_ini menandai sesi token yang dipakai",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List login sessions",
+                "responses": {
+                    "200": {
+                        "description": "Sessions",
+                        "schema": {
+                            "$ref": "#/definitions/http.UserSessionListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Keluarkan satu perangkat; access token dan refresh token sesi itu langsung ditolak",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Sign out a session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 12,
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session ended",
+                        "schema": {
+                            "$ref": "#/definitions/http.AuthLogoutResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "http.APIResponseID": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to POST data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.APIResponseString": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": ""
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to PUT data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.AddressCitiesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.CityRef"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to get data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.AddressCityDetailResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.CityRef"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to get data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.AddressProvinceDetailResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.ProvinceRef"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to get data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.AddressProvincesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProvinceRef"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to get data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.AuthForgotPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": ""
+                },
+                "no_telp": {
+                    "type": "string",
+                    "example": "08123456789"
+                }
+            }
+        },
+        "http.AuthForgotPasswordResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": "Jika akun terdaftar, kode OTP telah dikirim"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to POST data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.AuthLoginData": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "id_kota": {
+                    "$ref": "#/definitions/http.CityRef"
+                },
+                "id_provinsi": {
+                    "$ref": "#/definitions/http.ProvinceRef"
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "no_telp": {
+                    "type": "string",
+                    "example": "08123456789"
+                },
+                "pekerjaan": {
+                    "type": "string",
+                    "example": "Reseller"
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "n3Jq0c5xV2m8pL1sQ7tY4wZ9aB6dE0fG2hK5jM8rT1u"
+                },
+                "refresh_token_expires_at": {
+                    "type": "string",
+                    "example": "2025-01-08T10:00:00+07:00"
+                },
+                "tanggal_Lahir": {
+                    "type": "string",
+                    "example": "01/01/1990"
+                },
+                "tentang": {
+                    "type": "string",
+                    "example": "Saya reseller Evermos"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token_expires_at": {
+                    "type": "string",
+                    "example": "2025-01-01T10:15:00+07:00"
+                }
+            }
+        },
+        "http.AuthLoginRequest": {
+            "type": "object",
+            "properties": {
+                "kata_sandi": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "no_telp": {
+                    "type": "string",
+                    "example": "08123456789"
+                }
+            }
+        },
+        "http.AuthLoginResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.AuthLoginData"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to POST data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.AuthLogoutAllData": {
+            "type": "object",
+            "properties": {
+                "sesi_dicabut": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "http.AuthLogoutAllResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.AuthLogoutAllData"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to POST data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.AuthLogoutResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": "Logout Succeed"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to POST data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.AuthRefreshRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "n3Jq0c5xV2m8pL1sQ7tY4wZ9aB6dE0fG2hK5jM8rT1u"
+                }
+            }
+        },
+        "http.AuthRegisterRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "id_kota": {
+                    "type": "string",
+                    "example": "1101"
+                },
+                "id_provinsi": {
+                    "type": "string",
+                    "example": "11"
+                },
+                "kata_sandi": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "no_telp": {
+                    "type": "string",
+                    "example": "08123456789"
+                },
+                "pekerjaan": {
+                    "type": "string",
+                    "example": "Reseller"
+                },
+                "tanggal_Lahir": {
+                    "type": "string",
+                    "example": "01/01/1990"
+                }
+            }
+        },
+        "http.AuthRegisterResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": "Register Succeed"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to POST data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.AuthResetPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": ""
+                },
+                "kata_sandi": {
+                    "type": "string",
+                    "example": "passwordBaru123"
+                },
+                "kode": {
+                    "type": "string",
+                    "example": "482915"
+                },
+                "no_telp": {
+                    "type": "string",
+                    "example": "08123456789"
+                }
+            }
+        },
+        "http.AuthResetPasswordResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": "Reset Password Succeed"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to POST data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.AuthTokens": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "Xk2pV9qL4mT7sB1nW6cR3yF8hJ0dG5aZ2eU7iO4tQ9w"
+                },
+                "refresh_token_expires_at": {
+                    "type": "string",
+                    "example": "2025-01-08T10:00:00+07:00"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token_expires_at": {
+                    "type": "string",
+                    "example": "2025-01-01T10:15:00+07:00"
+                }
+            }
+        },
+        "http.AuthTokensResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.AuthTokens"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to POST data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.CategoryAttribute": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "kode": {
+                    "type": "string",
+                    "example": "bahan"
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "Bahan"
+                },
+                "nilai": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Katun",
+                        "Linen",
+                        "Polyester"
+                    ]
+                },
+                "tipe": {
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "number",
+                        "enum",
+                        "boolean"
+                    ],
+                    "example": "enum"
+                },
+                "wajib": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.CategoryAttributeInput": {
+            "type": "object",
+            "properties": {
+                "kode": {
+                    "type": "string",
+                    "example": "bahan"
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "Bahan"
+                },
+                "nilai": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Katun",
+                        "Linen",
+                        "Polyester"
+                    ]
+                },
+                "tipe": {
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "number",
+                        "enum",
+                        "boolean"
+                    ],
+                    "example": "enum"
+                },
+                "wajib": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.CategoryAttributesRequest": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.CategoryAttributeInput"
+                    }
+                }
+            }
+        },
+        "http.CategoryAttributesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.CategoryAttribute"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.CategoryCreateRequest": {
+            "type": "object",
+            "properties": {
+                "nama_category": {
+                    "type": "string",
+                    "example": "Fashion"
+                }
+            }
+        },
+        "http.CategoryDetail": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.CategoryAttribute"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "nama_category": {
+                    "type": "string",
+                    "example": "Fashion"
+                }
+            }
+        },
+        "http.CategoryDetailResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.CategoryDetail"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.CategoryItem": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "nama_category": {
+                    "type": "string",
+                    "example": "Fashion"
+                }
+            }
+        },
+        "http.CategoryListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.CategoryItem"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.CategoryUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "nama_category": {
+                    "type": "string",
+                    "example": "Electronics - Updated"
+                }
+            }
+        },
+        "http.CityRef": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "1101"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Kota Banda Aceh"
+                },
+                "province_id": {
+                    "type": "string",
+                    "example": "11"
+                }
+            }
+        },
+        "http.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Invalid JSON"
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Failed to POST data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "http.FeedListData": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.FeedProduct"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "total_page": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "http.FeedListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.FeedListData"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.FeedProduct": {
+            "type": "object",
+            "properties": {
+                "alasan_penolakan": {
+                    "type": "string",
+                    "example": "Foto produk tidak sesuai"
+                },
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductAttribute"
+                    }
+                },
+                "berat": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "category": {
+                    "$ref": "#/definitions/http.ProductCategory"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01T10:00:00+07:00"
+                },
+                "deskripsi": {
+                    "type": "string",
+                    "example": "Bahan katun, nyaman dipakai"
+                },
+                "flash_sale": {
+                    "$ref": "#/definitions/http.ProductFlashSale"
+                },
+                "harga_konsumen": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "harga_reseller": {
+                    "type": "integer",
+                    "example": 90000
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "lebar": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "nama_produk": {
+                    "type": "string",
+                    "example": "Kemeja Pria Lengan Panjang"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductOption"
+                    }
+                },
+                "panjang": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductPhoto"
+                    }
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "kemeja-pria-lengan-panjang"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "pending_review",
+                        "published",
+                        "rejected"
+                    ],
+                    "example": "published"
+                },
+                "stok": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "stok_minimum": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "tinggi": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "toko": {
+                    "$ref": "#/definitions/http.ProductStore"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductVariant"
+                    }
+                }
+            }
+        },
+        "http.FlashSale": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.FlashSaleItem"
+                    }
+                },
+                "mulai": {
+                    "type": "string",
+                    "example": "2025-12-12T12:00:00+07:00"
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "Flash Sale 12.12"
+                },
+                "selesai": {
+                    "type": "string",
+                    "example": "2025-12-12T23:59:59+07:00"
+                },
+                "sisa_detik": {
+                    "type": "integer",
+                    "example": 5400
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "upcoming",
+                        "active",
+                        "ended"
+                    ],
+                    "example": "active"
+                }
+            }
+        },
+        "http.FlashSaleItem": {
+            "type": "object",
+            "properties": {
+                "diskon_persen": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "harga_normal": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "harga_sale": {
+                    "type": "integer",
+                    "example": 89000
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kuota": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "product": {
+                    "$ref": "#/definitions/http.FlashSaleProduct"
+                },
+                "sisa_kuota": {
+                    "type": "integer",
+                    "example": 37
+                },
+                "terjual": {
+                    "type": "integer",
+                    "example": 13
+                },
+                "variant": {
+                    "$ref": "#/definitions/http.FlashSaleVariant"
+                }
+            }
+        },
+        "http.FlashSaleItemInput": {
+            "type": "object",
+            "properties": {
+                "harga_sale": {
+                    "type": "integer",
+                    "example": 89000
+                },
+                "kuota": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "variant_id": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "http.FlashSaleListData": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.FlashSale"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_page": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "http.FlashSaleListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.FlashSaleListData"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.FlashSaleProduct": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nama_produk": {
+                    "type": "string",
+                    "example": "Kemeja Pria Lengan Panjang"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "kemeja-pria-lengan-panjang"
+                }
+            }
+        },
+        "http.FlashSaleRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.FlashSaleItemInput"
+                    }
+                },
+                "mulai": {
+                    "type": "string",
+                    "example": "2025-12-12T12:00:00+07:00"
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "Flash Sale 12.12"
+                },
+                "selesai": {
+                    "type": "string",
+                    "example": "2025-12-12T23:59:59+07:00"
+                }
+            }
+        },
+        "http.FlashSaleResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.FlashSale"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.FlashSaleVariant": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "M / Putih"
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "KMJ-M-PTH"
+                }
+            }
+        },
+        "http.ImageSize": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer",
+                    "example": 600
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://files.local/uploads/products/1758869029454234400-IMG_2867-medium.jpg"
+                },
+                "webp": {
+                    "type": "string",
+                    "example": "https://files.local/uploads/products/1758869029454234400-IMG_2867-medium.webp"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 800
+                }
+            }
+        },
+        "http.ImportStartData": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "job_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "status": {
+                    "type": "string",
+                    "example": "queued"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 250
+                }
+            }
+        },
+        "http.ImportStartResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.ImportStartData"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to POST data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.JobData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-26T10:00:00Z"
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "error_message": {
+                    "type": "string"
+                },
+                "error_report_url": {
+                    "type": "string",
+                    "example": "http://localhost:8000/uploads/private/jobs/12/import-errors.csv?expires=1758872629\u0026sig=Zk3..."
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2025-09-26T10:01:12Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "processed": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "progress": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "status": {
+                    "type": "string",
+                    "example": "done"
+                },
+                "succeeded": {
+                    "type": "integer",
+                    "example": 247
+                },
+                "tipe": {
+                    "type": "string",
+                    "example": "product_import"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 250
+                }
+            }
+        },
+        "http.JobResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.JobData"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.MyStore": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "nama_toko": {
+                    "type": "string",
+                    "example": "Toko Budi"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "manager",
+                        "staff-orders",
+                        "staff-catalog"
+                    ],
+                    "example": "staff-orders"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "toko-budi"
+                },
+                "url_foto": {
+                    "type": "string",
+                    "example": "https://files.local/uploads/stores/toko-1.jpg"
+                },
+                "verified": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "http.MyStoreListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.MyStore"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.Notification": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-26T10:00:00Z"
+                },
+                "data": {},
+                "dibaca": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "dibaca_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "judul": {
+                    "type": "string",
+                    "example": "Stok menipis: Kemeja Pria Lengan Panjang (M / Putih)"
+                },
+                "pesan": {
+                    "type": "string",
+                    "example": "Stok Kemeja Pria Lengan Panjang (M / Putih) tinggal 2 (batas minimum 5)."
+                },
+                "tipe": {
+                    "type": "string",
+                    "example": "low_stock"
+                }
+            }
+        },
+        "http.NotificationListData": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.Notification"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "unread": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "http.NotificationListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.NotificationListData"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.PhotoListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductPhoto"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to PUT data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.PhotoOrderRequest": {
+            "type": "object",
+            "properties": {
+                "photo_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        1,
+                        2
+                    ]
+                },
+                "primary_photo_id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "http.PriceHistoryData": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "harga_konsumen": {
+                    "type": "integer",
+                    "example": 95000
+                },
+                "harga_konsumen_max": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "harga_konsumen_min": {
+                    "type": "integer",
+                    "example": 89000
+                },
+                "harga_reseller": {
+                    "type": "integer",
+                    "example": 80000
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.PricePoint"
+                    }
+                }
+            }
+        },
+        "http.PriceHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.PriceHistoryData"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.PricePoint": {
+            "type": "object",
+            "properties": {
+                "berlaku_sejak": {
+                    "type": "string",
+                    "example": "2025-09-20T08:15:00Z"
+                },
+                "harga_konsumen": {
+                    "type": "integer",
+                    "example": 95000
+                },
+                "harga_reseller": {
+                    "type": "integer",
+                    "example": 80000
+                },
+                "sumber": {
+                    "type": "string",
+                    "example": "update"
+                }
+            }
+        },
+        "http.Product": {
+            "type": "object",
+            "properties": {
+                "alasan_penolakan": {
+                    "type": "string",
+                    "example": "Foto produk tidak sesuai"
+                },
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductAttribute"
+                    }
+                },
+                "berat": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "category": {
+                    "$ref": "#/definitions/http.ProductCategory"
+                },
+                "deskripsi": {
+                    "type": "string",
+                    "example": "Bahan katun, nyaman dipakai"
+                },
+                "flash_sale": {
+                    "$ref": "#/definitions/http.ProductFlashSale"
+                },
+                "harga_konsumen": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "harga_reseller": {
+                    "type": "integer",
+                    "example": 90000
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "lebar": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "nama_produk": {
+                    "type": "string",
+                    "example": "Kemeja Pria Lengan Panjang"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductOption"
+                    }
+                },
+                "panjang": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductPhoto"
+                    }
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "kemeja-pria-lengan-panjang"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "pending_review",
+                        "published",
+                        "rejected"
+                    ],
+                    "example": "published"
+                },
+                "stok": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "stok_minimum": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "tinggi": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "toko": {
+                    "$ref": "#/definitions/http.ProductStore"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductVariant"
+                    }
+                }
+            }
+        },
+        "http.ProductAttribute": {
+            "type": "object",
+            "properties": {
+                "kode": {
+                    "type": "string",
+                    "example": "bahan"
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "Bahan"
+                },
+                "nilai": {
+                    "type": "string",
+                    "example": "Katun"
+                }
+            }
+        },
+        "http.ProductCategory": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "nama_category": {
+                    "type": "string",
+                    "example": "Fashion"
+                }
+            }
+        },
+        "http.ProductDetailResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.Product"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.ProductFlashSale": {
+            "type": "object",
+            "properties": {
+                "diskon_persen": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "harga_normal": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "harga_sale": {
+                    "type": "integer",
+                    "example": 89000
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "Flash Sale 12.12"
+                },
+                "selesai": {
+                    "type": "string",
+                    "example": "2025-12-12T23:59:59+07:00"
+                },
+                "sisa_detik": {
+                    "type": "integer",
+                    "example": 5400
+                },
+                "sisa_kuota": {
+                    "type": "integer",
+                    "example": 37
+                }
+            }
+        },
+        "http.ProductListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.Product"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.ProductOption": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "Ukuran"
+                },
+                "nilai": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "S",
+                        "M",
+                        "L"
+                    ]
+                }
+            }
+        },
+        "http.ProductPhoto": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_primary": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "sizes": {
+                    "description": "original, medium, thumb",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.ImageSize"
+                    }
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://files.local/uploads/products/1758869029454234400-IMG_2867.jpg"
+                },
+                "urutan": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "http.ProductRejectRequest": {
+            "type": "object",
+            "properties": {
+                "alasan": {
+                    "type": "string",
+                    "example": "Foto produk tidak sesuai"
+                }
+            }
+        },
+        "http.ProductStore": {
+            "type": "object",
+            "properties": {
+                "dikirim_dari": {
+                    "$ref": "#/definitions/http.StoreOrigin"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "nama_toko": {
+                    "type": "string",
+                    "example": "Toko Budi"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "toko-budi"
+                },
+                "status": {
+                    "$ref": "#/definitions/http.StoreStatus"
+                },
+                "url_foto": {
+                    "type": "string",
+                    "example": "https://files.local/uploads/stores/toko-1758868233503052000.jpg"
+                },
+                "verified": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.ProductVariant": {
+            "type": "object",
+            "properties": {
+                "berat": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "flash_sale": {
+                    "$ref": "#/definitions/http.ProductFlashSale"
+                },
+                "harga_konsumen": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "harga_reseller": {
+                    "type": "integer",
+                    "example": 90000
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "lebar": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "M / Putih"
+                },
+                "opsi": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "panjang": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductPhoto"
+                    }
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "KMJ-M-PTH"
+                },
+                "stok": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "tinggi": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "http.ProvinceRef": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "11"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Aceh"
+                }
+            }
+        },
+        "http.ReviewListData": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ReviewedProduct"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_page": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "http.ReviewListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.ReviewListData"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.ReviewResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.ReviewedProduct"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to POST data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.ReviewedProduct": {
+            "type": "object",
+            "properties": {
+                "alasan_penolakan": {
+                    "type": "string",
+                    "example": "Foto produk tidak sesuai"
+                },
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductAttribute"
+                    }
+                },
+                "berat": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "category": {
+                    "$ref": "#/definitions/http.ProductCategory"
+                },
+                "deskripsi": {
+                    "type": "string",
+                    "example": "Bahan katun, nyaman dipakai"
+                },
+                "flash_sale": {
+                    "$ref": "#/definitions/http.ProductFlashSale"
+                },
+                "harga_konsumen": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "harga_reseller": {
+                    "type": "integer",
+                    "example": 90000
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "lebar": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "nama_produk": {
+                    "type": "string",
+                    "example": "Kemeja Pria Lengan Panjang"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductOption"
+                    }
+                },
+                "panjang": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductPhoto"
+                    }
+                },
+                "reviewed_at": {
+                    "type": "string",
+                    "example": "2025-01-02T09:00:00+07:00"
+                },
+                "reviewed_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "kemeja-pria-lengan-panjang"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "pending_review",
+                        "published",
+                        "rejected"
+                    ],
+                    "example": "published"
+                },
+                "stok": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "stok_minimum": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "tinggi": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "toko": {
+                    "$ref": "#/definitions/http.ProductStore"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-01T10:00:00+07:00"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductVariant"
+                    }
+                }
+            }
+        },
+        "http.StockAdjustRequest": {
+            "type": "object",
+            "properties": {
+                "alasan": {
+                    "type": "string",
+                    "example": "Barang rusak saat stock opname"
+                },
+                "jumlah": {
+                    "type": "integer",
+                    "example": -2
+                },
+                "stok_baru": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "variant_id": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "http.StockAlert": {
+            "type": "object",
+            "properties": {
+                "batas": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-26T10:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "nama_produk": {
+                    "type": "string",
+                    "example": "Kemeja Pria Lengan Panjang"
+                },
+                "nama_varian": {
+                    "type": "string",
+                    "example": "M / Putih"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "KMJ-M-PTH"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "kemeja-pria-lengan-panjang"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "open"
+                },
+                "stok": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "stok_minimum": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "stok_sekarang": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "variant_id": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "http.StockAlertListData": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.StockAlert"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_page": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "http.StockAlertListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.StockAlertListData"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.StockHistoryData": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.StockMovement"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_page": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "http.StockHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.StockHistoryData"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.StockMovement": {
+            "type": "object",
+            "properties": {
+                "alasan": {
+                    "type": "string",
+                    "example": "Barang rusak saat stock opname"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01T10:00:00+07:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "jumlah": {
+                    "type": "integer",
+                    "example": -2
+                },
+                "nama_varian": {
+                    "type": "string",
+                    "example": "M / Putih"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "KMJ-M-PTH"
+                },
+                "stok_sebelum": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "stok_sesudah": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "tipe": {
+                    "type": "string",
+                    "enum": [
+                        "initial",
+                        "sale",
+                        "cancel",
+                        "adjustment",
+                        "import"
+                    ],
+                    "example": "adjustment"
+                },
+                "trx_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "variant_id": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "http.StockMovementResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.StockMovement"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to POST data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.StoreAlamatAsal": {
+            "type": "object",
+            "properties": {
+                "detail_alamat": {
+                    "type": "string",
+                    "example": "Jl. Merdeka No. 10, Sumur Bandung"
+                },
+                "id_kota": {
+                    "type": "string",
+                    "example": "3273"
+                },
+                "id_provinsi": {
+                    "type": "string",
+                    "example": "32"
+                },
+                "kode_pos": {
+                    "type": "string",
+                    "example": "40111"
+                },
+                "nama_kota": {
+                    "type": "string",
+                    "example": "KOTA BANDUNG"
+                },
+                "nama_provinsi": {
+                    "type": "string",
+                    "example": "JAWA BARAT"
+                }
             }
-        }
-    },
-    "definitions": {
-        "http.APIResponseID": {
+        },
+        "http.StoreAlamatAsalRequest": {
+            "type": "object",
+            "properties": {
+                "detail_alamat": {
+                    "type": "string",
+                    "example": "Jl. Merdeka No. 10, Sumur Bandung"
+                },
+                "id_kota": {
+                    "type": "string",
+                    "example": "3273"
+                },
+                "id_provinsi": {
+                    "type": "string",
+                    "example": "32"
+                },
+                "kode_pos": {
+                    "type": "string",
+                    "example": "40111"
+                }
+            }
+        },
+        "http.StoreAlamatAsalResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "integer",
-                    "example": 10
+                    "$ref": "#/definitions/http.StoreAlamatAsal"
                 },
                 "errors": {
                     "type": "array",
@@ -1612,7 +7816,7 @@ const docTemplate = `{
                 },
                 "message": {
                     "type": "string",
-                    "example": "Succeed to POST data"
+                    "example": "Succeed to UPDATE data"
                 },
                 "status": {
                     "type": "boolean",
@@ -1620,12 +7824,28 @@ const docTemplate = `{
                 }
             }
         },
-        "http.APIResponseString": {
+        "http.StoreFollow": {
+            "type": "object",
+            "properties": {
+                "diikuti": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id_toko": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "pengikut": {
+                    "type": "integer",
+                    "example": 121
+                }
+            }
+        },
+        "http.StoreFollowResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "string",
-                    "example": ""
+                    "$ref": "#/definitions/http.StoreFollow"
                 },
                 "errors": {
                     "type": "array",
@@ -1638,7 +7858,7 @@ const docTemplate = `{
                 },
                 "message": {
                     "type": "string",
-                    "example": "Succeed to PUT data"
+                    "example": "Succeed to POST data"
                 },
                 "status": {
                     "type": "boolean",
@@ -1646,39 +7866,90 @@ const docTemplate = `{
                 }
             }
         },
-        "http.AddressCitiesResponse": {
+        "http.StoreJam": {
             "type": "object",
             "properties": {
-                "data": {
+                "buka": {
+                    "type": "string",
+                    "example": "08:00"
+                },
+                "hari": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "tutup": {
+                    "type": "string",
+                    "example": "17:00"
+                }
+            }
+        },
+        "http.StoreOperasional": {
+            "type": "object",
+            "properties": {
+                "jam_operasional": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/http.CityRef"
+                        "$ref": "#/definitions/http.StoreJam"
                     }
                 },
-                "errors": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        ""
-                    ]
+                "libur": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "message": {
+                "libur_sampai": {
                     "type": "string",
-                    "example": "Succeed to get data"
+                    "example": "2025-04-07T00:00:00+07:00"
+                },
+                "pesan_libur": {
+                    "type": "string",
+                    "example": "Libur lebaran"
                 },
                 "status": {
+                    "$ref": "#/definitions/http.StoreStatus"
+                },
+                "zona_waktu": {
+                    "type": "string",
+                    "example": "WIB"
+                }
+            }
+        },
+        "http.StoreOperasionalRequest": {
+            "type": "object",
+            "properties": {
+                "jam_operasional": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.StoreJam"
+                    }
+                },
+                "libur": {
                     "type": "boolean",
                     "example": true
+                },
+                "libur_sampai": {
+                    "type": "string",
+                    "example": "2025-04-07T00:00:00+07:00"
+                },
+                "pesan_libur": {
+                    "type": "string",
+                    "example": "Libur lebaran"
+                },
+                "zona_waktu": {
+                    "type": "string",
+                    "enum": [
+                        "WIB",
+                        "WITA",
+                        "WIT"
+                    ],
+                    "example": "WIB"
                 }
             }
         },
-        "http.AddressCityDetailResponse": {
+        "http.StoreOperasionalResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/http.CityRef"
+                    "$ref": "#/definitions/http.StoreOperasional"
                 },
                 "errors": {
                     "type": "array",
@@ -1691,7 +7962,7 @@ const docTemplate = `{
                 },
                 "message": {
                     "type": "string",
-                    "example": "Succeed to get data"
+                    "example": "Succeed to UPDATE data"
                 },
                 "status": {
                     "type": "boolean",
@@ -1699,11 +7970,83 @@ const docTemplate = `{
                 }
             }
         },
-        "http.AddressProvinceDetailResponse": {
+        "http.StoreOrigin": {
+            "type": "object",
+            "properties": {
+                "id_kota": {
+                    "type": "string",
+                    "example": "3273"
+                },
+                "id_provinsi": {
+                    "type": "string",
+                    "example": "32"
+                },
+                "nama_kota": {
+                    "type": "string",
+                    "example": "KOTA BANDUNG"
+                },
+                "nama_provinsi": {
+                    "type": "string",
+                    "example": "JAWA BARAT"
+                }
+            }
+        },
+        "http.StorePage": {
+            "type": "object",
+            "properties": {
+                "bergabung": {
+                    "type": "string",
+                    "example": "2025-01-01T10:00:00+07:00"
+                },
+                "diikuti": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "dikirim_dari": {
+                    "$ref": "#/definitions/http.StoreOrigin"
+                },
+                "foto_sizes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/http.ImageSize"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "nama_toko": {
+                    "type": "string",
+                    "example": "Toko Budi"
+                },
+                "operasional": {
+                    "$ref": "#/definitions/http.StoreOperasional"
+                },
+                "products": {
+                    "$ref": "#/definitions/http.StoreProductListData"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "toko-budi"
+                },
+                "stats": {
+                    "$ref": "#/definitions/http.StoreStats"
+                },
+                "url_foto": {
+                    "type": "string",
+                    "example": "https://files.local/uploads/stores/toko-1.jpg"
+                },
+                "verified": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.StorePageResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/http.ProvinceRef"
+                    "$ref": "#/definitions/http.StorePage"
                 },
                 "errors": {
                     "type": "array",
@@ -1716,7 +8059,7 @@ const docTemplate = `{
                 },
                 "message": {
                     "type": "string",
-                    "example": "Succeed to get data"
+                    "example": "Succeed to GET data"
                 },
                 "status": {
                     "type": "boolean",
@@ -1724,15 +8067,39 @@ const docTemplate = `{
                 }
             }
         },
-        "http.AddressProvincesResponse": {
+        "http.StoreProductListData": {
             "type": "object",
             "properties": {
-                "data": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/http.ProvinceRef"
+                        "$ref": "#/definitions/http.Product"
                     }
                 },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_page": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "http.StoreProductListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.StoreProductListData"
+                },
                 "errors": {
                     "type": "array",
                     "items": {
@@ -1744,7 +8111,7 @@ const docTemplate = `{
                 },
                 "message": {
                     "type": "string",
-                    "example": "Succeed to get data"
+                    "example": "Succeed to GET data"
                 },
                 "status": {
                     "type": "boolean",
@@ -1752,126 +8119,148 @@ const docTemplate = `{
                 }
             }
         },
-        "http.AuthLoginData": {
+        "http.StoreStats": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "john@example.com"
-                },
-                "id_kota": {
-                    "$ref": "#/definitions/http.CityRef"
-                },
-                "id_provinsi": {
-                    "$ref": "#/definitions/http.ProvinceRef"
-                },
-                "nama": {
-                    "type": "string",
-                    "example": "John Doe"
-                },
-                "no_telp": {
-                    "type": "string",
-                    "example": "08123456789"
+                "jumlah_produk": {
+                    "type": "integer",
+                    "example": 24
                 },
-                "pekerjaan": {
-                    "type": "string",
-                    "example": "Reseller"
+                "jumlah_ulasan": {
+                    "type": "integer",
+                    "example": 58
                 },
-                "tanggal_Lahir": {
-                    "type": "string",
-                    "example": "01/01/1990"
+                "pengikut": {
+                    "type": "integer",
+                    "example": 120
                 },
-                "tentang": {
-                    "type": "string",
-                    "example": "Saya reseller Evermos"
+                "rating": {
+                    "type": "number",
+                    "example": 4.7
                 },
-                "token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "total_terjual": {
+                    "type": "integer",
+                    "example": 310
                 }
             }
         },
-        "http.AuthLoginRequest": {
+        "http.StoreStatus": {
             "type": "object",
             "properties": {
-                "kata_sandi": {
+                "buka": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "buka_lagi": {
                     "type": "string",
-                    "example": "password123"
+                    "example": "2025-04-07T08:00:00+07:00"
                 },
-                "no_telp": {
+                "libur": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "pesan": {
                     "type": "string",
-                    "example": "08123456789"
+                    "example": "Libur lebaran"
                 }
             }
         },
-        "http.AuthLoginResponse": {
+        "http.StoreVerification": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/http.AuthLoginData"
+                "alasan": {
+                    "type": "string",
+                    "example": ""
                 },
-                "errors": {
+                "catatan": {
+                    "type": "string",
+                    "example": "Distributor resmi merek Kemeja Nusantara"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01T10:00:00+07:00"
+                },
+                "dokumen": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        ""
-                    ]
+                        "$ref": "#/definitions/http.StoreVerificationDokumen"
+                    }
                 },
-                "message": {
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "reviewed_at": {
                     "type": "string",
-                    "example": "Succeed to POST data"
+                    "example": "2025-01-02T09:00:00+07:00"
                 },
                 "status": {
-                    "type": "boolean",
-                    "example": true
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "rejected"
+                    ],
+                    "example": "pending"
+                },
+                "toko": {
+                    "$ref": "#/definitions/http.StoreVerificationToko"
                 }
             }
         },
-        "http.AuthRegisterRequest": {
+        "http.StoreVerificationDokumen": {
             "type": "object",
             "properties": {
-                "email": {
+                "content_type": {
                     "type": "string",
-                    "example": "john@example.com"
+                    "example": "application/pdf"
                 },
-                "id_kota": {
+                "nama": {
                     "type": "string",
-                    "example": "1101"
+                    "example": "nib.pdf"
                 },
-                "id_provinsi": {
-                    "type": "string",
-                    "example": "11"
+                "ukuran": {
+                    "type": "integer",
+                    "example": 184320
                 },
-                "kata_sandi": {
+                "url": {
                     "type": "string",
-                    "example": "password123"
+                    "example": "https://files.local/uploads/private/toko-verifikasi/5/1758868233503052000-0.pdf?expires=1758869133\u0026sig=3f2a"
+                }
+            }
+        },
+        "http.StoreVerificationListData": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.StoreVerification"
+                    }
                 },
-                "nama": {
-                    "type": "string",
-                    "example": "John Doe"
+                "limit": {
+                    "type": "integer",
+                    "example": 20
                 },
-                "no_telp": {
-                    "type": "string",
-                    "example": "08123456789"
+                "page": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "pekerjaan": {
-                    "type": "string",
-                    "example": "Reseller"
+                "total": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "tanggal_Lahir": {
-                    "type": "string",
-                    "example": "01/01/1990"
+                "total_page": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "http.AuthRegisterResponse": {
+        "http.StoreVerificationListResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "string",
-                    "example": "Register Succeed"
+                    "$ref": "#/definitions/http.StoreVerificationListData"
                 },
                 "errors": {
                     "type": "array",
@@ -1884,7 +8273,7 @@ const docTemplate = `{
                 },
                 "message": {
                     "type": "string",
-                    "example": "Succeed to POST data"
+                    "example": "Succeed to GET data"
                 },
                 "status": {
                     "type": "boolean",
@@ -1892,20 +8281,11 @@ const docTemplate = `{
                 }
             }
         },
-        "http.CategoryCreateRequest": {
-            "type": "object",
-            "properties": {
-                "nama_category": {
-                    "type": "string",
-                    "example": "Fashion"
-                }
-            }
-        },
-        "http.CategoryDetailResponse": {
+        "http.StoreVerificationResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/http.CategoryItem"
+                    "$ref": "#/definitions/http.StoreVerification"
                 },
                 "errors": {
                     "type": "array",
@@ -1918,7 +8298,7 @@ const docTemplate = `{
                 },
                 "message": {
                     "type": "string",
-                    "example": "Succeed to GET data"
+                    "example": "Succeed to POST data"
                 },
                 "status": {
                     "type": "boolean",
@@ -1926,27 +8306,27 @@ const docTemplate = `{
                 }
             }
         },
-        "http.CategoryItem": {
+        "http.StoreVerificationState": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "pengajuan": {
+                    "$ref": "#/definitions/http.StoreVerification"
                 },
-                "nama_category": {
+                "verified": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "verified_at": {
                     "type": "string",
-                    "example": "Fashion"
+                    "example": "2025-01-02T09:00:00+07:00"
                 }
             }
         },
-        "http.CategoryListResponse": {
+        "http.StoreVerificationStateResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/http.CategoryItem"
-                    }
+                    "$ref": "#/definitions/http.StoreVerificationState"
                 },
                 "errors": {
                     "type": "array",
@@ -1967,118 +8347,77 @@ const docTemplate = `{
                 }
             }
         },
-        "http.CategoryUpdateRequest": {
-            "type": "object",
-            "properties": {
-                "nama_category": {
-                    "type": "string",
-                    "example": "Electronics - Updated"
-                }
-            }
-        },
-        "http.CityRef": {
+        "http.StoreVerificationToko": {
             "type": "object",
             "properties": {
                 "id": {
-                    "type": "string",
-                    "example": "1101"
+                    "type": "integer",
+                    "example": 5
                 },
-                "name": {
+                "nama_toko": {
                     "type": "string",
-                    "example": "Kota Banda Aceh"
+                    "example": "Toko Budi"
                 },
-                "province_id": {
+                "slug": {
                     "type": "string",
-                    "example": "11"
-                }
-            }
-        },
-        "http.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "errors": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "Invalid JSON"
-                    ]
+                    "example": "toko-budi"
                 },
-                "message": {
+                "url_foto": {
                     "type": "string",
-                    "example": "Failed to POST data"
+                    "example": "https://files.local/uploads/stores/toko-1.jpg"
                 },
-                "status": {
+                "verified": {
                     "type": "boolean",
                     "example": false
                 }
             }
         },
-        "http.Product": {
+        "http.TokoInvitation": {
             "type": "object",
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/http.ProductCategory"
-                },
-                "deskripsi": {
+                "created_at": {
                     "type": "string",
-                    "example": "Bahan katun, nyaman dipakai"
-                },
-                "harga_konsumen": {
-                    "type": "integer",
-                    "example": 120000
+                    "example": "2025-09-26T10:00:00Z"
                 },
-                "harga_reseller": {
-                    "type": "integer",
-                    "example": 90000
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-10-03T10:00:00Z"
                 },
                 "id": {
                     "type": "integer",
-                    "example": 10
+                    "example": 3
                 },
-                "nama_produk": {
+                "kontak": {
                     "type": "string",
-                    "example": "Kemeja Pria Lengan Panjang"
-                },
-                "photos": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/http.ProductPhoto"
-                    }
+                    "example": "siti@example.com"
                 },
-                "slug": {
+                "role": {
                     "type": "string",
-                    "example": "kemeja-pria-lengan-panjang"
+                    "example": "staff-catalog"
                 },
-                "stok": {
-                    "type": "integer",
-                    "example": 50
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "accepted",
+                        "declined",
+                        "cancelled"
+                    ],
+                    "example": "pending"
                 },
                 "toko": {
-                    "$ref": "#/definitions/http.ProductStore"
-                }
-            }
-        },
-        "http.ProductCategory": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 2
-                },
-                "nama_category": {
-                    "type": "string",
-                    "example": "Fashion"
+                    "$ref": "#/definitions/http.TrxToko"
                 }
             }
         },
-        "http.ProductDetailResponse": {
+        "http.TokoInvitationListResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/http.Product"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.TokoInvitation"
+                    }
                 },
                 "errors": {
                     "type": "array",
@@ -2099,14 +8438,29 @@ const docTemplate = `{
                 }
             }
         },
-        "http.ProductListResponse": {
+        "http.TokoInvitationRequest": {
+            "type": "object",
+            "properties": {
+                "kontak": {
+                    "type": "string",
+                    "example": "siti@example.com"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "manager",
+                        "staff-orders",
+                        "staff-catalog"
+                    ],
+                    "example": "staff-catalog"
+                }
+            }
+        },
+        "http.TokoInvitationResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/http.Product"
-                    }
+                    "$ref": "#/definitions/http.TokoInvitation"
                 },
                 "errors": {
                     "type": "array",
@@ -2119,7 +8473,7 @@ const docTemplate = `{
                 },
                 "message": {
                     "type": "string",
-                    "example": "Succeed to GET data"
+                    "example": "Succeed to POST data"
                 },
                 "status": {
                     "type": "boolean",
@@ -2127,50 +8481,80 @@ const docTemplate = `{
                 }
             }
         },
-        "http.ProductPhoto": {
+        "http.TokoMember": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-26T10:00:00Z"
                 },
-                "product_id": {
+                "email": {
+                    "type": "string",
+                    "example": "siti@example.com"
+                },
+                "id_user": {
                     "type": "integer",
-                    "example": 10
+                    "example": 12
                 },
-                "url": {
+                "nama": {
+                    "type": "string",
+                    "example": "Siti"
+                },
+                "notelp": {
+                    "type": "string",
+                    "example": "081234567890"
+                },
+                "role": {
                     "type": "string",
-                    "example": "https://files.local/uploads/products/1758869029454234400-IMG_2867_11zon.jpg"
+                    "enum": [
+                        "owner",
+                        "manager",
+                        "staff-orders",
+                        "staff-catalog"
+                    ],
+                    "example": "staff-catalog"
                 }
             }
         },
-        "http.ProductStore": {
+        "http.TokoMemberListResponse": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 5
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.TokoMember"
+                    }
                 },
-                "nama_toko": {
-                    "type": "string",
-                    "example": "Toko Budi"
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
                 },
-                "url_foto": {
+                "message": {
                     "type": "string",
-                    "example": "https://files.local/uploads/stores/toko-1758868233503052000.jpg"
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "http.ProvinceRef": {
+        "http.TokoMemberRoleRequest": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "11"
-                },
-                "name": {
+                "role": {
                     "type": "string",
-                    "example": "Aceh"
+                    "enum": [
+                        "manager",
+                        "staff-orders",
+                        "staff-catalog"
+                    ],
+                    "example": "manager"
                 }
             }
         },
@@ -2184,6 +8568,10 @@ const docTemplate = `{
                 "product_id": {
                     "type": "integer",
                     "example": 10
+                },
+                "variant_id": {
+                    "type": "integer",
+                    "example": 7
                 }
             }
         },
@@ -2231,13 +8619,57 @@ const docTemplate = `{
                 }
             }
         },
-        "http.TransactionListData": {
+        "http.TransactionListData": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.TrxItem"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "http.TransactionListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.TransactionListData"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.TrashListData": {
             "type": "object",
             "properties": {
-                "data": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/http.TrxItem"
+                        "$ref": "#/definitions/http.TrashedProduct"
                     }
                 },
                 "limit": {
@@ -2247,14 +8679,22 @@ const docTemplate = `{
                 "page": {
                     "type": "integer",
                     "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_page": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "http.TransactionListResponse": {
+        "http.TrashListResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/http.TransactionListData"
+                    "$ref": "#/definitions/http.TrashListData"
                 },
                 "errors": {
                     "type": "array",
@@ -2275,6 +8715,114 @@ const docTemplate = `{
                 }
             }
         },
+        "http.TrashedProduct": {
+            "type": "object",
+            "properties": {
+                "alasan_penolakan": {
+                    "type": "string",
+                    "example": "Foto produk tidak sesuai"
+                },
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductAttribute"
+                    }
+                },
+                "berat": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "category": {
+                    "$ref": "#/definitions/http.ProductCategory"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2025-01-01T10:00:00+07:00"
+                },
+                "deskripsi": {
+                    "type": "string",
+                    "example": "Bahan katun, nyaman dipakai"
+                },
+                "flash_sale": {
+                    "$ref": "#/definitions/http.ProductFlashSale"
+                },
+                "harga_konsumen": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "harga_reseller": {
+                    "type": "integer",
+                    "example": 90000
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "lebar": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "nama_produk": {
+                    "type": "string",
+                    "example": "Kemeja Pria Lengan Panjang"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductOption"
+                    }
+                },
+                "panjang": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductPhoto"
+                    }
+                },
+                "purge_at": {
+                    "type": "string",
+                    "example": "2025-01-31T10:00:00+07:00"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "kemeja-pria-lengan-panjang"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "pending_review",
+                        "published",
+                        "rejected"
+                    ],
+                    "example": "published"
+                },
+                "stok": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "stok_minimum": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "tinggi": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "toko": {
+                    "$ref": "#/definitions/http.ProductStore"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ProductVariant"
+                    }
+                }
+            }
+        },
         "http.TrxAlamatKirim": {
             "type": "object",
             "properties": {
@@ -2320,6 +8868,10 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 240000
                 },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
                 "kuantitas": {
                     "type": "integer",
                     "example": 2
@@ -2329,6 +8881,22 @@ const docTemplate = `{
                 },
                 "toko": {
                     "$ref": "#/definitions/http.TrxToko"
+                },
+                "ulasan": {
+                    "$ref": "#/definitions/http.TrxUlasan"
+                }
+            }
+        },
+        "http.TrxFlashSale": {
+            "type": "object",
+            "properties": {
+                "harga_normal": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 4
                 }
             }
         },
@@ -2359,6 +8927,49 @@ const docTemplate = `{
                 "method_bayar": {
                     "type": "string",
                     "example": "COD"
+                },
+                "paket": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.TrxPaket"
+                    }
+                }
+            }
+        },
+        "http.TrxPaket": {
+            "type": "object",
+            "properties": {
+                "asal_id_kota": {
+                    "type": "string",
+                    "example": "3273"
+                },
+                "asal_kota": {
+                    "type": "string",
+                    "example": "KOTA BANDUNG"
+                },
+                "berat": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "berat_tagihan": {
+                    "type": "integer",
+                    "example": 750
+                },
+                "berat_volumetrik": {
+                    "type": "integer",
+                    "example": 750
+                },
+                "id_toko": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "kuantitas": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "nama_toko": {
+                    "type": "string",
+                    "example": "Toko Budi"
                 }
             }
         },
@@ -2382,6 +8993,10 @@ const docTemplate = `{
         "http.TrxProduct": {
             "type": "object",
             "properties": {
+                "berat": {
+                    "type": "integer",
+                    "example": 250
+                },
                 "category": {
                     "$ref": "#/definitions/http.TrxCategory"
                 },
@@ -2389,6 +9004,9 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Bahan katun, nyaman dipakai"
                 },
+                "flash_sale": {
+                    "$ref": "#/definitions/http.TrxFlashSale"
+                },
                 "harga_konsumen": {
                     "type": "integer",
                     "example": 120000
@@ -2401,10 +9019,18 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 10
                 },
+                "lebar": {
+                    "type": "integer",
+                    "example": 25
+                },
                 "nama_produk": {
                     "type": "string",
                     "example": "Kemeja Pria Lengan Panjang"
                 },
+                "panjang": {
+                    "type": "integer",
+                    "example": 30
+                },
                 "photos": {
                     "type": "array",
                     "items": {
@@ -2415,8 +9041,15 @@ const docTemplate = `{
                     "type": "string",
                     "example": "kemeja-pria-lengan-panjang"
                 },
+                "tinggi": {
+                    "type": "integer",
+                    "example": 3
+                },
                 "toko": {
                     "$ref": "#/definitions/http.TrxToko"
+                },
+                "variant": {
+                    "$ref": "#/definitions/http.TrxVariant"
                 }
             }
         },
@@ -2437,6 +9070,92 @@ const docTemplate = `{
                 }
             }
         },
+        "http.TrxUlasan": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-05T10:00:00+07:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "komentar": {
+                    "type": "string",
+                    "example": "Bahannya adem, sesuai foto"
+                },
+                "rating": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "http.TrxUlasanRequest": {
+            "type": "object",
+            "properties": {
+                "id_detail_trx": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "komentar": {
+                    "type": "string",
+                    "example": "Bahannya adem, sesuai foto"
+                },
+                "rating": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "http.TrxUlasanResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/http.TrxUlasan"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to POST data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.TrxVariant": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "M / Putih"
+                },
+                "opsi": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "KMJ-M-PTH"
+                }
+            }
+        },
         "http.UserProfileData": {
             "type": "object",
             "properties": {
@@ -2496,6 +9215,71 @@ const docTemplate = `{
                     "example": true
                 }
             }
+        },
+        "http.UserSession": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01T10:00:00+07:00"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-10T08:00:00+07:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "last_active_at": {
+                    "type": "string",
+                    "example": "2025-01-03T08:12:00+07:00"
+                },
+                "perangkat": {
+                    "type": "string",
+                    "example": "Chrome di Windows"
+                },
+                "sesi_ini": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/128.0 Safari/537.36"
+                }
+            }
+        },
+        "http.UserSessionListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.UserSession"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        ""
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Succeed to GET data"
+                },
+                "status": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -10,7 +10,11 @@ require (
 	gorm.io/gorm v1.31.0
 )
 
-require github.com/swaggo/swag v1.16.4
+require (
+	github.com/HugoSmits86/nativewebp v1.2.1
+	github.com/swaggo/swag v1.16.4
+	golang.org/x/image v0.25.0
+)
 
 require (
 	github.com/KyleBanks/depth v1.2.1 // indirect
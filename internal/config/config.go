@@ -17,9 +17,36 @@ type Config struct {
 	DBPass          string
 	DBName          string
 	JWTSecret       string
+	// JWTExpiryDays is how long a refresh token lives; a login session ends
+	// after that many days without refresh
 	JWTExpiryDays   int
-	UploadDirProduct string
+	// AccessTokenTTLMin is the lifetime of access tokens (JWT)
+	AccessTokenTTLMin int
+	// PasswordResetTTLMin is the lifetime of password reset codes
+	PasswordResetTTLMin int
+	// Notifications (OTP codes): "log" or "file" (appends JSON lines to NotifyFile)
+	NotifyDriver string
+	NotifyFile   string
 	BaseFileURL      string
+	// Storage: "local" (StorageLocalDir, served under BaseFileURL/uploads) or "s3"
+	StorageDriver   string
+	StorageLocalDir string
+	// FileURLSecret signs expiring URLs of private files
+	FileURLSecret string
+	// FileCacheMaxAgeSec is the Cache-Control max-age of public local files
+	FileCacheMaxAgeSec int
+	S3Endpoint    string
+	S3Region      string
+	S3Bucket      string
+	S3AccessKey   string
+	S3SecretKey   string
+	S3PublicURL   string
+	S3PathStyle   bool
+	// BodyLimitMB is the max request body size (multiple photos per request)
+	BodyLimitMB int
+	// Trashed products are purged after TrashRetentionDays; 0 interval disables the purger
+	TrashRetentionDays    int
+	TrashPurgeIntervalMin int
 	// Address/EMSIFA configuration
 	EMSIFABase       string
 	HTTPTimeoutMS    int
@@ -40,8 +67,25 @@ func Load() (*Config, error) {
 		DBName:          getEnv("DB_NAME", ""),
 		JWTSecret:       getEnv("JWT_SECRET", ""),
 		JWTExpiryDays:   getEnvInt("JWT_EXP_DAYS", 7),
-		UploadDirProduct: getEnv("UPLOAD_DIR_PRODUCT", "uploads/products"),
+		AccessTokenTTLMin: getEnvInt("ACCESS_TOKEN_TTL_MIN", 15),
+		PasswordResetTTLMin: getEnvInt("PASSWORD_RESET_TTL_MIN", 10),
+		NotifyDriver:     getEnv("NOTIFY_DRIVER", "log"),
+		NotifyFile:       getEnv("NOTIFY_FILE", "notifications.log"),
 		BaseFileURL:      getEnv("BASE_FILE_URL", ""),
+		StorageDriver:    getEnv("STORAGE_DRIVER", "local"),
+		StorageLocalDir:  getEnv("STORAGE_LOCAL_DIR", "uploads"),
+		FileURLSecret:    getEnv("FILE_URL_SECRET", ""),
+		FileCacheMaxAgeSec: getEnvInt("FILE_CACHE_MAX_AGE_SEC", 7*24*3600),
+		S3Endpoint:       getEnv("S3_ENDPOINT", ""),
+		S3Region:         getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:         getEnv("S3_BUCKET", ""),
+		S3AccessKey:      getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:      getEnv("S3_SECRET_KEY", ""),
+		S3PublicURL:      getEnv("S3_PUBLIC_URL", ""),
+		S3PathStyle:      getEnvBool("S3_PATH_STYLE", false),
+		BodyLimitMB:           getEnvInt("BODY_LIMIT_MB", 32),
+		TrashRetentionDays:    getEnvInt("TRASH_RETENTION_DAYS", 30),
+		TrashPurgeIntervalMin: getEnvInt("TRASH_PURGE_INTERVAL_MIN", 60),
 		// Defaults for EMSIFA-based address service
 		EMSIFABase:       getEnv("EMSIFA_BASE", "https://www.emsifa.com/api-wilayah-indonesia/api"),
 		HTTPTimeoutMS:    getEnvInt("HTTP_TIMEOUT_MS", 5000),
@@ -57,6 +101,11 @@ func Load() (*Config, error) {
 		return nil, errors.New("missing required JWT env var: JWT_SECRET")
 	}
 
+	// default the file signing secret to the JWT secret
+	if cfg.FileURLSecret == "" {
+		cfg.FileURLSecret = cfg.JWTSecret
+	}
+
 	return cfg, nil
 }
 
@@ -79,3 +128,15 @@ func getEnvInt(key string, def int) int {
 	}
 	return i
 }
+
+func getEnvBool(key string, def bool) bool {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return def
+	}
+	return b
+}
@@ -0,0 +1,66 @@
+package media
+
+import "encoding/binary"
+
+// exifOrientation returns the EXIF orientation tag of a JPEG, or 1 when the
+// file is not a JPEG or carries no orientation.
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	i := 2
+	for i+4 <= len(data) {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 { // start of scan / end of image
+			return 1
+		}
+		segLen := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if segLen < 2 || i+2+segLen > len(data) {
+			return 1
+		}
+		seg := data[i+4 : i+2+segLen]
+		if marker == 0xE1 && len(seg) > 6 && string(seg[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(seg[6:])
+		}
+		i += 2 + segLen
+	}
+	return 1
+}
+
+// tiffOrientation reads tag 0x0112 from IFD0 of a TIFF header.
+func tiffOrientation(t []byte) int {
+	if len(t) < 8 {
+		return 1
+	}
+	var bo binary.ByteOrder
+	switch string(t[:2]) {
+	case "II":
+		bo = binary.LittleEndian
+	case "MM":
+		bo = binary.BigEndian
+	default:
+		return 1
+	}
+	off := int(bo.Uint32(t[4:8]))
+	if off+2 > len(t) {
+		return 1
+	}
+	n := int(bo.Uint16(t[off : off+2]))
+	for k := 0; k < n; k++ {
+		e := off + 2 + k*12
+		if e+12 > len(t) {
+			return 1
+		}
+		if bo.Uint16(t[e:e+2]) == 0x0112 {
+			v := int(bo.Uint16(t[e+8 : e+10]))
+			if v >= 1 && v <= 8 {
+				return v
+			}
+			return 1
+		}
+	}
+	return 1
+}
@@ -0,0 +1,83 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrInvalidURL is returned for image URLs that are not fetchable http(s) links.
+var ErrInvalidURL = errors.New("URL gambar harus http/https")
+
+// fetchClient only dials public addresses so imported URLs cannot reach
+// internal services.
+var fetchClient = &http.Client{
+	Timeout: 20 * time.Second,
+	Transport: &http.Transport{
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: 10 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				ip := net.ParseIP(host)
+				if ip == nil || !publicIP(ip) {
+					return fmt.Errorf("alamat %s tidak diizinkan", host)
+				}
+				return nil
+			},
+		}).DialContext,
+		ResponseHeaderTimeout: 10 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 3 {
+			return errors.New("terlalu banyak redirect")
+		}
+		return CheckURL(req.URL.String())
+	},
+}
+
+func publicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsMulticast() || ip.IsInterfaceLocalMulticast())
+}
+
+// CheckURL validates an image URL without fetching it.
+func CheckURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	return nil
+}
+
+// FetchURL downloads an image from a public http(s) URL and runs it
+// through Process.
+func FetchURL(ctx context.Context, raw string) (*Result, error) {
+	if err := CheckURL(raw); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, raw, nil)
+	if err != nil {
+		return nil, ErrInvalidURL
+	}
+	resp, err := fetchClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengunduh gambar: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gagal mengunduh gambar: %s", resp.Status)
+	}
+	if resp.ContentLength > MaxFileSize {
+		return nil, ErrTooLarge
+	}
+	return Process(resp.Body)
+}
@@ -0,0 +1,303 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // register decoder
+	"image/jpeg"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+
+	"project-evermos/internal/storage"
+
+	"github.com/HugoSmits86/nativewebp" // also registers the webp decoder
+	"golang.org/x/image/draw"
+)
+
+const (
+	// MaxFileSize is the largest accepted upload per file.
+	MaxFileSize = 10 * 1024 * 1024
+	// maxPixels guards against decompression bombs (about 50MP).
+	maxPixels   = 50_000_000
+	jpegQuality = 85
+)
+
+var (
+	ErrTooLarge        = errors.New("file terlalu besar (maks 10MB)")
+	ErrUnsupportedType = errors.New("file harus gambar (jpg|jpeg|png|gif|webp)")
+	ErrInvalidImage    = errors.New("file gambar rusak atau tidak dapat dibaca")
+)
+
+// allowedTypes are the sniffed content types accepted as uploads.
+var allowedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// sizes generated for every upload; MaxEdge is the longest side in pixels.
+// Generated sizes also get a WebP copy.
+var sizes = []struct {
+	Name    string
+	MaxEdge int
+	WebP    bool
+}{
+	{"original", 2048, false},
+	{"medium", 800, true},
+	{"thumb", 240, true},
+}
+
+// Rendition is one encoded output of a processed image.
+type Rendition struct {
+	Size        string // original, medium, thumb
+	Ext         string // .jpg, .png or .webp
+	ContentType string
+	Width       int
+	Height      int
+	Data        []byte
+}
+
+// Result holds every rendition of one processed upload.
+type Result struct {
+	Renditions []Rendition
+}
+
+// Size describes the stored files of one size. URL is the JPEG (or PNG for
+// transparent images); WebP is set for generated sizes.
+type Size struct {
+	URL    string `json:"url"`
+	WebP   string `json:"webp,omitempty"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// Sizes maps size name (original, medium, thumb) to its files.
+type Sizes map[string]Size
+
+// ProcessFile opens an uploaded file and runs it through Process.
+func ProcessFile(fh *multipart.FileHeader) (*Result, error) {
+	if fh.Size > MaxFileSize {
+		return nil, ErrTooLarge
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Process(f)
+}
+
+// Process validates an upload by its magic bytes, decodes it, applies the
+// EXIF orientation and re-encodes it into all sizes. Re-encoding drops all
+// metadata (EXIF, GPS, ICC), so nothing from the original file is kept.
+func Process(r io.Reader) (*Result, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxFileSize {
+		return nil, ErrTooLarge
+	}
+	if !allowedTypes[http.DetectContentType(data)] {
+		return nil, ErrUnsupportedType
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrInvalidImage
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	img := orient(toNRGBA(src), exifOrientation(data))
+	opaque := img.Opaque()
+
+	res := &Result{}
+	for _, sz := range sizes {
+		scaled := fit(img, sz.MaxEdge)
+		b := scaled.Bounds()
+		var buf bytes.Buffer
+		rd := Rendition{Size: sz.Name, Width: b.Dx(), Height: b.Dy()}
+		if opaque {
+			if err := jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: jpegQuality}); err != nil {
+				return nil, err
+			}
+			rd.Ext, rd.ContentType = ".jpg", "image/jpeg"
+		} else {
+			if err := png.Encode(&buf, scaled); err != nil {
+				return nil, err
+			}
+			rd.Ext, rd.ContentType = ".png", "image/png"
+		}
+		rd.Data = buf.Bytes()
+		res.Renditions = append(res.Renditions, rd)
+
+		if sz.WebP {
+			var wbuf bytes.Buffer
+			if err := nativewebp.Encode(&wbuf, scaled, nil); err != nil {
+				return nil, err
+			}
+			res.Renditions = append(res.Renditions, Rendition{
+				Size: sz.Name, Ext: ".webp", ContentType: "image/webp",
+				Width: b.Dx(), Height: b.Dy(), Data: wbuf.Bytes(),
+			})
+		}
+	}
+	return res, nil
+}
+
+// FileName is the stored file name of a rendition: name.jpg for the original,
+// name-medium.jpg, name-thumb.webp, etc. for the others.
+func (rd Rendition) FileName(name string) string {
+	if rd.Size == "original" {
+		return name + rd.Ext
+	}
+	return fmt.Sprintf("%s-%s%s", name, rd.Size, rd.Ext)
+}
+
+// Store uploads every rendition under prefix/name and returns the sizes
+// with the storage URLs. Renditions already stored are removed again when
+// a later one fails.
+func (res *Result) Store(ctx context.Context, st storage.Storage, prefix, name string) (Sizes, error) {
+	out := Sizes{}
+	var keys []string
+	for _, rd := range res.Renditions {
+		key := path.Join(prefix, rd.FileName(name))
+		if err := st.Put(ctx, key, bytes.NewReader(rd.Data), int64(len(rd.Data)), rd.ContentType); err != nil {
+			for _, k := range keys {
+				_ = st.Delete(ctx, k)
+			}
+			return nil, err
+		}
+		keys = append(keys, key)
+		out.add(rd, st.URL(key))
+	}
+	return out, nil
+}
+
+func (s Sizes) add(rd Rendition, url string) {
+	sz := s[rd.Size]
+	sz.Width, sz.Height = rd.Width, rd.Height
+	if rd.Ext == ".webp" {
+		sz.WebP = url
+	} else {
+		sz.URL = url
+	}
+	s[rd.Size] = sz
+}
+
+// URLs lists every stored file URL.
+func (s Sizes) URLs() []string {
+	var out []string
+	for _, v := range s {
+		if v.URL != "" {
+			out = append(out, v.URL)
+		}
+		if v.WebP != "" {
+			out = append(out, v.WebP)
+		}
+	}
+	return out
+}
+
+// JSON encodes sizes for storage; empty sizes encode as "".
+func (s Sizes) JSON() string {
+	if len(s) == 0 {
+		return ""
+	}
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// ParseSizes decodes a stored sizes column. Legacy rows without sizes
+// return nil.
+func ParseSizes(raw string) Sizes {
+	if raw == "" {
+		return nil
+	}
+	var s Sizes
+	if err := json.Unmarshal([]byte(raw), &s); err != nil {
+		return nil
+	}
+	return s
+}
+
+func toNRGBA(src image.Image) *image.NRGBA {
+	if n, ok := src.(*image.NRGBA); ok && n.Rect.Min == (image.Point{}) {
+		return n
+	}
+	b := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Src)
+	return dst
+}
+
+// fit scales img down so its longest edge is at most maxEdge.
+func fit(img *image.NRGBA, maxEdge int) *image.NRGBA {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	if w <= maxEdge && h <= maxEdge {
+		return img
+	}
+	nw, nh := maxEdge, maxEdge
+	if w >= h {
+		nh = h * maxEdge / w
+	} else {
+		nw = w * maxEdge / h
+	}
+	if nw < 1 {
+		nw = 1
+	}
+	if nh < 1 {
+		nh = 1
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, nw, nh))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Src, nil)
+	return dst
+}
+
+// orient applies an EXIF orientation (1-8) so the pixels are upright.
+func orient(img *image.NRGBA, o int) *image.NRGBA {
+	if o < 2 || o > 8 {
+		return img
+	}
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	dw, dh := w, h
+	if o >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch o {
+			case 2: // mirror horizontal
+				dx, dy = w-1-x, y
+			case 3: // rotate 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // mirror vertical
+				dx, dy = x, h-1-y
+			case 5: // transpose
+				dx, dy = y, x
+			case 6: // rotate 90 CW
+				dx, dy = h-1-y, x
+			case 7: // transverse
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotate 90 CCW
+				dx, dy = y, w-1-x
+			}
+			dst.SetNRGBA(dx, dy, img.NRGBAAt(x, y))
+		}
+	}
+	return dst
+}
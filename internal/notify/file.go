@@ -0,0 +1,41 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// File appends messages as JSON lines to a file instead of sending them,
+// so local tools and scripts can read the codes.
+type File struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFile returns a driver that appends messages to path.
+func NewFile(path string) *File { return &File{path: path} }
+
+// Send appends m to the file.
+func (f *File) Send(_ context.Context, m Message) error {
+	if m.SentAt.IsZero() {
+		m.SentAt = time.Now()
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fh, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := fh.Write(append(b, '\n')); err != nil {
+		fh.Close()
+		return err
+	}
+	return fh.Close()
+}
@@ -0,0 +1,22 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Log writes messages to the application log instead of sending them.
+type Log struct{}
+
+// NewLog returns a driver that logs messages.
+func NewLog() *Log { return &Log{} }
+
+// Send logs m.
+func (Log) Send(_ context.Context, m Message) error {
+	if m.SentAt.IsZero() {
+		m.SentAt = time.Now()
+	}
+	log.Printf("[notify] %s to %s: %s %s", m.Channel, m.To, m.Subject, m.Body)
+	return nil
+}
@@ -0,0 +1,46 @@
+// Package notify delivers messages such as one-time codes to users by SMS
+// or email. Drivers are chosen by config; the log and file drivers only
+// record messages, for local development.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"project-evermos/internal/config"
+)
+
+// Channels a message can be sent over.
+const (
+	ChannelSMS   = "sms"
+	ChannelEmail = "email"
+)
+
+// Message is one message to a phone number (sms) or email address.
+type Message struct {
+	Channel string    `json:"channel"`
+	To      string    `json:"to"`
+	Subject string    `json:"subject,omitempty"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// Sender is implemented by every driver.
+type Sender interface {
+	// Send delivers m; it returns once the message is accepted.
+	Send(ctx context.Context, m Message) error
+}
+
+// New builds the driver selected by cfg.NotifyDriver.
+func New(cfg *config.Config) (Sender, error) {
+	switch strings.ToLower(cfg.NotifyDriver) {
+	case "", "log":
+		return NewLog(), nil
+	case "file":
+		return NewFile(cfg.NotifyFile), nil
+	default:
+		return nil, fmt.Errorf("notify: unknown driver %q", cfg.NotifyDriver)
+	}
+}
@@ -0,0 +1,42 @@
+// Package slug builds URL slugs for products and stores.
+package slug
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var reNonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ErrExhausted is returned when no free slug was found.
+var ErrExhausted = errors.New("failed to generate unique slug")
+
+// Make lowercases name and joins its words with "-". Returns fallback when
+// nothing is left.
+func Make(name, fallback string) string {
+	s := reNonWord.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+	s = strings.Trim(s, "-")
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+// Unique returns base, or base-1, base-2, ... for the first candidate that
+// taken reports as free.
+func Unique(base string, taken func(string) (bool, error)) (string, error) {
+	s := base
+	for i := 0; i < 1000; i++ {
+		t, err := taken(s)
+		if err != nil {
+			return "", err
+		}
+		if !t {
+			return s, nil
+		}
+		s = fmt.Sprintf("%s-%d", base, i+1)
+	}
+	return "", ErrExhausted
+}
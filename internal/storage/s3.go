@@ -0,0 +1,260 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3Config configures the S3-compatible driver. Endpoint defaults to AWS;
+// set it (with PathStyle) for MinIO and similar servers.
+type S3Config struct {
+	Endpoint  string // e.g. http://127.0.0.1:9000
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PublicURL is the base URL for public objects (CDN or bucket website);
+	// defaults to the bucket URL
+	PublicURL string
+	PathStyle bool
+}
+
+// S3 stores files in an S3-compatible bucket, signing requests with AWS
+// Signature Version 4.
+type S3 struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// maxPresignTTL is the longest expiry S3 accepts for presigned URLs.
+const maxPresignTTL = 7 * 24 * time.Hour
+
+func NewS3(cfg S3Config) (*S3, error) {
+	if cfg.Bucket == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errors.New("storage: s3 requires S3_BUCKET, S3_ACCESS_KEY and S3_SECRET_KEY")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+	ep, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || ep.Host == "" {
+		return nil, fmt.Errorf("storage: invalid S3_ENDPOINT %q", cfg.Endpoint)
+	}
+	cfg.PublicURL = strings.TrimRight(cfg.PublicURL, "/")
+	return &S3{cfg: cfg, endpoint: ep, client: &http.Client{Timeout: 60 * time.Second}}, nil
+}
+
+// objectURL is the API URL of key (path-style or virtual-hosted).
+func (s *S3) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	if s.cfg.PathStyle {
+		u.Path = "/" + s.cfg.Bucket + "/" + key
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+		u.Path = "/" + key
+	}
+	u.RawPath = uriEncodePath(u.Path)
+	return &u
+}
+
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key).String(), r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return s.do(req, http.StatusOK)
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key).String(), nil)
+	if err != nil {
+		return err
+	}
+	// S3 answers 204 even when the key does not exist
+	return s.do(req, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+func (s *S3) URL(key string) string {
+	key, _ = cleanKey(key)
+	if s.cfg.PublicURL != "" {
+		return s.cfg.PublicURL + "/" + uriEncodePath(key)
+	}
+	return s.objectURL(key).String()
+}
+
+// SignedURL returns a presigned GET URL.
+func (s *S3) SignedURL(key string, ttl time.Duration) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	if ttl <= 0 || ttl > maxPresignTTL {
+		return "", fmt.Errorf("storage: signed URL ttl must be between 1s and %s", maxPresignTTL)
+	}
+	u := s.objectURL(key)
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := s.scope(now)
+	q := url.Values{}
+	q.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	q.Set("X-Amz-Credential", s.cfg.AccessKey+"/"+scope)
+	q.Set("X-Amz-Date", amzDate)
+	q.Set("X-Amz-Expires", strconv.Itoa(int(ttl.Seconds())))
+	q.Set("X-Amz-SignedHeaders", "host")
+	canonical := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		canonicalQuery(q),
+		"host:" + u.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	q.Set("X-Amz-Signature", s.signature(now, amzDate, scope, canonical))
+	u.RawQuery = canonicalQuery(q)
+	return u.String(), nil
+}
+
+func (s *S3) Key(rawURL string) (string, bool) {
+	if s.cfg.PublicURL != "" {
+		if key, ok := trimURLPrefix(rawURL, s.cfg.PublicURL); ok {
+			return unescapeKey(key)
+		}
+	}
+	base := strings.TrimSuffix(s.objectURL("").String(), "/")
+	if key, ok := trimURLPrefix(rawURL, base); ok {
+		return unescapeKey(key)
+	}
+	return "", false
+}
+
+// do signs and sends req, accepting any of the given status codes.
+func (s *S3) do(req *http.Request, ok ...int) error {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := s.scope(now)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", "UNSIGNED-PAYLOAD")
+	signed := "host;x-amz-content-sha256;x-amz-date"
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:UNSIGNED-PAYLOAD\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signed,
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKey, scope, signed, s.signature(now, amzDate, scope, canonical)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	for _, code := range ok {
+		if resp.StatusCode == code {
+			return nil
+		}
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("storage: s3 %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+}
+
+func (s *S3) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.cfg.Region + "/s3/aws4_request"
+}
+
+func (s *S3) signature(now time.Time, amzDate, scope, canonical string) string {
+	sum := sha256.Sum256([]byte(canonical))
+	toSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(sum[:])
+	k := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), now.Format("20060102"))
+	k = hmacSHA256(k, s.cfg.Region)
+	k = hmacSHA256(k, "s3")
+	k = hmacSHA256(k, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(k, toSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	m := hmac.New(sha256.New, key)
+	m.Write([]byte(data))
+	return m.Sum(nil)
+}
+
+// canonicalQuery encodes q sorted by key with SigV4 escaping.
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		vs := append([]string(nil), q[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, uriEncode(k)+"="+uriEncode(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncodePath escapes every path segment, keeping the slashes.
+func uriEncodePath(p string) string {
+	segs := strings.Split(p, "/")
+	for i, s := range segs {
+		segs[i] = uriEncode(s)
+	}
+	return strings.Join(segs, "/")
+}
+
+// uriEncode escapes everything except RFC 3986 unreserved characters.
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func unescapeKey(key string) (string, bool) {
+	k, err := url.PathUnescape(key)
+	if err != nil {
+		return "", false
+	}
+	return k, true
+}
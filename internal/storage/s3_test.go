@@ -0,0 +1,264 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessKey = "minio"
+	testSecretKey = "minio-secret"
+	testRegion    = "us-east-1"
+	testBucket    = "evermos"
+)
+
+// fakeBucket is a minimal S3 stand-in: it checks the SigV4 Authorization
+// header of every request and keeps objects in memory.
+type fakeBucket struct {
+	t       *testing.T
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+	methods []string
+}
+
+func newFakeBucket(t *testing.T) (*fakeBucket, *httptest.Server) {
+	b := &fakeBucket{t: t, objects: map[string][]byte{}, types: map[string]string{}}
+	srv := httptest.NewServer(b)
+	t.Cleanup(srv.Close)
+	return b, srv
+}
+
+func (b *fakeBucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := verifyHeaderAuth(r, testSecretKey); err != "" {
+		http.Error(w, err, http.StatusForbidden)
+		return
+	}
+	prefix := "/" + testBucket + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.methods = append(b.methods, r.Method)
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		b.objects[key] = body
+		b.types[key] = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(b.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verifyHeaderAuth recomputes the SigV4 signature of r independently of
+// the driver and returns what is wrong with it, or "".
+func verifyHeaderAuth(r *http.Request, secret string) string {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 ") {
+		return "missing AWS4-HMAC-SHA256 Authorization"
+	}
+	fields := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(auth, "AWS4-HMAC-SHA256 "), ", ") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+	cred := strings.Split(fields["Credential"], "/")
+	if len(cred) != 5 || cred[0] != testAccessKey || cred[2] != testRegion || cred[3] != "s3" || cred[4] != "aws4_request" {
+		return "bad credential " + fields["Credential"]
+	}
+	amzDate := r.Header.Get("X-Amz-Date")
+	if !strings.HasPrefix(amzDate, cred[1]) {
+		return "X-Amz-Date does not match credential date"
+	}
+	signed := strings.Split(fields["SignedHeaders"], ";")
+	var headers strings.Builder
+	for _, h := range signed {
+		v := r.Header.Get(h)
+		if h == "host" {
+			v = r.Host
+		}
+		headers.WriteString(h + ":" + strings.TrimSpace(v) + "\n")
+	}
+	canonical := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		testCanonicalQuery(r.URL.Query()),
+		headers.String(),
+		fields["SignedHeaders"],
+		r.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	want := testSign(secret, cred[1], amzDate, strings.Join(cred[1:], "/"), canonical)
+	if !hmac.Equal([]byte(want), []byte(fields["Signature"])) {
+		return "signature mismatch"
+	}
+	return ""
+}
+
+func testCanonicalQuery(q url.Values) string {
+	var parts []string
+	for k, vs := range q {
+		for _, v := range vs {
+			parts = append(parts, url.QueryEscape(k)+"="+strings.ReplaceAll(url.QueryEscape(v), "+", "%20"))
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "&")
+}
+
+func testSign(secret, date, amzDate, scope, canonical string) string {
+	mac := func(key []byte, data string) []byte {
+		m := hmac.New(sha256.New, key)
+		m.Write([]byte(data))
+		return m.Sum(nil)
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	k := mac([]byte("AWS4"+secret), date)
+	k = mac(k, testRegion)
+	k = mac(k, "s3")
+	k = mac(k, "aws4_request")
+	return hex.EncodeToString(mac(k, "AWS4-HMAC-SHA256\n"+amzDate+"\n"+scope+"\n"+hex.EncodeToString(sum[:])))
+}
+
+func newTestS3(t *testing.T, endpoint, secret string) *S3 {
+	s, err := NewS3(S3Config{Endpoint: endpoint, Region: testRegion, Bucket: testBucket,
+		AccessKey: testAccessKey, SecretKey: secret, PathStyle: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestS3PutDeleteSigned(t *testing.T) {
+	b, srv := newFakeBucket(t)
+	s := newTestS3(t, srv.URL, testSecretKey)
+	ctx := context.Background()
+
+	key := "products/foto baru+1.jpg"
+	if err := s.Put(ctx, key, strings.NewReader("jpeg-bytes"), 10, "image/jpeg"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got := string(b.objects[key]); got != "jpeg-bytes" {
+		t.Fatalf("stored %q, want %q", got, "jpeg-bytes")
+	}
+	if got := b.types[key]; got != "image/jpeg" {
+		t.Fatalf("content type %q", got)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := b.objects[key]; ok {
+		t.Fatal("object still stored after Delete")
+	}
+	if strings.Join(b.methods, ",") != "PUT,DELETE" {
+		t.Fatalf("methods %v", b.methods)
+	}
+}
+
+func TestS3RejectsWrongSecret(t *testing.T) {
+	_, srv := newFakeBucket(t)
+	s := newTestS3(t, srv.URL, "not-the-secret")
+	if err := s.Put(context.Background(), "products/a.jpg", strings.NewReader("x"), 1, ""); err == nil {
+		t.Fatal("Put with a wrong secret succeeded")
+	}
+	if err := s.Delete(context.Background(), "products/a.jpg"); err == nil {
+		t.Fatal("Delete with a wrong secret succeeded")
+	}
+}
+
+func TestS3ObjectURL(t *testing.T) {
+	s := newTestS3(t, "http://127.0.0.1:9000/", testSecretKey)
+	if got, want := s.objectURL("products/a b.jpg").String(), "http://127.0.0.1:9000/evermos/products/a%20b.jpg"; got != want {
+		t.Fatalf("path-style objectURL = %s, want %s", got, want)
+	}
+	v, err := NewS3(S3Config{Region: testRegion, Bucket: testBucket, AccessKey: testAccessKey, SecretKey: testSecretKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := v.objectURL("products/a.jpg").String(), "https://evermos.s3.us-east-1.amazonaws.com/products/a.jpg"; got != want {
+		t.Fatalf("virtual-hosted objectURL = %s, want %s", got, want)
+	}
+}
+
+func TestS3SignedURL(t *testing.T) {
+	s := newTestS3(t, "http://127.0.0.1:9000", testSecretKey)
+	raw, err := s.SignedURL("private/jobs/1/report.csv", 15*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Path != "/evermos/private/jobs/1/report.csv" {
+		t.Fatalf("path %s", u.Path)
+	}
+	q := u.Query()
+	date := time.Now().UTC().Format("20060102")
+	for k, want := range map[string]string{
+		"X-Amz-Algorithm":     "AWS4-HMAC-SHA256",
+		"X-Amz-Credential":    testAccessKey + "/" + date + "/" + testRegion + "/s3/aws4_request",
+		"X-Amz-Expires":       "900",
+		"X-Amz-SignedHeaders": "host",
+	} {
+		if got := q.Get(k); got != want {
+			t.Fatalf("%s = %q, want %q", k, got, want)
+		}
+	}
+	sig := q.Get("X-Amz-Signature")
+	q.Del("X-Amz-Signature")
+	canonical := strings.Join([]string{http.MethodGet, u.EscapedPath(), testCanonicalQuery(q),
+		"host:" + u.Host + "\n", "host", "UNSIGNED-PAYLOAD"}, "\n")
+	scope := date + "/" + testRegion + "/s3/aws4_request"
+	if want := testSign(testSecretKey, date, q.Get("X-Amz-Date"), scope, canonical); sig != want {
+		t.Fatalf("presigned signature %s, want %s", sig, want)
+	}
+
+	if _, err := s.SignedURL("private/a.pdf", 7*24*time.Hour); err != nil {
+		t.Fatalf("7 day ttl rejected: %v", err)
+	}
+	for _, ttl := range []time.Duration{0, -time.Second, 7*24*time.Hour + time.Second} {
+		if _, err := s.SignedURL("private/a.pdf", ttl); err == nil {
+			t.Fatalf("ttl %s accepted", ttl)
+		}
+	}
+}
+
+func TestS3KeyInvertsURL(t *testing.T) {
+	keys := []string{"products/a.jpg", "stores/toko 1/foto (2).webp", "products/kémeja+batik.png"}
+	for _, public := range []string{"", "https://cdn.example.com/files"} {
+		s, err := NewS3(S3Config{Endpoint: "http://127.0.0.1:9000", Region: testRegion, Bucket: testBucket,
+			AccessKey: testAccessKey, SecretKey: testSecretKey, PublicURL: public, PathStyle: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, k := range keys {
+			got, ok := s.Key(s.URL(k))
+			if !ok || got != k {
+				t.Fatalf("Key(URL(%q)) = %q, %v (public %q)", k, got, ok, public)
+			}
+		}
+		if _, ok := s.Key("https://elsewhere.example.com/products/a.jpg"); ok {
+			t.Fatalf("foreign URL mapped to a key (public %q)", public)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Package storage abstracts where uploaded files live. Keys are
+// slash-separated paths such as "products/123-foo.jpg"; each driver turns
+// them into public or signed URLs.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"project-evermos/internal/config"
+)
+
+// PrivatePrefix marks keys that are only reachable through SignedURL
+// (invoices, review photos, ...).
+const PrivatePrefix = "private/"
+
+// IsPrivate reports whether key is a private file.
+func IsPrivate(key string) bool {
+	return strings.HasPrefix(key, PrivatePrefix)
+}
+
+// ErrInvalidKey is returned for keys that are empty or escape the root.
+var ErrInvalidKey = errors.New("storage: invalid key")
+
+// Storage is implemented by every driver.
+type Storage interface {
+	// Put stores size bytes from r under key, replacing any existing object.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Delete removes key; deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL is the public URL of key.
+	URL(key string) string
+	// SignedURL is a URL for key that stops working after ttl.
+	SignedURL(key string, ttl time.Duration) (string, error)
+	// Key maps a URL produced by URL back to its key. URLs pointing
+	// elsewhere (e.g. external photo links) return false.
+	Key(url string) (string, bool)
+}
+
+// New builds the driver selected by cfg.StorageDriver.
+func New(cfg *config.Config) (Storage, error) {
+	switch strings.ToLower(cfg.StorageDriver) {
+	case "", "local":
+		return NewLocal(cfg.StorageLocalDir, strings.TrimRight(cfg.BaseFileURL, "/")+LocalURLPrefix, cfg.FileURLSecret), nil
+	case "s3":
+		return NewS3(S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PublicURL: cfg.S3PublicURL,
+			PathStyle: cfg.S3PathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("storage: unknown driver %q", cfg.StorageDriver)
+	}
+}
+
+// CleanKey normalizes a key like the drivers do, so checks such as
+// IsPrivate see the key that is actually stored.
+func CleanKey(key string) (string, error) { return cleanKey(key) }
+
+// cleanKey normalizes a key and rejects ones that could escape the root.
+func cleanKey(key string) (string, error) {
+	key = strings.TrimLeft(strings.ReplaceAll(key, "\\", "/"), "/")
+	if key == "" {
+		return "", ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return "", ErrInvalidKey
+		}
+	}
+	return key, nil
+}
+
+// trimURLPrefix returns the key of url under base, if any.
+func trimURLPrefix(url, base string) (string, bool) {
+	if i := strings.IndexAny(url, "?#"); i >= 0 {
+		url = url[:i]
+	}
+	if !strings.HasPrefix(url, base+"/") {
+		return "", false
+	}
+	key, err := cleanKey(strings.TrimPrefix(url, base+"/"))
+	if err != nil {
+		return "", false
+	}
+	return key, true
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/mail"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,6 +23,7 @@ func NewHandler(s *service.Service) *Handler { return &Handler{svc: s} }
 
 var (
 	phoneRegex = regexp.MustCompile(`^\d{10,15}$`)
+	otpRegex   = regexp.MustCompile(`^\d{6}$`)
 )
 
 type loginRequest struct {
@@ -29,6 +31,22 @@ type loginRequest struct {
 	KataSandi string `json:"kata_sandi"`
 }
 
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type forgotPasswordRequest struct {
+	NoTelp string `json:"no_telp"`
+	Email  string `json:"email"`
+}
+
+type resetPasswordRequest struct {
+	NoTelp    string `json:"no_telp"`
+	Email     string `json:"email"`
+	Kode      string `json:"kode"`
+	KataSandi string `json:"kata_sandi"`
+}
+
 type registerRequest struct {
 	Nama         string `json:"nama"`
 	KataSandi    string `json:"kata_sandi"`
@@ -67,7 +85,7 @@ func (h *Handler) Login(c *fiber.Ctx) error {
 		})
 	}
 
-	u, token, err := h.svc.Login(req.NoTelp, req.KataSandi)
+	u, tokens, err := h.svc.Login(req.NoTelp, req.KataSandi, sessionMeta(c))
 	if err != nil {
 		if errors.Is(err, service.ErrInvalidCredentials) {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
@@ -97,15 +115,18 @@ func (h *Handler) Login(c *fiber.Ctx) error {
 		"message": "Succeed to POST data",
 		"errors":  nil,
 		"data": fiber.Map{
-			"nama":           u.Nama,
-			"no_telp":        u.NoTelp,
-			"tanggal_Lahir":  tanggalLahir,
-			"tentang":        valueOrEmpty(u.Tentang),
-			"pekerjaan":      u.Pekerjaan,
-			"email":          u.Email,
-			"id_provinsi":    fiber.Map{"id": u.IDProvinsi, "name": provinceName(u.IDProvinsi)},
-			"id_kota":        fiber.Map{"id": u.IDKota, "province_id": u.IDProvinsi, "name": cityName(u.IDKota)},
-			"token":          token,
+			"nama":                     u.Nama,
+			"no_telp":                  u.NoTelp,
+			"tanggal_Lahir":            tanggalLahir,
+			"tentang":                  valueOrEmpty(u.Tentang),
+			"pekerjaan":                u.Pekerjaan,
+			"email":                    u.Email,
+			"id_provinsi":              fiber.Map{"id": u.IDProvinsi, "name": provinceName(u.IDProvinsi)},
+			"id_kota":                  fiber.Map{"id": u.IDKota, "province_id": u.IDProvinsi, "name": cityName(u.IDKota)},
+			"token":                    tokens.AccessToken,
+			"token_expires_at":         tokens.AccessExpiresAt,
+			"refresh_token":            tokens.RefreshToken,
+			"refresh_token_expires_at": tokens.RefreshExpiresAt,
 		},
 	}
 	return c.Status(fiber.StatusOK).JSON(resp)
@@ -210,6 +231,199 @@ func (h *Handler) Register(c *fiber.Ctx) error {
 	})
 }
 
+// Refresh trades a refresh token for a new access and refresh token.
+func (h *Handler) Refresh(c *fiber.Ctx) error {
+	var req refreshRequest
+	if err := c.BodyParser(&req); err != nil || strings.TrimSpace(req.RefreshToken) == "" {
+		return respondFail(c, fiber.StatusBadRequest, "POST", "refresh_token wajib diisi")
+	}
+	tokens, err := h.svc.Refresh(strings.TrimSpace(req.RefreshToken), sessionMeta(c))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRefresh) || errors.Is(err, service.ErrRefreshReused) {
+			return respondFail(c, fiber.StatusUnauthorized, "POST", err.Error())
+		}
+		return respondFail(c, fiber.StatusInternalServerError, "POST", err.Error())
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  true,
+		"message": "Succeed to POST data",
+		"errors":  nil,
+		"data":    tokens,
+	})
+}
+
+// Logout ends the session of a refresh token; its access tokens stop
+// working at once.
+func (h *Handler) Logout(c *fiber.Ctx) error {
+	var req refreshRequest
+	if err := c.BodyParser(&req); err != nil || strings.TrimSpace(req.RefreshToken) == "" {
+		return respondFail(c, fiber.StatusBadRequest, "POST", "refresh_token wajib diisi")
+	}
+	if err := h.svc.Logout(strings.TrimSpace(req.RefreshToken)); err != nil {
+		if errors.Is(err, service.ErrInvalidRefresh) {
+			return respondFail(c, fiber.StatusUnauthorized, "POST", err.Error())
+		}
+		return respondFail(c, fiber.StatusInternalServerError, "POST", err.Error())
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  true,
+		"message": "Succeed to POST data",
+		"errors":  nil,
+		"data":    "Logout Succeed",
+	})
+}
+
+// LogoutAll ends every session of the logged in user, including the
+// current one.
+func (h *Handler) LogoutAll(c *fiber.Ctx) error {
+	uid, _ := c.Locals("user_id").(uint)
+	if uid == 0 {
+		return respondFail(c, fiber.StatusUnauthorized, "POST", "Unauthorized")
+	}
+	n, err := h.svc.LogoutAll(uid)
+	if err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "POST", err.Error())
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  true,
+		"message": "Succeed to POST data",
+		"errors":  nil,
+		"data":    fiber.Map{"sesi_dicabut": n},
+	})
+}
+
+// ForgotPassword sends a password reset code to the phone or email of an
+// account. The answer is the same whether the account exists or not.
+func (h *Handler) ForgotPassword(c *fiber.Ctx) error {
+	var req forgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondFail(c, fiber.StatusBadRequest, "POST", "Invalid JSON")
+	}
+	if errs := validateResetTarget(req.NoTelp, req.Email); len(errs) > 0 {
+		return respondFail(c, fiber.StatusBadRequest, "POST", errs...)
+	}
+	t := service.ResetTarget{NoTelp: req.NoTelp, Email: req.Email}
+	if err := h.svc.ForgotPassword(c.UserContext(), t); err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "POST", err.Error())
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  true,
+		"message": "Succeed to POST data",
+		"errors":  nil,
+		"data":    "Jika akun terdaftar, kode OTP telah dikirim",
+	})
+}
+
+// ResetPassword sets a new password with a reset code and signs the
+// account out everywhere.
+func (h *Handler) ResetPassword(c *fiber.Ctx) error {
+	var req resetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondFail(c, fiber.StatusBadRequest, "POST", "Invalid JSON")
+	}
+	errs := validateResetTarget(req.NoTelp, req.Email)
+	if !otpRegex.MatchString(strings.TrimSpace(req.Kode)) {
+		errs = append(errs, "kode OTP harus 6 digit")
+	}
+	if len(req.KataSandi) < 6 {
+		errs = append(errs, "Kata sandi minimal 6 karakter")
+	}
+	if len(errs) > 0 {
+		return respondFail(c, fiber.StatusBadRequest, "POST", errs...)
+	}
+	t := service.ResetTarget{NoTelp: req.NoTelp, Email: req.Email}
+	if err := h.svc.ResetPassword(t, req.Kode, req.KataSandi); err != nil {
+		switch {
+		case errors.Is(err, service.ErrResetAttempts):
+			return respondFail(c, fiber.StatusTooManyRequests, "POST", err.Error())
+		case errors.Is(err, service.ErrResetCode):
+			return respondFail(c, fiber.StatusBadRequest, "POST", err.Error())
+		}
+		return respondFail(c, fiber.StatusInternalServerError, "POST", err.Error())
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  true,
+		"message": "Succeed to POST data",
+		"errors":  nil,
+		"data":    "Reset Password Succeed",
+	})
+}
+
+// validateResetTarget checks that exactly one of phone and email is given.
+func validateResetTarget(noTelp, email string) []string {
+	noTelp, email = strings.TrimSpace(noTelp), strings.TrimSpace(email)
+	switch {
+	case noTelp == "" && email == "":
+		return []string{service.ErrResetTarget.Error()}
+	case noTelp != "" && email != "":
+		return []string{"isi salah satu: no_telp atau email"}
+	case noTelp != "" && !phoneRegex.MatchString(noTelp):
+		return []string{"No Telp tidak valid"}
+	case email != "":
+		if _, err := mail.ParseAddress(email); err != nil {
+			return []string{"Email tidak valid"}
+		}
+	}
+	return nil
+}
+
+// ListSessions lists where the logged in user is signed in.
+func (h *Handler) ListSessions(c *fiber.Ctx) error {
+	uid, _ := c.Locals("user_id").(uint)
+	if uid == 0 {
+		return respondFail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
+	}
+	sid, _ := c.Locals("session_id").(uint)
+	items, err := h.svc.ListSessions(uid, sid)
+	if err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  true,
+		"message": "Succeed to GET data",
+		"errors":  nil,
+		"data":    items,
+	})
+}
+
+// RevokeSession signs the logged in user out of one device.
+func (h *Handler) RevokeSession(c *fiber.Ctx) error {
+	uid, _ := c.Locals("user_id").(uint)
+	if uid == 0 {
+		return respondFail(c, fiber.StatusUnauthorized, "DELETE", "Unauthorized")
+	}
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return respondFail(c, fiber.StatusBadRequest, "DELETE", "id sesi tidak valid")
+	}
+	if err := h.svc.RevokeSession(uid, uint(id)); err != nil {
+		if errors.Is(err, service.ErrSessionNotFound) {
+			return respondFail(c, fiber.StatusNotFound, "DELETE", err.Error())
+		}
+		return respondFail(c, fiber.StatusInternalServerError, "DELETE", err.Error())
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  true,
+		"message": "Succeed to DELETE data",
+		"errors":  nil,
+		"data":    "Logout Succeed",
+	})
+}
+
+func respondFail(c *fiber.Ctx, code int, verb string, errs ...string) error {
+	return c.Status(code).JSON(fiber.Map{
+		"status":  false,
+		"message": "Failed to " + verb + " data",
+		"errors":  errs,
+		"data":    nil,
+	})
+}
+
+// sessionMeta describes the client of a login or refresh request.
+func sessionMeta(c *fiber.Ctx) service.SessionMeta {
+	return service.SessionMeta{UserAgent: c.Get(fiber.HeaderUserAgent), IP: c.IP()}
+}
+
 func valueOrEmpty(p *string) string {
 	if p == nil { return "" }
 	return *p
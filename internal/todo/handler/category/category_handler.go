@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	usersRepo "project-evermos/internal/todo/repository/users"
+	authsvc "project-evermos/internal/todo/service/auth"
 	svc "project-evermos/internal/todo/service/category"
 
 	"github.com/gofiber/fiber/v2"
@@ -49,8 +50,8 @@ func respondFail(c *fiber.Ctx, code int, op string, errs ...string) error {
 
 // -------- Middleware --------
 // AuthJWT reads header 'token' (or Authorization: Bearer) and sets user_id & is_admin in Context.
-// On invalid/absent token, returns 401 with Failed to POST data.
-func AuthJWT(secret string, db *gorm.DB, op string) fiber.Handler {
+// On invalid/absent token or a logged out session, returns 401 with Failed to POST data.
+func AuthJWT(secret string, db *gorm.DB, sessions authsvc.SessionChecker, op string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		tok := strings.TrimSpace(c.Get("token"))
 		if tok == "" {
@@ -73,8 +74,9 @@ func AuthJWT(secret string, db *gorm.DB, op string) fiber.Handler {
 		if err != nil || !tkn.Valid {
 			return respondFail(c, fiber.StatusUnauthorized, op, "Unauthorized")
 		}
-		var uid uint
+		var uid, sid uint
 		if claims, ok := tkn.Claims.(jwt.MapClaims); ok {
+			sid = authsvc.SessionID(claims)
 			if v, ok := claims["user_id"]; ok {
 				switch vv := v.(type) {
 				case float64:
@@ -104,6 +106,13 @@ func AuthJWT(secret string, db *gorm.DB, op string) fiber.Handler {
 		if uid == 0 {
 			return respondFail(c, fiber.StatusUnauthorized, op, "Unauthorized")
 		}
+		active, err := sessions.SessionActive(sid, uid)
+		if err != nil {
+			return respondFail(c, fiber.StatusInternalServerError, op, err.Error())
+		}
+		if !active {
+			return respondFail(c, fiber.StatusUnauthorized, op, "Unauthorized")
+		}
 		// lookup admin flag from DB
 		uRepo := usersRepo.NewRepository(db)
 		u, err := uRepo.FindByID(uid)
@@ -115,6 +124,7 @@ func AuthJWT(secret string, db *gorm.DB, op string) fiber.Handler {
 			isAdmin = *u.IsAdmin
 		}
 		c.Locals("user_id", uid)
+		c.Locals("session_id", sid)
 		c.Locals("is_admin", isAdmin)
 		return c.Next()
 	}
@@ -165,7 +175,55 @@ func (h *Handler) GetByID(c *fiber.Ctx) error {
 		}
 		return respondFail(c, fiber.StatusBadRequest, "GET", err.Error())
 	}
-	return respondOK(c, "GET", fiber.Map{"id": cat.ID, "nama_category": cat.NamaCategory})
+	return respondOK(c, "GET", fiber.Map{"id": cat.ID, "nama_category": cat.NamaCategory, "attributes": cat.Attributes})
+}
+
+// GET /category/:id/attributes (public)
+func (h *Handler) ListAttributes(c *fiber.Ctx) error {
+	id64, err := strconv.ParseUint(strings.TrimSpace(c.Params("id")), 10, 64)
+	if err != nil || id64 == 0 {
+		return respondFail(c, fiber.StatusNotFound, "GET", "No Data Category")
+	}
+	attrs, err := h.s.Attributes(uint(id64))
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return respondFail(c, fiber.StatusNotFound, "GET", "No Data Category")
+		}
+		return respondFail(c, fiber.StatusBadRequest, "GET", err.Error())
+	}
+	return respondOK(c, "GET", attrs)
+}
+
+// PUT /category/:id/attributes (private admin)
+// Replaces the whole attribute schema; attributes are matched by kode.
+func (h *Handler) ReplaceAttributes(c *fiber.Ctx) error {
+	id64, err := strconv.ParseUint(strings.TrimSpace(c.Params("id")), 10, 64)
+	if err != nil || id64 == 0 {
+		return respondFail(c, fiber.StatusNotFound, "PUT", "No Data Category")
+	}
+	var body struct {
+		Attributes []svc.AttributeInput `json:"attributes"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		return respondFail(c, fiber.StatusBadRequest, "PUT", "Invalid JSON")
+	}
+	isAdmin := false
+	if v := c.Locals("is_admin"); v != nil {
+		if b, ok := v.(bool); ok {
+			isAdmin = b
+		}
+	}
+	attrs, err := h.s.ReplaceAttributes(isAdmin, uint(id64), body.Attributes)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return respondFail(c, fiber.StatusNotFound, "PUT", "No Data Category")
+		}
+		if strings.Contains(strings.ToLower(err.Error()), "forbidden") {
+			return respondFail(c, fiber.StatusForbidden, "PUT", "Forbidden")
+		}
+		return respondFail(c, fiber.StatusBadRequest, "PUT", err.Error())
+	}
+	return respondOK(c, "PUT", attrs)
 }
 
 // POST /category (private admin)
@@ -0,0 +1,94 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"project-evermos/internal/storage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Handler serves files of the local storage driver.
+type Handler struct {
+	store  *storage.Local
+	maxAge time.Duration
+}
+
+// NewHandler serves files from store; public files are cacheable for maxAge.
+func NewHandler(store *storage.Local, maxAge time.Duration) *Handler {
+	return &Handler{store: store, maxAge: maxAge}
+}
+
+func fail(c *fiber.Ctx, httpStatus int, errs ...string) error {
+	return c.Status(httpStatus).JSON(fiber.Map{
+		"status":  false,
+		"message": "Failed to GET data",
+		"errors":  errs,
+		"data":    nil,
+	})
+}
+
+// GET /uploads/*
+// Files under private/ need a valid ?expires=&sig= from SignedURL; other
+// files are public. Range requests are handled by SendFile.
+func (h *Handler) Serve(c *fiber.Ctx) error {
+	raw, err := url.PathUnescape(c.Params("*"))
+	if err != nil {
+		return fail(c, fiber.StatusBadRequest, "path tidak valid")
+	}
+	// decide on the cleaned key: "\private/..." or "/private/..." is private too
+	key, err := storage.CleanKey(raw)
+	if err != nil {
+		return fail(c, fiber.StatusBadRequest, "path tidak valid")
+	}
+	private := storage.IsPrivate(key)
+	if private {
+		exp, _ := strconv.ParseInt(c.Query("expires"), 10, 64)
+		if !h.store.Verify(key, exp, c.Query("sig")) {
+			return fail(c, fiber.StatusForbidden, "link tidak valid atau sudah kedaluwarsa")
+		}
+	}
+	path, err := h.store.Path(key)
+	if err != nil {
+		return fail(c, fiber.StatusBadRequest, "path tidak valid")
+	}
+	fi, err := os.Stat(path)
+	if err != nil || fi.IsDir() {
+		if err == nil || errors.Is(err, os.ErrNotExist) {
+			return fail(c, fiber.StatusNotFound, "File tidak ditemukan")
+		}
+		return fail(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	// file names are unique per upload, so size+mtime identify the content
+	etag := fmt.Sprintf(`"%x-%x"`, fi.Size(), fi.ModTime().UnixNano())
+	c.Set(fiber.HeaderETag, etag)
+	if private {
+		// never cache beyond the link's own expiry
+		exp, _ := strconv.ParseInt(c.Query("expires"), 10, 64)
+		c.Set(fiber.HeaderCacheControl, fmt.Sprintf("private, max-age=%d", exp-time.Now().Unix()))
+	} else {
+		c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(h.maxAge.Seconds())))
+	}
+	if etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	return c.SendFile(path)
+}
+
+// etagMatches implements the weak comparison of If-None-Match.
+func etagMatches(header, etag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == "*" || t == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package flashsale
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	model "project-evermos/internal/todo/model/flashsale"
+	fssvc "project-evermos/internal/todo/service/flashsale"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	svc *fssvc.Service
+}
+
+func NewHandler(s *fssvc.Service) *Handler { return &Handler{svc: s} }
+
+func fail(c *fiber.Ctx, httpStatus int, verb string, errs ...string) error {
+	return c.Status(httpStatus).JSON(fiber.Map{
+		"status":  false,
+		"message": fmt.Sprintf("Failed to %s data", verb),
+		"errors":  errs,
+		"data":    nil,
+	})
+}
+
+func respondOK(c *fiber.Ctx, verb string, data interface{}) error {
+	return c.JSON(fiber.Map{
+		"status":  true,
+		"message": fmt.Sprintf("Succeed to %s data", verb),
+		"errors":  nil,
+		"data":    data,
+	})
+}
+
+func jwtUserID(c *fiber.Ctx) (uint, bool) {
+	switch v := c.Locals("user_id").(type) {
+	case uint:
+		return v, v > 0
+	case int:
+		return uint(v), v > 0
+	case float64:
+		return uint(v), v > 0
+	case string:
+		n, err := strconv.ParseUint(v, 10, 64)
+		return uint(n), err == nil && n > 0
+	}
+	return 0, false
+}
+
+// List returns a handler for GET /flash-sales (public, default running
+// campaigns) and GET /admin/flash-sales (default all).
+func (h *Handler) List(defaultStatus string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		limit, _ := strconv.Atoi(c.Query("limit", "20"))
+		page, _ := strconv.Atoi(c.Query("page", "1"))
+		res, err := h.svc.List(c.Query("status", defaultStatus), limit, page)
+		if err != nil {
+			if errors.Is(err, fssvc.ErrStatus) {
+				return fail(c, fiber.StatusBadRequest, "GET", err.Error())
+			}
+			return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
+		}
+		now := time.Now()
+		items := make([]fiber.Map, 0, len(res.Items))
+		for i := range res.Items {
+			items = append(items, mapFlashSale(&res.Items[i], now))
+		}
+		return respondOK(c, "GET", fiber.Map{
+			"items":      items,
+			"total":      res.Total,
+			"page":       res.Page,
+			"limit":      res.Limit,
+			"total_page": (res.Total + int64(res.Limit) - 1) / int64(res.Limit),
+		})
+	}
+}
+
+// GET /flash-sales/:id
+func (h *Handler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return fail(c, fiber.StatusBadRequest, "GET", "id tidak valid")
+	}
+	f, err := h.svc.GetByID(uint(id))
+	if err != nil {
+		return h.respondErr(c, "GET", err)
+	}
+	return respondOK(c, "GET", mapFlashSale(f, time.Now()))
+}
+
+// POST /admin/flash-sales (admin)
+func (h *Handler) Create(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "POST", "Unauthorized")
+	}
+	var in fssvc.Input
+	if err := c.BodyParser(&in); err != nil {
+		return fail(c, fiber.StatusBadRequest, "POST", "invalid payload (mulai/selesai harus RFC3339)")
+	}
+	f, err := h.svc.Create(uid, in)
+	if err != nil {
+		return h.respondErr(c, "POST", err)
+	}
+	return respondOK(c, "POST", mapFlashSale(f, time.Now()))
+}
+
+// PUT /admin/flash-sales/:id (admin), only before the campaign starts
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return fail(c, fiber.StatusBadRequest, "PUT", "id tidak valid")
+	}
+	var in fssvc.Input
+	if err := c.BodyParser(&in); err != nil {
+		return fail(c, fiber.StatusBadRequest, "PUT", "invalid payload (mulai/selesai harus RFC3339)")
+	}
+	f, err := h.svc.Update(uint(id), in)
+	if err != nil {
+		return h.respondErr(c, "PUT", err)
+	}
+	return respondOK(c, "PUT", mapFlashSale(f, time.Now()))
+}
+
+// DELETE /admin/flash-sales/:id (admin): deletes an upcoming campaign or
+// ends a running one
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return fail(c, fiber.StatusBadRequest, "DELETE", "id tidak valid")
+	}
+	if err := h.svc.Delete(uint(id)); err != nil {
+		return h.respondErr(c, "DELETE", err)
+	}
+	return respondOK(c, "DELETE", "")
+}
+
+func (h *Handler) respondErr(c *fiber.Ctx, verb string, err error) error {
+	switch {
+	case errors.Is(err, fssvc.ErrNotFound):
+		return fail(c, fiber.StatusNotFound, verb, "Flash sale tidak ditemukan")
+	case errors.Is(err, fssvc.ErrStarted), errors.Is(err, fssvc.ErrEnded):
+		return fail(c, fiber.StatusConflict, verb, err.Error())
+	}
+	return fail(c, fiber.StatusBadRequest, verb, err.Error())
+}
+
+// mapFlashSale renders a campaign; sisa_detik counts down to the start of
+// an upcoming campaign or to the end of a running one.
+func mapFlashSale(f *model.FlashSale, now time.Time) fiber.Map {
+	status := f.Status(now)
+	var sisa int64
+	switch status {
+	case model.StatusUpcoming:
+		sisa = int64(f.Mulai.Sub(now).Seconds())
+	case model.StatusActive:
+		sisa = int64(f.Selesai.Sub(now).Seconds())
+	}
+	items := make([]fiber.Map, 0, len(f.Items))
+	for _, it := range f.Items {
+		item := fiber.Map{
+			"id":         it.ID,
+			"product":    nil,
+			"variant":    nil,
+			"harga_sale": it.HargaSale,
+			"kuota":      it.Kuota,
+			"terjual":    it.Terjual,
+			"sisa_kuota": it.Kuota - it.Terjual,
+		}
+		normal := 0
+		if it.Produk != nil {
+			normal, _ = strconv.Atoi(it.Produk.HargaKonsumen)
+			item["product"] = fiber.Map{"id": it.Produk.ID, "nama_produk": it.Produk.NamaProduk, "slug": it.Produk.Slug}
+		}
+		if it.Varian != nil {
+			normal = it.Varian.HargaKonsumen
+			item["variant"] = fiber.Map{"id": it.Varian.ID, "sku": it.Varian.SKU, "nama": it.Varian.NamaVarian}
+		}
+		item["harga_normal"] = normal
+		item["diskon_persen"] = model.DiscountPercent(normal, it.HargaSale)
+		items = append(items, item)
+	}
+	return fiber.Map{
+		"id":         f.ID,
+		"nama":       f.Nama,
+		"mulai":      f.Mulai,
+		"selesai":    f.Selesai,
+		"status":     status,
+		"sisa_detik": sisa,
+		"items":      items,
+	}
+}
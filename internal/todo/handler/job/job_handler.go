@@ -0,0 +1,110 @@
+package job
+
+import (
+	"fmt"
+	"strconv"
+
+	model "project-evermos/internal/todo/model/job"
+	jobsvc "project-evermos/internal/todo/service/job"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	svc *jobsvc.Service
+}
+
+func NewHandler(s *jobsvc.Service) *Handler { return &Handler{svc: s} }
+
+func fail(c *fiber.Ctx, httpStatus int, verb string, errs ...string) error {
+	return c.Status(httpStatus).JSON(fiber.Map{
+		"status":  false,
+		"message": fmt.Sprintf("Failed to %s data", verb),
+		"errors":  errs,
+		"data":    nil,
+	})
+}
+
+func respondOK(c *fiber.Ctx, verb string, data interface{}) error {
+	return c.JSON(fiber.Map{
+		"status":  true,
+		"message": fmt.Sprintf("Succeed to %s data", verb),
+		"errors":  nil,
+		"data":    data,
+	})
+}
+
+func jwtUserID(c *fiber.Ctx) (uint, bool) {
+	switch v := c.Locals("user_id").(type) {
+	case uint:
+		return v, v > 0
+	case int:
+		return uint(v), v > 0
+	case float64:
+		return uint(v), v > 0
+	case string:
+		n, err := strconv.ParseUint(v, 10, 64)
+		return uint(n), err == nil && n > 0
+	}
+	return 0, false
+}
+
+// GET /jobs/:id
+// Only the user who started the job can see it.
+func (h *Handler) GetByID(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
+	}
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return fail(c, fiber.StatusBadRequest, "GET", "id tidak valid")
+	}
+	j, err := h.svc.Get(uint(id))
+	if err != nil {
+		return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	if j == nil || j.IDUser != uid {
+		return fail(c, fiber.StatusNotFound, "GET", "Job tidak ditemukan")
+	}
+	out, err := h.mapJob(j)
+	if err != nil {
+		return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	return respondOK(c, "GET", out)
+}
+
+// mapJob renders a job, including a signed error report URL when present.
+func (h *Handler) mapJob(j *model.Job) (fiber.Map, error) {
+	reportURL, err := h.svc.ReportURL(j)
+	if err != nil {
+		return nil, err
+	}
+	progress := 0
+	if j.Total > 0 {
+		progress = j.Processed * 100 / j.Total
+	}
+	var report interface{}
+	if reportURL != "" {
+		report = reportURL
+	}
+	var errMsg interface{}
+	if j.ErrorMessage != "" {
+		errMsg = j.ErrorMessage
+	}
+	return fiber.Map{
+		"id":               j.ID,
+		"tipe":             j.Tipe,
+		"status":           j.Status,
+		"dry_run":          j.DryRun,
+		"total":            j.Total,
+		"processed":        j.Processed,
+		"succeeded":        j.Succeeded,
+		"failed":           j.Failed,
+		"progress":         progress,
+		"error_message":    errMsg,
+		"error_report_url": report,
+		"created_at":       j.CreatedAt,
+		"finished_at":      j.FinishedAt,
+	}, nil
+}
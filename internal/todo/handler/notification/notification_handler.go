@@ -0,0 +1,119 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	model "project-evermos/internal/todo/model/notification"
+	notifsvc "project-evermos/internal/todo/service/notification"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	svc *notifsvc.Service
+}
+
+func NewHandler(s *notifsvc.Service) *Handler { return &Handler{svc: s} }
+
+func fail(c *fiber.Ctx, httpStatus int, verb string, errs ...string) error {
+	return c.Status(httpStatus).JSON(fiber.Map{
+		"status":  false,
+		"message": fmt.Sprintf("Failed to %s data", verb),
+		"errors":  errs,
+		"data":    nil,
+	})
+}
+
+func respondOK(c *fiber.Ctx, verb string, data interface{}) error {
+	return c.JSON(fiber.Map{
+		"status":  true,
+		"message": fmt.Sprintf("Succeed to %s data", verb),
+		"errors":  nil,
+		"data":    data,
+	})
+}
+
+func jwtUserID(c *fiber.Ctx) (uint, bool) {
+	switch v := c.Locals("user_id").(type) {
+	case uint:
+		return v, v > 0
+	case int:
+		return uint(v), v > 0
+	case float64:
+		return uint(v), v > 0
+	case string:
+		n, err := strconv.ParseUint(v, 10, 64)
+		return uint(n), err == nil && n > 0
+	}
+	return 0, false
+}
+
+// GET /notifications?unread=&limit=&page=
+func (h *Handler) List(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
+	}
+	unread, _ := strconv.ParseBool(c.Query("unread", "false"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	res, err := h.svc.List(uid, unread, limit, page)
+	if err != nil {
+		return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	items := make([]fiber.Map, 0, len(res.Items))
+	for i := range res.Items {
+		items = append(items, mapNotification(&res.Items[i]))
+	}
+	return respondOK(c, "GET", fiber.Map{
+		"items":      items,
+		"total":      res.Total,
+		"unread":     res.Unread,
+		"page":       res.Page,
+		"limit":      res.Limit,
+		"total_page": (res.Total + int64(res.Limit) - 1) / int64(res.Limit),
+	})
+}
+
+// PUT /notifications/:id/read, or /notifications/read for all
+func (h *Handler) MarkRead(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "PUT", "Unauthorized")
+	}
+	var id uint64
+	if s := c.Params("id"); s != "" {
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil || n == 0 {
+			return fail(c, fiber.StatusBadRequest, "PUT", "id tidak valid")
+		}
+		id = n
+	}
+	if err := h.svc.MarkRead(uid, uint(id)); err != nil {
+		if errors.Is(err, notifsvc.ErrNotFound) {
+			return fail(c, fiber.StatusNotFound, "PUT", "Notifikasi tidak ditemukan")
+		}
+		return fail(c, fiber.StatusInternalServerError, "PUT", err.Error())
+	}
+	return respondOK(c, "PUT", "")
+}
+
+func mapNotification(n *model.Notification) fiber.Map {
+	var data interface{}
+	if n.DataJSON != "" {
+		_ = json.Unmarshal([]byte(n.DataJSON), &data)
+	}
+	return fiber.Map{
+		"id":         n.ID,
+		"tipe":       n.Tipe,
+		"judul":      n.Judul,
+		"pesan":      n.Pesan,
+		"data":       data,
+		"dibaca":     n.DibacaAt != nil,
+		"dibaca_at":  n.DibacaAt,
+		"created_at": n.CreatedAt,
+	}
+}
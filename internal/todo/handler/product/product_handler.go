@@ -1,30 +1,49 @@
 package product
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/url"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"project-evermos/internal/config"
+	"project-evermos/internal/media"
+	"project-evermos/internal/storage"
+	fsmodel "project-evermos/internal/todo/model/flashsale"
+	jobmodel "project-evermos/internal/todo/model/job"
 	prodmodel "project-evermos/internal/todo/model/product"
+	tokomodel "project-evermos/internal/todo/model/toko"
 	tokoRepo "project-evermos/internal/todo/repository/toko"
+	jobsvc "project-evermos/internal/todo/service/job"
 	prodsvc "project-evermos/internal/todo/service/product"
+	tokosvc "project-evermos/internal/todo/service/toko"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 // Handler struct dan constructor
 type Handler struct {
-	s     *prodsvc.Service
-	tokoR *tokoRepo.Repository
-	cfg   *config.Config
+	s      *prodsvc.Service
+	tokoR  *tokoRepo.Repository
+	access *tokosvc.Access
+	cfg    *config.Config
+	store  storage.Storage
+	jobs   *jobsvc.Service
 }
 
-func NewHandler(s *prodsvc.Service, tokoR *tokoRepo.Repository, cfg *config.Config) *Handler {
-	return &Handler{s: s, tokoR: tokoR, cfg: cfg}
+func NewHandler(s *prodsvc.Service, tokoR *tokoRepo.Repository, cfg *config.Config, store storage.Storage, jobs *jobsvc.Service) *Handler {
+	return &Handler{s: s, tokoR: tokoR, access: tokosvc.NewAccess(tokoR), cfg: cfg, store: store, jobs: jobs}
 }
 
 // Helpers untuk response standar
@@ -83,6 +102,71 @@ func jwtUserID(c *fiber.Ctx) (uint, bool) {
 
 // Endpoint: GET /product
 func (h *Handler) List(c *fiber.Ctx) error {
+	params, code, err := h.listParams(c)
+	if err != nil {
+		return respondFail(c, code, "GET", err.Error())
+	}
+	res, err := h.s.List(params)
+	if err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+
+	out := make([]fiber.Map, 0, len(res.Items))
+	for _, p := range res.Items {
+		out = append(out, mapProductResponse(&p))
+	}
+	return respondOK(c, "GET", out)
+}
+
+// Endpoint: GET /toko/:id_toko/products
+// Same filters as GET /product, paginated.
+func (h *Handler) ListByToko(c *fiber.Ctx) error {
+	id := parseUint(c.Params("id_toko"))
+	if id == 0 {
+		return respondFail(c, fiber.StatusBadRequest, "GET", "id_toko tidak valid")
+	}
+	t, err := h.tokoR.FindByID(id)
+	if err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	if t == nil {
+		return respondFail(c, fiber.StatusNotFound, "GET", "Toko tidak ditemukan")
+	}
+	page, code, err := h.StoreProducts(c, id)
+	if err != nil {
+		return respondFail(c, code, "GET", err.Error())
+	}
+	return respondOK(c, "GET", page)
+}
+
+// StoreProducts returns a page of a toko's products filtered like GET
+// /product. The store page embeds it, see the toko handler.
+func (h *Handler) StoreProducts(c *fiber.Ctx, tokoID uint) (fiber.Map, int, error) {
+	params, code, err := h.listParams(c)
+	if err != nil {
+		return nil, code, err
+	}
+	params.TokoID = tokoID
+	res, err := h.s.List(params)
+	if err != nil {
+		return nil, fiber.StatusInternalServerError, err
+	}
+	out := make([]fiber.Map, 0, len(res.Items))
+	for _, p := range res.Items {
+		out = append(out, mapProductResponse(&p))
+	}
+	return fiber.Map{
+		"items":      out,
+		"total":      res.Total,
+		"page":       res.Page,
+		"limit":      res.Limit,
+		"total_page": (res.Total + int64(res.Limit) - 1) / int64(res.Limit),
+	}, fiber.StatusOK, nil
+}
+
+// listParams reads the filters of GET /product. On error it also returns
+// the HTTP status to answer with.
+func (h *Handler) listParams(c *fiber.Ctx) (prodsvc.ListParams, int, error) {
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	minStr := strings.TrimSpace(c.Query("min_harga", ""))
@@ -99,24 +183,45 @@ func (h *Handler) List(c *fiber.Ctx) error {
 		}
 	}
 
-	items, _, err := h.s.List(prodsvc.ListParams{
-		NamaProduk: c.Query("nama_produk", ""),
-		CategoryID: parseUint(c.Query("category_id", "0")),
-		TokoID:     parseUint(c.Query("toko_id", "0")),
-		MinHarga:   min,
-		MaxHarga:   max,
-		Limit:      limit,
-		Page:       page,
-	})
+	attrs, err := parseAttrFilters(c)
 	if err != nil {
-		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+		return prodsvc.ListParams{}, fiber.StatusBadRequest, err
+	}
+	var verified *bool
+	if v := strings.TrimSpace(c.Query("verified", "")); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return prodsvc.ListParams{}, fiber.StatusBadRequest, errors.New("verified harus true atau false")
+		}
+		verified = &b
 	}
 
-	out := make([]fiber.Map, 0, len(items))
-	for _, p := range items {
-		out = append(out, mapProductResponse(&p))
+	// only published products are public; a logged-in seller also sees
+	// those of the store they act on
+	var ownToko uint
+	if uid, ok := jwtUserID(c); ok {
+		t, _, err := h.access.Store(uid, tokoHeader(c), tokosvc.PermView)
+		switch {
+		case err == nil:
+			ownToko = t.ID
+		case !errors.Is(err, tokosvc.ErrNotFound) && !errors.Is(err, tokosvc.ErrForbidden) && !errors.Is(err, tokosvc.ErrTokoRequired):
+			return prodsvc.ListParams{}, fiber.StatusInternalServerError, err
+		}
 	}
-	return respondOK(c, "GET", out)
+
+	return prodsvc.ListParams{
+		NamaProduk:  c.Query("nama_produk", ""),
+		CategoryID:  parseUint(c.Query("category_id", "0")),
+		TokoID:      parseUint(c.Query("toko_id", "0")),
+		Status:      prodmodel.StatusPublished,
+		OwnerTokoID: ownToko,
+		Attributes:  attrs,
+		MinHarga:    min,
+		MaxHarga:    max,
+		Verified:    verified,
+		Limit:       limit,
+		Page:        page,
+	}, fiber.StatusOK, nil
 }
 
 // Endpoint: GET /product/:id
@@ -126,12 +231,64 @@ func (h *Handler) GetByID(c *fiber.Ctx) error {
 	if err != nil {
 		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
 	}
+	if ok, err := h.visible(c, p); err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	} else if !ok {
+		return respondFail(c, fiber.StatusNotFound, "GET", "No Data Product")
+	}
+	return respondOK(c, "GET", mapProductResponse(p))
+}
+
+// visible reports whether p may be shown to the requester: published
+// products to everyone, others only to members of its store. A nil p is
+// not visible.
+func (h *Handler) visible(c *fiber.Ctx, p *prodmodel.Product) (bool, error) {
 	if p == nil {
+		return false, nil
+	}
+	if p.Status == prodmodel.StatusPublished {
+		return true, nil
+	}
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return false, nil
+	}
+	role, err := h.access.Role(p.IDToko, uid)
+	if errors.Is(err, tokosvc.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return tokosvc.Can(role, tokosvc.PermView), nil
+}
+
+// Endpoint: GET /product/slug/:slug
+// A former slug answers 301 to the product's current slug.
+func (h *Handler) GetBySlug(c *fiber.Ctx) error {
+	p, cur, err := h.s.GetBySlug(c.Params("slug"))
+	if err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	if p == nil && cur != "" {
+		return c.Redirect(redirectURL("/product/slug/"+url.PathEscape(cur), c), fiber.StatusMovedPermanently)
+	}
+	if ok, err := h.visible(c, p); err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	} else if !ok {
 		return respondFail(c, fiber.StatusNotFound, "GET", "No Data Product")
 	}
 	return respondOK(c, "GET", mapProductResponse(p))
 }
 
+// redirectURL appends the request's query string to path.
+func redirectURL(path string, c *fiber.Ctx) string {
+	if q := string(c.Request().URI().QueryString()); q != "" {
+		return path + "?" + q
+	}
+	return path
+}
+
 // Endpoint: POST /product (multipart)
 func (h *Handler) Create(c *fiber.Ctx) error {
 	uid, ok := jwtUserID(c)
@@ -139,13 +296,10 @@ func (h *Handler) Create(c *fiber.Ctx) error {
 		return respondFail(c, fiber.StatusUnauthorized, "POST", "Unauthorized")
 	}
 
-	// validasi kepemilikan: user harus punya toko
-	t, err := h.tokoR.FindByUserID(uid)
-	if err != nil {
-		return respondFail(c, fiber.StatusInternalServerError, "POST", err.Error())
-	}
-	if t == nil {
-		return respondFail(c, fiber.StatusBadRequest, "POST", "User belum memiliki toko")
+	// validasi kepemilikan: user harus punya toko atau mengelola katalog toko
+	t, ok, err := h.requireStore(c, "POST", tokosvc.PermCatalog, fiber.StatusBadRequest)
+	if !ok {
+		return err
 	}
 
 	name := strings.TrimSpace(c.FormValue("nama_produk"))
@@ -153,11 +307,21 @@ func (h *Handler) Create(c *fiber.Ctx) error {
 	hRes := atoiDefault(c.FormValue("harga_reseller"), -1)
 	hKon := atoiDefault(c.FormValue("harga_konsumen"), -1)
 	stok := atoiDefault(c.FormValue("stok"), -1)
+	stokMin := atoiDefault(c.FormValue("stok_minimum"), 0)
+	berat := atoiDefault(c.FormValue("berat"), 0)
+	panjang := atoiDefault(c.FormValue("panjang"), 0)
+	lebar := atoiDefault(c.FormValue("lebar"), 0)
+	tinggi := atoiDefault(c.FormValue("tinggi"), 0)
 	deskripsi := c.FormValue("deskripsi")
+	status := strings.TrimSpace(c.FormValue("status"))
 
-	// siapkan direktori upload
-	if strings.TrimSpace(h.cfg.UploadDirProduct) != "" {
-		_ = os.MkdirAll(h.cfg.UploadDirProduct, 0755)
+	variants, verr := parseVariantsForm(c)
+	if verr != nil {
+		return respondFail(c, fiber.StatusBadRequest, "POST", verr.Error())
+	}
+	attributes, aerr := parseAttributesForm(c)
+	if aerr != nil {
+		return respondFail(c, fiber.StatusBadRequest, "POST", aerr.Error())
 	}
 
 	// proses file
@@ -165,27 +329,15 @@ func (h *Handler) Create(c *fiber.Ctx) error {
 	if ferr != nil && !strings.Contains(strings.ToLower(ferr.Error()), "multipart") {
 		return respondFail(c, fiber.StatusBadRequest, "POST", "Invalid multipart form")
 	}
-	var savedURLs []string
+	var saved []prodsvc.PhotoUpload
 	if form != nil {
-		files := form.File["photos"]
-		for _, f := range files {
-			if !isAllowedImage(f.Filename) {
-				return respondFail(c, fiber.StatusBadRequest, "POST", "Invalid image type")
-			}
-			if f.Size > 5*1024*1024 {
-				return respondFail(c, fiber.StatusBadRequest, "POST", "File too large")
-			}
-			fname := fmt.Sprintf("%d-%s", time.Now().UnixNano(), sanitizeFilename(f.Filename))
-			osPath := filepath.Join(h.cfg.UploadDirProduct, fname)
-			urlPath := filepath.ToSlash(osPath)
-			// pastikan direktori ada
-			_ = os.MkdirAll(filepath.Dir(osPath), 0755)
-			if err1 := c.SaveFile(f, osPath); err1 != nil {
-				return respondFail(c, fiber.StatusInternalServerError, "POST", err1.Error())
-			}
-			base := strings.TrimRight(h.cfg.BaseFileURL, "/")
-			url := base + "/" + strings.TrimLeft(urlPath, "/")
-			savedURLs = append(savedURLs, url)
+		photos, code, err1 := h.savePhotos(c, form.File["photos"])
+		if err1 != nil {
+			return respondFail(c, code, "POST", err1.Error())
+		}
+		saved = photos
+		if code, err1 := h.saveVariantPhotos(c, form, variants); err1 != nil {
+			return respondFail(c, code, "POST", err1.Error())
 		}
 	}
 
@@ -196,9 +348,17 @@ func (h *Handler) Create(c *fiber.Ctx) error {
 		HargaReseller: hRes,
 		HargaKonsumen: hKon,
 		Stok:          stok,
+		StokMinimum:   stokMin,
+		Berat:         berat,
+		Panjang:       panjang,
+		Lebar:         lebar,
+		Tinggi:        tinggi,
 		Deskripsi:     deskripsi,
-		PhotoURLs:     savedURLs,
+		Photos:        saved,
 		TokoID:        t.ID,
+		Variants:      variants,
+		Status:        status,
+		Attributes:    attributes,
 	})
 	if err != nil {
 		return respondFail(c, fiber.StatusBadRequest, "POST", err.Error())
@@ -214,15 +374,15 @@ func (h *Handler) Update(c *fiber.Ctx) error {
 	}
 
 	id := parseUint(c.Params("id"))
-	ownerID, err := h.s.RepoOwnerUserID(id)
+	tokoID, err := h.s.RepoTokoID(id)
 	if err != nil {
 		return respondFail(c, fiber.StatusInternalServerError, "PUT", err.Error())
 	}
-	if ownerID == 0 {
+	if tokoID == 0 {
 		return respondFail(c, fiber.StatusNotFound, "PUT", "No Data Product")
 	}
-	if ownerID != uid {
-		return respondFail(c, fiber.StatusForbidden, "PUT", "Tidak memiliki izin mengelola produk ini")
+	if ok, err := h.canManageCatalog(c, "PUT", tokoID, uid); !ok {
+		return err
 	}
 
 	var namePtr *string
@@ -249,37 +409,43 @@ func (h *Handler) Update(c *fiber.Ctx) error {
 		n := atoiDefault(v, 0)
 		stokPtr = &n
 	}
+	var stokMinPtr *int
+	if v := c.FormValue("stok_minimum"); strings.TrimSpace(v) != "" {
+		n := atoiDefault(v, -1)
+		stokMinPtr = &n
+	}
 	var deskPtr *string
 	if v := c.FormValue("deskripsi"); v != "" {
 		deskPtr = &v
 	}
+	dimPtr := func(field string) *int {
+		v := c.FormValue(field)
+		if strings.TrimSpace(v) == "" {
+			return nil
+		}
+		n := atoiDefault(v, 0)
+		return &n
+	}
 
-	// siapkan direktori upload
-	if strings.TrimSpace(h.cfg.UploadDirProduct) != "" {
-		_ = os.MkdirAll(h.cfg.UploadDirProduct, 0755)
+	variants, verr := parseVariantsForm(c)
+	if verr != nil {
+		return respondFail(c, fiber.StatusBadRequest, "PUT", verr.Error())
+	}
+	attributes, aerr := parseAttributesForm(c)
+	if aerr != nil {
+		return respondFail(c, fiber.StatusBadRequest, "PUT", aerr.Error())
 	}
 
-	var savedURLs []string
+	var saved []prodsvc.PhotoUpload
 	form, _ := c.MultipartForm()
 	if form != nil {
-		files := form.File["photos"]
-		for _, f := range files {
-			if !isAllowedImage(f.Filename) {
-				return respondFail(c, fiber.StatusBadRequest, "PUT", "Invalid image type")
-			}
-			if f.Size > 5*1024*1024 {
-				return respondFail(c, fiber.StatusBadRequest, "PUT", "File too large")
-			}
-			fname := fmt.Sprintf("%d-%s", time.Now().UnixNano(), sanitizeFilename(f.Filename))
-			osPath := filepath.Join(h.cfg.UploadDirProduct, fname)
-			urlPath := filepath.ToSlash(osPath)
-			_ = os.MkdirAll(filepath.Dir(osPath), 0755)
-			if err := c.SaveFile(f, osPath); err != nil {
-				return respondFail(c, fiber.StatusInternalServerError, "PUT", err.Error())
-			}
-			base := strings.TrimRight(h.cfg.BaseFileURL, "/")
-			url := base + "/" + strings.TrimLeft(urlPath, "/")
-			savedURLs = append(savedURLs, url)
+		photos, code, err := h.savePhotos(c, form.File["photos"])
+		if err != nil {
+			return respondFail(c, code, "PUT", err.Error())
+		}
+		saved = photos
+		if code, err := h.saveVariantPhotos(c, form, variants); err != nil {
+			return respondFail(c, code, "PUT", err.Error())
 		}
 	}
 
@@ -291,8 +457,15 @@ func (h *Handler) Update(c *fiber.Ctx) error {
 		HargaReseller: hResPtr,
 		HargaKonsumen: hKonPtr,
 		Stok:          stokPtr,
+		StokMinimum:   stokMinPtr,
 		Deskripsi:     deskPtr,
-		PhotoURLs:     savedURLs,
+		Berat:         dimPtr("berat"),
+		Panjang:       dimPtr("panjang"),
+		Lebar:         dimPtr("lebar"),
+		Tinggi:        dimPtr("tinggi"),
+		Photos:        saved,
+		Variants:      variants,
+		Attributes:    attributes,
 	}); err != nil {
 		msg := strings.ToLower(err.Error())
 		if strings.Contains(msg, "not found") {
@@ -312,32 +485,579 @@ func (h *Handler) Delete(c *fiber.Ctx) error {
 	}
 
 	id := parseUint(c.Params("id"))
-	ownerID, err := h.s.RepoOwnerUserID(id)
+	tokoID, err := h.s.RepoTokoID(id)
 	if err != nil {
 		return respondFail(c, fiber.StatusInternalServerError, "DELETE", err.Error())
 	}
-	if ownerID == 0 {
+	if tokoID == 0 {
 		return respondFail(c, fiber.StatusBadRequest, "DELETE", "record not found")
 	}
-	if ownerID != uid {
-		return respondFail(c, fiber.StatusForbidden, "DELETE", "Tidak memiliki izin mengelola produk ini")
+	if ok, err := h.canManageCatalog(c, "DELETE", tokoID, uid); !ok {
+		return err
 	}
 
 	if err := h.s.Delete(id); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return respondFail(c, fiber.StatusBadRequest, "DELETE", "record not found")
+		}
+		return respondFail(c, fiber.StatusInternalServerError, "DELETE", err.Error())
+	}
+	return respondOK(c, "DELETE", "")
+}
+
+// Endpoint: GET /toko/my/products/trash
+func (h *Handler) ListTrash(c *fiber.Ctx) error {
+	t, ok, err := h.requireStore(c, "GET", tokosvc.PermCatalog, fiber.StatusNotFound)
+	if !ok {
+		return err
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	res, err := h.s.ListTrash(t.ID, limit, page)
+	if err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	retention := time.Duration(h.cfg.TrashRetentionDays) * 24 * time.Hour
+	items := make([]fiber.Map, 0, len(res.Items))
+	for i := range res.Items {
+		p := &res.Items[i]
+		m := mapProductResponse(p)
+		m["deleted_at"] = p.DeletedAt.Time
+		m["purge_at"] = p.DeletedAt.Time.Add(retention)
+		items = append(items, m)
+	}
+	return respondOK(c, "GET", fiber.Map{
+		"items":      items,
+		"total":      res.Total,
+		"page":       res.Page,
+		"limit":      res.Limit,
+		"total_page": (res.Total + int64(res.Limit) - 1) / int64(res.Limit),
+	})
+}
+
+// Endpoint: GET /user/feed
+// New products of the stores the user follows, newest first.
+func (h *Handler) Feed(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return respondFail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	res, err := h.s.ListFeed(uid, limit, page)
+	if err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	items := make([]fiber.Map, 0, len(res.Items))
+	for i := range res.Items {
+		p := &res.Items[i]
+		m := mapProductResponse(p)
+		m["created_at"] = p.CreatedAt
+		items = append(items, m)
+	}
+	return respondOK(c, "GET", fiber.Map{
+		"items":      items,
+		"total":      res.Total,
+		"page":       res.Page,
+		"limit":      res.Limit,
+		"total_page": (res.Total + int64(res.Limit) - 1) / int64(res.Limit),
+	})
+}
+
+// Endpoint: POST /product/:id/restore
+func (h *Handler) Restore(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return respondFail(c, fiber.StatusUnauthorized, "POST", "Unauthorized")
+	}
+	id := parseUint(c.Params("id"))
+	tokoID, err := h.s.TrashedTokoID(id)
+	if err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "POST", err.Error())
+	}
+	if tokoID == 0 {
+		return respondFail(c, fiber.StatusNotFound, "POST", "Produk tidak ada di tempat sampah")
+	}
+	if ok, err := h.canManageCatalog(c, "POST", tokoID, uid); !ok {
+		return err
+	}
+	if err := h.s.Restore(id); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return respondFail(c, fiber.StatusNotFound, "POST", "Produk tidak ada di tempat sampah")
+		}
+		return respondFail(c, fiber.StatusInternalServerError, "POST", err.Error())
+	}
+	p, err := h.s.GetByID(id)
+	if err != nil || p == nil {
+		return respondOK(c, "POST", id)
+	}
+	return respondOK(c, "POST", mapProductResponse(p))
+}
+
+// Endpoint: DELETE /product/:id/photos/:photo_id
+func (h *Handler) DeletePhoto(c *fiber.Ctx) error {
+	_, id, ok, err := h.requireOwner(c, "DELETE")
+	if !ok {
+		return err
+	}
+	if err := h.s.DeletePhoto(id, parseUint(c.Params("photo_id"))); err != nil {
+		if errors.Is(err, prodsvc.ErrPhotoNotFound) {
+			return respondFail(c, fiber.StatusNotFound, "DELETE", "Foto tidak ditemukan")
+		}
 		return respondFail(c, fiber.StatusInternalServerError, "DELETE", err.Error())
 	}
 	return respondOK(c, "DELETE", "")
 }
 
+// Endpoint: PUT /product/:id/photos/order
+func (h *Handler) ReorderPhotos(c *fiber.Ctx) error {
+	_, id, ok, err := h.requireOwner(c, "PUT")
+	if !ok {
+		return err
+	}
+	var body struct {
+		PhotoIDs       []uint `json:"photo_ids"`
+		PrimaryPhotoID *uint  `json:"primary_photo_id"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		return respondFail(c, fiber.StatusBadRequest, "PUT", "Invalid JSON")
+	}
+	p, err := h.s.ReorderPhotos(id, body.PhotoIDs, body.PrimaryPhotoID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return respondFail(c, fiber.StatusNotFound, "PUT", "No Data Product")
+		}
+		return respondFail(c, fiber.StatusBadRequest, "PUT", err.Error())
+	}
+	return respondOK(c, "PUT", mapProductResponse(p)["photos"])
+}
+
+// Endpoint: GET /toko/my/alerts?status=open|resolved|all
+func (h *Handler) ListAlerts(c *fiber.Ctx) error {
+	t, ok, err := h.requireStore(c, "GET", tokosvc.PermCatalog, fiber.StatusNotFound)
+	if !ok {
+		return err
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	res, err := h.s.ListStockAlerts(t.ID, c.Query("status", "open"), limit, page)
+	if err != nil {
+		if errors.Is(err, prodsvc.ErrAlertStatus) {
+			return respondFail(c, fiber.StatusBadRequest, "GET", err.Error())
+		}
+		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	items := make([]fiber.Map, 0, len(res.Items))
+	for _, a := range res.Items {
+		items = append(items, fiber.Map{
+			"id":            a.ID,
+			"product_id":    a.IDProduk,
+			"nama_produk":   a.NamaProduk,
+			"slug":          a.Slug,
+			"variant_id":    a.IDVarian,
+			"sku":           a.SKU,
+			"nama_varian":   a.NamaVarian,
+			"stok":          a.Stok,
+			"batas":         a.Batas,
+			"stok_sekarang": a.StokSekarang,
+			"stok_minimum":  a.StokMinimum,
+			"status":        alertStatus(a.ResolvedAt),
+			"created_at":    a.CreatedAt,
+			"resolved_at":   a.ResolvedAt,
+		})
+	}
+	return respondOK(c, "GET", fiber.Map{
+		"items":      items,
+		"total":      res.Total,
+		"page":       res.Page,
+		"limit":      res.Limit,
+		"total_page": (res.Total + int64(res.Limit) - 1) / int64(res.Limit),
+	})
+}
+
+func alertStatus(resolvedAt *time.Time) string {
+	if resolvedAt != nil {
+		return "resolved"
+	}
+	return "open"
+}
+
+// Endpoint: GET /toko/my/products/export?format=csv|json
+// Streams the whole catalog as a download; the CSV can be edited and sent
+// back to the import endpoint.
+func (h *Handler) Export(c *fiber.Ctx) error {
+	if _, ok := jwtUserID(c); !ok {
+		return respondFail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
+	}
+	format := strings.ToLower(c.Query("format", prodsvc.ExportCSV))
+	if format != prodsvc.ExportCSV && format != prodsvc.ExportJSON {
+		return respondFail(c, fiber.StatusBadRequest, "GET", prodsvc.ErrExportFormat.Error())
+	}
+	t, ok, err := h.requireStore(c, "GET", tokosvc.PermCatalog, fiber.StatusNotFound)
+	if !ok {
+		return err
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == prodsvc.ExportJSON {
+		contentType = fiber.MIMEApplicationJSONCharsetUTF8
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="produk-%d-%s.%s"`, t.ID, time.Now().Format("20060102"), format))
+	tokoID := t.ID
+	// the writer runs after the handler returns, so it must not touch c
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := h.s.Export(context.Background(), tokoID, format, w); err != nil {
+			log.Printf("export toko %d: %v", tokoID, err)
+		}
+	})
+	return nil
+}
+
+// Endpoint: POST /toko/my/products/import
+// Accepts a CSV as multipart field "file" or as a text/csv body. The file is
+// checked synchronously; rows are validated and created by a background job.
+func (h *Handler) Import(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return respondFail(c, fiber.StatusUnauthorized, "POST", "Unauthorized")
+	}
+	t, ok, err := h.requireStore(c, "POST", tokosvc.PermCatalog, fiber.StatusBadRequest)
+	if !ok {
+		return err
+	}
+
+	var src io.Reader
+	if f, ferr := c.FormFile("file"); ferr == nil && f != nil {
+		fh, err := f.Open()
+		if err != nil {
+			return respondFail(c, fiber.StatusBadRequest, "POST", "file tidak dapat dibaca")
+		}
+		defer fh.Close()
+		src = fh
+	} else if strings.Contains(strings.ToLower(c.Get("Content-Type")), "csv") {
+		src = bytes.NewReader(c.Body())
+	} else {
+		return respondFail(c, fiber.StatusBadRequest, "POST", "file CSV wajib diisi (field file)")
+	}
+	rows, err := prodsvc.ParseImportCSV(src)
+	if err != nil {
+		return respondFail(c, fiber.StatusBadRequest, "POST", err.Error())
+	}
+	dryRun := parseBool(c.Query("dry_run", c.FormValue("dry_run")))
+
+	tokoID := t.ID
+	j := &jobmodel.Job{Tipe: jobmodel.TypeProductImport, IDUser: uid, IDToko: &tokoID, DryRun: dryRun, Total: len(rows)}
+	params := prodsvc.ImportParams{UserID: uid, TokoID: t.ID, DryRun: dryRun, Rows: rows}
+	err = h.jobs.Start(j, func(ctx context.Context, tr *jobsvc.Tracker) (jobsvc.Result, error) {
+		failed, err := h.s.Import(ctx, params, tr)
+		if len(failed) == 0 {
+			return jobsvc.Result{}, err
+		}
+		return jobsvc.Result{Report: prodsvc.ImportErrorsCSV(failed), ReportName: "import-errors.csv"}, err
+	})
+	if err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "POST", err.Error())
+	}
+	c.Status(fiber.StatusAccepted)
+	return respondOK(c, "POST", fiber.Map{
+		"job_id":  j.ID,
+		"status":  j.Status,
+		"total":   j.Total,
+		"dry_run": j.DryRun,
+	})
+}
+
+// Endpoint: POST /product/:id/submit
+// Sends a draft or rejected product to the admin review queue.
+func (h *Handler) Submit(c *fiber.Ctx) error {
+	_, id, ok, err := h.requireOwner(c, "POST")
+	if !ok {
+		return err
+	}
+	p, err := h.s.Submit(id)
+	if err != nil {
+		if errors.Is(err, prodsvc.ErrNotSubmittable) || errors.Is(err, prodsvc.ErrNoOrigin) {
+			return respondFail(c, fiber.StatusConflict, "POST", err.Error())
+		}
+		return respondFail(c, fiber.StatusInternalServerError, "POST", err.Error())
+	}
+	return respondOK(c, "POST", mapProductResponse(p))
+}
+
+// Endpoint: GET /admin/products?status=pending_review (admin)
+func (h *Handler) ListReview(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	res, err := h.s.ListForReview(c.Query("status", ""), limit, page)
+	if err != nil {
+		if errors.Is(err, prodsvc.ErrReviewStatus) {
+			return respondFail(c, fiber.StatusBadRequest, "GET", err.Error())
+		}
+		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	items := make([]fiber.Map, 0, len(res.Items))
+	for i := range res.Items {
+		p := &res.Items[i]
+		m := mapProductResponse(p)
+		m["reviewed_by"] = p.ReviewedBy
+		m["reviewed_at"] = p.ReviewedAt
+		m["updated_at"] = p.UpdatedAt
+		items = append(items, m)
+	}
+	return respondOK(c, "GET", fiber.Map{
+		"items":      items,
+		"total":      res.Total,
+		"page":       res.Page,
+		"limit":      res.Limit,
+		"total_page": (res.Total + int64(res.Limit) - 1) / int64(res.Limit),
+	})
+}
+
+// Endpoint: POST /admin/products/:id/approve (admin)
+func (h *Handler) Approve(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return respondFail(c, fiber.StatusUnauthorized, "POST", "Unauthorized")
+	}
+	p, err := h.s.Approve(parseUint(c.Params("id")), uid)
+	return h.respondReview(c, p, err)
+}
+
+// Endpoint: POST /admin/products/:id/reject (admin)
+func (h *Handler) Reject(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return respondFail(c, fiber.StatusUnauthorized, "POST", "Unauthorized")
+	}
+	var body struct {
+		Alasan string `json:"alasan"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		return respondFail(c, fiber.StatusBadRequest, "POST", "Invalid JSON")
+	}
+	p, err := h.s.Reject(parseUint(c.Params("id")), uid, body.Alasan)
+	return h.respondReview(c, p, err)
+}
+
+// respondReview writes the result of an approve/reject decision.
+func (h *Handler) respondReview(c *fiber.Ctx, p *prodmodel.Product, err error) error {
+	if err != nil {
+		switch {
+		case strings.Contains(strings.ToLower(err.Error()), "not found"):
+			return respondFail(c, fiber.StatusNotFound, "POST", "No Data Product")
+		case errors.Is(err, prodsvc.ErrNotReviewable), errors.Is(err, prodsvc.ErrNotRejectable):
+			return respondFail(c, fiber.StatusConflict, "POST", err.Error())
+		}
+		return respondFail(c, fiber.StatusBadRequest, "POST", err.Error())
+	}
+	m := mapProductResponse(p)
+	m["reviewed_by"] = p.ReviewedBy
+	m["reviewed_at"] = p.ReviewedAt
+	return respondOK(c, "POST", m)
+}
+
+// requireOwner resolves :id and checks that the JWT user may manage the
+// catalog of the product's store. When ok is false a failure response has
+// been written; return err as-is.
+func (h *Handler) requireOwner(c *fiber.Ctx, verb string) (uid, id uint, ok bool, err error) {
+	uid, okJWT := jwtUserID(c)
+	if !okJWT {
+		return 0, 0, false, respondFail(c, fiber.StatusUnauthorized, verb, "Unauthorized")
+	}
+	id = parseUint(c.Params("id"))
+	tokoID, err := h.s.RepoTokoID(id)
+	if err != nil {
+		return 0, 0, false, respondFail(c, fiber.StatusInternalServerError, verb, err.Error())
+	}
+	if tokoID == 0 {
+		return 0, 0, false, respondFail(c, fiber.StatusNotFound, verb, "No Data Product")
+	}
+	if ok, err := h.canManageCatalog(c, verb, tokoID, uid); !ok {
+		return 0, 0, false, err
+	}
+	return uid, id, true, nil
+}
+
+// canManageCatalog checks that uid may manage the products of a store.
+// When ok is false a failure response has been written.
+func (h *Handler) canManageCatalog(c *fiber.Ctx, verb string, tokoID, uid uint) (bool, error) {
+	if _, err := h.access.Authorize(tokoID, uid, tokosvc.PermCatalog); err != nil {
+		if errors.Is(err, tokosvc.ErrForbidden) || errors.Is(err, tokosvc.ErrNotFound) {
+			return false, respondFail(c, fiber.StatusForbidden, verb, "Tidak memiliki izin mengelola produk ini")
+		}
+		return false, respondFail(c, fiber.StatusInternalServerError, verb, err.Error())
+	}
+	return true, nil
+}
+
+// requireStore resolves the store the JWT user acts on (the
+// tokosvc.HeaderToko header, else their own store) and checks perm.
+// noStore is the status answered when the user has no store. When ok is
+// false a failure response has been written; return err as-is.
+func (h *Handler) requireStore(c *fiber.Ctx, verb string, perm tokosvc.Permission, noStore int) (*tokomodel.Toko, bool, error) {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return nil, false, respondFail(c, fiber.StatusUnauthorized, verb, "Unauthorized")
+	}
+	hdr := tokoHeader(c)
+	t, _, err := h.access.Store(uid, hdr, perm)
+	switch {
+	case err == nil:
+		return t, true, nil
+	case errors.Is(err, tokosvc.ErrNotFound) && hdr == 0 && noStore == fiber.StatusBadRequest:
+		return nil, false, respondFail(c, noStore, verb, "User belum memiliki toko")
+	case errors.Is(err, tokosvc.ErrNotFound):
+		return nil, false, respondFail(c, fiber.StatusNotFound, verb, "Toko tidak ditemukan")
+	case errors.Is(err, tokosvc.ErrTokoRequired):
+		return nil, false, respondFail(c, fiber.StatusBadRequest, verb, err.Error())
+	case errors.Is(err, tokosvc.ErrForbidden):
+		return nil, false, respondFail(c, fiber.StatusForbidden, verb, "Tidak memiliki izin mengelola toko ini")
+	}
+	return nil, false, respondFail(c, fiber.StatusInternalServerError, verb, err.Error())
+}
+
+// tokoHeader returns the store chosen with tokosvc.HeaderToko, 0 when none.
+func tokoHeader(c *fiber.Ctx) uint {
+	return parseUint(strings.TrimSpace(c.Get(tokosvc.HeaderToko)))
+}
+
+// Endpoint: POST /product/:id/stock/adjust
+func (h *Handler) AdjustStock(c *fiber.Ctx) error {
+	uid, id, ok, err := h.requireOwner(c, "POST")
+	if !ok {
+		return err
+	}
+	var body struct {
+		VariantID uint   `json:"variant_id"`
+		Jumlah    *int   `json:"jumlah"`
+		StokBaru  *int   `json:"stok_baru"`
+		Alasan    string `json:"alasan"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		return respondFail(c, fiber.StatusBadRequest, "POST", "Invalid JSON")
+	}
+	m, err := h.s.AdjustStock(prodsvc.AdjustStockParams{
+		UserID:    uid,
+		ProductID: id,
+		VariantID: body.VariantID,
+		Jumlah:    body.Jumlah,
+		StokBaru:  body.StokBaru,
+		Alasan:    body.Alasan,
+	})
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return respondFail(c, fiber.StatusNotFound, "POST", "No Data Product")
+		}
+		return respondFail(c, fiber.StatusBadRequest, "POST", err.Error())
+	}
+	return respondOK(c, "POST", mapStockMovement(m))
+}
+
+// Endpoint: GET /product/:id/stock/history
+func (h *Handler) StockHistory(c *fiber.Ctx) error {
+	_, id, ok, err := h.requireOwner(c, "GET")
+	if !ok {
+		return err
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	res, err := h.s.StockHistory(prodsvc.StockHistoryParams{
+		ProductID: id,
+		VariantID: parseUint(c.Query("variant_id", "0")),
+		Tipe:      c.Query("tipe", ""),
+		Limit:     limit,
+		Page:      page,
+	})
+	if err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	items := make([]fiber.Map, 0, len(res.Items))
+	for i := range res.Items {
+		items = append(items, mapStockMovement(&res.Items[i]))
+	}
+	return respondOK(c, "GET", fiber.Map{
+		"items":      items,
+		"total":      res.Total,
+		"page":       res.Page,
+		"limit":      res.Limit,
+		"total_page": (res.Total + int64(res.Limit) - 1) / int64(res.Limit),
+	})
+}
+
+// Endpoint: GET /product/:id/price-history (public)
+func (h *Handler) PriceHistory(c *fiber.Ctx) error {
+	id := parseUint(c.Params("id"))
+	p, err := h.s.GetByID(id)
+	if err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	if ok, err := h.visible(c, p); err != nil {
+		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	} else if !ok {
+		return respondFail(c, fiber.StatusNotFound, "GET", "No Data Product")
+	}
+	days, _ := strconv.Atoi(c.Query("days", "90"))
+	res, err := h.s.PriceHistory(prodsvc.PriceHistoryParams{
+		ProductID: id,
+		VariantID: parseUint(c.Query("variant_id", "0")),
+		Days:      days,
+	})
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return respondFail(c, fiber.StatusNotFound, "GET", "No Data Product")
+		}
+		if errors.Is(err, prodsvc.ErrVariantInvalid) {
+			return respondFail(c, fiber.StatusBadRequest, "GET", err.Error())
+		}
+		return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	items := make([]fiber.Map, 0, len(res.Items))
+	for _, r := range res.Items {
+		items = append(items, fiber.Map{
+			"harga_reseller": r.HargaReseller,
+			"harga_konsumen": r.HargaKonsumen,
+			"sumber":         r.Sumber,
+			"berlaku_sejak":  r.CreatedAt,
+		})
+	}
+	return respondOK(c, "GET", fiber.Map{
+		"days":               res.Days,
+		"harga_reseller":     res.HargaReseller,
+		"harga_konsumen":     res.HargaKonsumen,
+		"harga_konsumen_min": res.MinKonsumen,
+		"harga_konsumen_max": res.MaxKonsumen,
+		"items":              items,
+	})
+}
+
+func mapStockMovement(m *prodmodel.StockMovement) fiber.Map {
+	return fiber.Map{
+		"id":           m.ID,
+		"product_id":   m.IDProduk,
+		"variant_id":   m.IDVarian,
+		"sku":          m.SKU,
+		"nama_varian":  m.NamaVarian,
+		"tipe":         m.Tipe,
+		"jumlah":       m.Jumlah,
+		"stok_sebelum": m.StokSebelum,
+		"stok_sesudah": m.StokSesudah,
+		"alasan":       m.Alasan,
+		"user_id":      m.IDUser,
+		"trx_id":       m.IDTrx,
+		"created_at":   m.CreatedAt,
+	}
+}
+
 // Mapper respons produk
 func mapProductResponse(p *prodmodel.Product) fiber.Map {
-	photos := make([]fiber.Map, 0, len(p.Photos))
-	for _, ph := range p.Photos {
-		photos = append(photos, fiber.Map{"id": ph.ID, "product_id": ph.IDProduk, "url": ph.URL})
-	}
+	now := time.Now()
+	photos := mapPhotos(p.Photos)
 	var toko fiber.Map
 	if p.Toko != nil {
-		toko = fiber.Map{"id": p.Toko.ID, "nama_toko": p.Toko.NamaToko, "url_foto": p.Toko.UrlFoto}
+		toko = fiber.Map{"id": p.Toko.ID, "nama_toko": p.Toko.NamaToko, "slug": p.Toko.Slug, "url_foto": p.Toko.UrlFoto,
+			"status": tokosvc.StatusOf(p.Toko.Operasional, now), "dikirim_dari": tokosvc.ShipsFrom(p.Toko.AlamatAsal),
+			"verified": p.Toko.Verified}
 	} else {
 		toko = fiber.Map{"id": p.IDToko}
 	}
@@ -347,24 +1067,235 @@ func mapProductResponse(p *prodmodel.Product) fiber.Map {
 	} else {
 		category = fiber.Map{"id": p.IDCategory}
 	}
+	options := make([]fiber.Map, 0, len(p.Options))
+	for _, o := range p.Options {
+		var nilai []string
+		_ = json.Unmarshal([]byte(o.NilaiJSON), &nilai)
+		options = append(options, fiber.Map{"id": o.ID, "nama": o.NamaOpsi, "nilai": nilai})
+	}
+	variants := make([]fiber.Map, 0, len(p.Variants))
+	for _, v := range p.Variants {
+		opsi := map[string]string{}
+		_ = json.Unmarshal([]byte(v.OpsiJSON), &opsi)
+		vPhotos := mapPhotos(v.Photos)
+		variants = append(variants, fiber.Map{
+			"id":             v.ID,
+			"sku":            v.SKU,
+			"nama":           v.NamaVarian,
+			"opsi":           opsi,
+			"harga_reseller": v.HargaReseller,
+			"harga_konsumen": v.HargaKonsumen,
+			"stok":           v.Stok,
+			"berat":          v.Berat,
+			"panjang":        v.Panjang,
+			"lebar":          v.Lebar,
+			"tinggi":         v.Tinggi,
+			"photos":         vPhotos,
+			"flash_sale":     mapSale(p.Sale(&v), v.HargaKonsumen, now),
+		})
+	}
+	attributes := make([]fiber.Map, 0, len(p.Attributes))
+	for _, a := range prodsvc.SortedAttributes(p) {
+		attributes = append(attributes, fiber.Map{"kode": a.Atribut.Kode, "nama": a.Atribut.Nama, "nilai": a.Nilai})
+	}
 	// harga di DB string -> ubah ke int untuk output
 	hargaRes := atoiSafe(p.HargaReseller)
 	hargaKon := atoiSafe(p.HargaKonsumen)
 
 	return fiber.Map{
-		"id":             p.ID,
-		"nama_produk":    p.NamaProduk,
-		"slug":           p.Slug,
-		"harga_reseller": hargaRes,
-		"harga_konsumen": hargaKon,
-		"stok":           p.Stok,
-		"deskripsi":      p.Deskripsi,
-		"toko":           toko,
-		"category":       category,
-		"photos":         photos,
+		"id":               p.ID,
+		"nama_produk":      p.NamaProduk,
+		"slug":             p.Slug,
+		"harga_reseller":   hargaRes,
+		"harga_konsumen":   hargaKon,
+		"stok":             p.Stok,
+		"stok_minimum":     p.StokMinimum,
+		"berat":            p.Berat,
+		"panjang":          p.Panjang,
+		"lebar":            p.Lebar,
+		"tinggi":           p.Tinggi,
+		"deskripsi":        p.Deskripsi,
+		"status":           p.Status,
+		"alasan_penolakan": p.AlasanPenolakan,
+		"toko":             toko,
+		"category":         category,
+		"photos":           photos,
+		"options":          options,
+		"variants":         variants,
+		"attributes":       attributes,
+		"flash_sale":       mapSale(p.Sale(nil), hargaKon, now),
+	}
+}
+
+// mapSale renders a running flash sale price against the normal consumer
+// price, with a countdown to the end of the campaign. nil when there is no
+// sale or it is not cheaper, as checkout then charges the normal price.
+func mapSale(s *prodmodel.SaleItem, normal int, now time.Time) fiber.Map {
+	if s == nil || s.HargaSale >= normal {
+		return nil
+	}
+	sisa := int64(s.Selesai.Sub(now).Seconds())
+	if sisa < 0 {
+		sisa = 0
+	}
+	return fiber.Map{
+		"id":            s.IDFlashSale,
+		"nama":          s.NamaCampaign,
+		"harga_normal":  normal,
+		"harga_sale":    s.HargaSale,
+		"diskon_persen": fsmodel.DiscountPercent(normal, s.HargaSale),
+		"sisa_kuota":    s.Kuota - s.Terjual,
+		"selesai":       s.Selesai,
+		"sisa_detik":    sisa,
 	}
 }
 
+// mapPhotos keeps the stored order (urutan) but puts the primary photo first.
+func mapPhotos(list []prodmodel.Photo) []fiber.Map {
+	sorted := make([]prodmodel.Photo, len(list))
+	copy(sorted, list)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].IsPrimary && !sorted[j].IsPrimary })
+	out := make([]fiber.Map, 0, len(sorted))
+	for _, ph := range sorted {
+		out = append(out, fiber.Map{
+			"id":         ph.ID,
+			"product_id": ph.IDProduk,
+			"url":        ph.URL,
+			"sizes":      media.ParseSizes(ph.SizesJSON),
+			"urutan":     ph.Urutan,
+			"is_primary": ph.IsPrimary,
+		})
+	}
+	return out
+}
+
+// parseVariantsForm reads optional "options" and "variants" JSON form fields.
+// Returns nil when neither field is sent so updates keep current variants.
+func parseVariantsForm(c *fiber.Ctx) (*prodsvc.VariantsInput, error) {
+	optRaw := strings.TrimSpace(c.FormValue("options"))
+	varRaw := strings.TrimSpace(c.FormValue("variants"))
+	if optRaw == "" && varRaw == "" {
+		return nil, nil
+	}
+	in := &prodsvc.VariantsInput{}
+	if optRaw != "" {
+		if err := json.Unmarshal([]byte(optRaw), &in.Options); err != nil {
+			return nil, fmt.Errorf("options harus JSON array")
+		}
+	}
+	if varRaw != "" {
+		if err := json.Unmarshal([]byte(varRaw), &in.Variants); err != nil {
+			return nil, fmt.Errorf("variants harus JSON array")
+		}
+	}
+	return in, nil
+}
+
+// parseAttributesForm reads the attributes form field, a JSON object of
+// kode -> nilai (string, number or boolean). nil when the field is absent.
+func parseAttributesForm(c *fiber.Ctx) (map[string]string, error) {
+	raw := strings.TrimSpace(c.FormValue("attributes"))
+	if raw == "" {
+		return nil, nil
+	}
+	var in map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &in); err != nil {
+		return nil, fmt.Errorf("attributes harus JSON object")
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		switch vv := v.(type) {
+		case nil:
+			out[k] = ""
+		case string:
+			out[k] = vv
+		case float64:
+			out[k] = strconv.FormatFloat(vv, 'f', -1, 64)
+		case bool:
+			out[k] = strconv.FormatBool(vv)
+		default:
+			return nil, fmt.Errorf("attributes.%s harus string, angka atau boolean", k)
+		}
+	}
+	return out, nil
+}
+
+// maxAttrFilters caps the attr[...] filters of one listing request.
+const maxAttrFilters = 10
+
+// parseAttrFilters reads attr[kode]=v1,v2 query parameters.
+func parseAttrFilters(c *fiber.Ctx) (map[string][]string, error) {
+	out := map[string][]string{}
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		k := string(key)
+		if !strings.HasPrefix(k, "attr[") || !strings.HasSuffix(k, "]") {
+			return
+		}
+		kode := strings.ToLower(strings.TrimSpace(k[len("attr[") : len(k)-1]))
+		for _, v := range strings.Split(string(value), ",") {
+			if v = strings.TrimSpace(v); kode != "" && v != "" {
+				out[kode] = append(out[kode], v)
+			}
+		}
+	})
+	if len(out) > maxAttrFilters {
+		return nil, fmt.Errorf("maksimal %d filter attr", maxAttrFilters)
+	}
+	return out, nil
+}
+
+// savePhotos validates, processes and stores uploaded product photos.
+// On failure it also returns the HTTP status to respond with.
+func (h *Handler) savePhotos(c *fiber.Ctx, files []*multipart.FileHeader) ([]prodsvc.PhotoUpload, int, error) {
+	// proses semua file dulu supaya tidak ada file tersimpan jika salah satu invalid
+	results := make([]*media.Result, 0, len(files))
+	for _, f := range files {
+		res, err := media.ProcessFile(f)
+		if err != nil {
+			return nil, fiber.StatusBadRequest, err
+		}
+		results = append(results, res)
+	}
+	var saved []prodsvc.PhotoUpload
+	for i, res := range results {
+		name := fmt.Sprintf("%d-%s", time.Now().UnixNano(), baseName(files[i].Filename))
+		sizes, err := res.Store(c.UserContext(), h.store, "products", name)
+		if err != nil {
+			return nil, fiber.StatusInternalServerError, err
+		}
+		saved = append(saved, prodsvc.PhotoUpload{URL: sizes["original"].URL, Sizes: sizes})
+	}
+	return saved, fiber.StatusOK, nil
+}
+
+// saveVariantPhotos stores files sent as "variant_photos[<sku>]" onto the matching variant.
+func (h *Handler) saveVariantPhotos(c *fiber.Ctx, form *multipart.Form, in *prodsvc.VariantsInput) (int, error) {
+	for key, files := range form.File {
+		if !strings.HasPrefix(key, "variant_photos[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		sku := strings.TrimSpace(key[len("variant_photos[") : len(key)-1])
+		idx := -1
+		if in != nil {
+			for i := range in.Variants {
+				if strings.EqualFold(strings.TrimSpace(in.Variants[i].SKU), sku) {
+					idx = i
+					break
+				}
+			}
+		}
+		if idx < 0 {
+			return fiber.StatusBadRequest, fmt.Errorf("variant_photos untuk sku %q tidak ada di variants", sku)
+		}
+		photos, code, err := h.savePhotos(c, files)
+		if err != nil {
+			return code, err
+		}
+		in.Variants[idx].Photos = append(in.Variants[idx].Photos, photos...)
+	}
+	return fiber.StatusOK, nil
+}
+
 // Utilities
 func parseUint(s string) uint {
 	n, _ := strconv.Atoi(strings.TrimSpace(s))
@@ -380,17 +1311,24 @@ func atoiDefault(s string, def int) int {
 	}
 	return n
 }
+func parseBool(s string) bool {
+	b, _ := strconv.ParseBool(strings.TrimSpace(s))
+	return b
+}
 func atoiSafe(s string) int {
 	n, _ := strconv.Atoi(strings.TrimSpace(s))
 	return n
 }
-func isAllowedImage(name string) bool {
-	lower := strings.ToLower(name)
-	return strings.HasSuffix(lower, ".jpg") || strings.HasSuffix(lower, ".jpeg") || strings.HasSuffix(lower, ".png")
-}
 func sanitizeFilename(name string) string {
 	name = strings.ReplaceAll(name, " ", "-")
 	name = strings.ReplaceAll(name, "..", "")
 	name = strings.Trim(name, "-")
 	return name
 }
+
+// baseName is the sanitized upload name without extension; the stored
+// extension comes from the processed output.
+func baseName(name string) string {
+	name = sanitizeFilename(filepath.Base(name))
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
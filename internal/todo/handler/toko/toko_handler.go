@@ -1,14 +1,22 @@
 package toko
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"os"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
+	"project-evermos/internal/media"
+	"project-evermos/internal/storage"
+	authsvc "project-evermos/internal/todo/service/auth"
 	tokosvc "project-evermos/internal/todo/service/toko"
 
 	"github.com/gofiber/fiber/v2"
@@ -16,12 +24,25 @@ import (
 )
 
 // NewHandler constructs toko HTTP handlers
-func NewHandler(s *tokosvc.Service) *Handler { return &Handler{svc: s} }
+func NewHandler(s *tokosvc.Service, store storage.Storage) *Handler {
+	return &Handler{svc: s, store: store}
+}
 
 type Handler struct {
-	svc *tokosvc.Service
+	svc      *tokosvc.Service
+	store    storage.Storage
+	products ProductLister
+}
+
+// ProductLister lists the products of a toko for its store page, with the
+// filters of GET /product. The product handler implements it.
+type ProductLister interface {
+	StoreProducts(c *fiber.Ctx, tokoID uint) (fiber.Map, int, error)
 }
 
+// SetProducts enables the product list on store pages.
+func (h *Handler) SetProducts(p ProductLister) { h.products = p }
+
 // ---------- Helpers ----------
 
 // jwtUserID extracts user ID set by middleware
@@ -59,6 +80,27 @@ func jwtUserID(c *fiber.Ctx) (uint, bool) {
 	return 0, false
 }
 
+// tokoHeader returns the store chosen with tokosvc.HeaderToko, 0 when none.
+func tokoHeader(c *fiber.Ctx) uint {
+	n, _ := strconv.ParseUint(strings.TrimSpace(c.Get(tokosvc.HeaderToko)), 10, 64)
+	return uint(n)
+}
+
+// respondErr maps toko service errors to responses.
+func (h *Handler) respondErr(c *fiber.Ctx, verb string, err error) error {
+	switch {
+	case errors.Is(err, tokosvc.ErrNotFound):
+		return fail(c, fiber.StatusNotFound, verb, "Toko tidak ditemukan")
+	case errors.Is(err, tokosvc.ErrForbidden):
+		return fail(c, fiber.StatusForbidden, verb, "Tidak memiliki izin mengelola toko ini")
+	case errors.Is(err, tokosvc.ErrInvited), errors.Is(err, tokosvc.ErrMember), errors.Is(err, tokosvc.ErrInvitationClosed):
+		return fail(c, fiber.StatusConflict, verb, err.Error())
+	case errors.Is(err, tokosvc.ErrTokoRequired):
+		return fail(c, fiber.StatusBadRequest, verb, err.Error())
+	}
+	return fail(c, fiber.StatusInternalServerError, verb, err.Error())
+}
+
 func imgURLExtValid(u string) bool {
 	u = strings.TrimSpace(strings.ToLower(u))
 	if u == "" {
@@ -77,18 +119,6 @@ func imgURLExtValid(u string) bool {
 	return false
 }
 
-func isAllowedImageFilename(name string) bool {
-	name = strings.ToLower(strings.TrimSpace(name))
-	allowed := []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
-	ext := filepath.Ext(name)
-	for _, e := range allowed {
-		if ext == e {
-			return true
-		}
-	}
-	return false
-}
-
 func fail(c *fiber.Ctx, httpStatus int, verb string, errs ...string) error {
 	if len(errs) == 0 {
 		errs = []string{"Unknown error"}
@@ -110,6 +140,35 @@ func respondOK(c *fiber.Ctx, verb string, data interface{}) error {
 	})
 }
 
+// addProducts adds a page of the store's products to a store page. On
+// error it also returns the HTTP status to answer with.
+func (h *Handler) addProducts(c *fiber.Ctx, data map[string]interface{}, tokoID uint) (int, error) {
+	if h.products == nil {
+		return fiber.StatusOK, nil
+	}
+	page, code, err := h.products.StoreProducts(c, tokoID)
+	if err != nil {
+		return code, err
+	}
+	data["products"] = page
+	return fiber.StatusOK, nil
+}
+
+// addFollowing tells a signed-in viewer of a store page whether they follow
+// the store; anonymous viewers get no "diikuti".
+func (h *Handler) addFollowing(c *fiber.Ctx, data map[string]interface{}, tokoID uint) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return nil
+	}
+	following, err := h.svc.IsFollowing(tokoID, uid)
+	if err != nil {
+		return err
+	}
+	data["diikuti"] = following
+	return nil
+}
+
 // ---------- Handlers ----------
 
 // GET /toko/my
@@ -118,9 +177,9 @@ func (h *Handler) GetMy(c *fiber.Ctx) error {
 	if !ok {
 		return fail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
 	}
-	data, err := h.svc.GetMyStore(uid)
+	data, err := h.svc.GetMyStore(uid, tokoHeader(c))
 	if err != nil {
-		return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
+		return h.respondErr(c, "GET", err)
 	}
 	// If user has no store, return data: null (as per policy)
 	return respondOK(c, "GET", data)
@@ -140,21 +199,21 @@ func (h *Handler) Update(c *fiber.Ctx) error {
 
 	// Support both JSON and form-data (multipart or x-www-form-urlencoded)
 	var namaToko, urlFoto string
+	var fotoSizes media.Sizes
 	ct := strings.ToLower(c.Get("Content-Type"))
 	if strings.Contains(ct, "multipart/form-data") || strings.Contains(ct, "application/x-www-form-urlencoded") {
 		// try file first
 		if f, ferr := c.FormFile("photo"); ferr == nil && f != nil && f.Size > 0 {
-			if !isAllowedImageFilename(f.Filename) {
-				return fail(c, fiber.StatusBadRequest, "UPDATE", "photo harus file gambar (jpg|jpeg|png|gif|webp)")
+			res, perr := media.ProcessFile(f)
+			if perr != nil {
+				return fail(c, fiber.StatusBadRequest, "UPDATE", "photo: "+perr.Error())
 			}
-			_ = os.MkdirAll("uploads/stores", 0755)
-			fname := fmt.Sprintf("toko-%d%s", time.Now().UnixNano(), filepath.Ext(f.Filename))
-			dest := filepath.Join("uploads/stores", fname)
-			if err := c.SaveFile(f, dest); err != nil {
+			sizes, serr := res.Store(c.UserContext(), h.store, "stores", fmt.Sprintf("toko-%d", time.Now().UnixNano()))
+			if serr != nil {
 				return fail(c, fiber.StatusInternalServerError, "UPDATE", "gagal menyimpan file foto")
 			}
-			// store relative path with forward slashes for consistency
-			urlFoto = filepath.ToSlash(dest)
+			fotoSizes = sizes
+			urlFoto = sizes["original"].URL
 		} else {
 			urlFoto = c.FormValue("photo")
 		}
@@ -182,20 +241,14 @@ func (h *Handler) Update(c *fiber.Ctx) error {
 		return fail(c, fiber.StatusBadRequest, "UPDATE", "photo harus URL file gambar (jpg|jpeg|png|gif|webp)")
 	}
 
-	if err := h.svc.UpdateStore(uint(id64), uid, namaToko, urlFoto); err != nil {
-		switch {
-		case errors.Is(err, tokosvc.ErrNotFound):
-			return fail(c, fiber.StatusNotFound, "UPDATE", "Toko tidak ditemukan")
-		case errors.Is(err, tokosvc.ErrForbidden):
-			return fail(c, fiber.StatusForbidden, "UPDATE", "Tidak memiliki izin mengelola toko ini")
-		default:
-			return fail(c, fiber.StatusInternalServerError, "UPDATE", err.Error())
-		}
+	if err := h.svc.UpdateStore(uint(id64), uid, namaToko, urlFoto, fotoSizes); err != nil {
+		return h.respondErr(c, "UPDATE", err)
 	}
 	return respondOK(c, "UPDATE", "Update Succeed")
 }
 
 // GET /toko/:id_toko (public)
+// Store page: info, stats and a page of products filtered like GET /product.
 func (h *Handler) GetByID(c *fiber.Ctx) error {
 	idStr := c.Params("id_toko")
 	id64, err := strconv.ParseUint(idStr, 10, 64)
@@ -213,29 +266,554 @@ func (h *Handler) GetByID(c *fiber.Ctx) error {
 			return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
 		}
 	}
+	if code, err := h.addProducts(c, data, uint(id64)); err != nil {
+		return fail(c, code, "GET", err.Error())
+	}
+	if err := h.addFollowing(c, data, uint(id64)); err != nil {
+		return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
 	return respondOK(c, "GET", data)
 }
 
-// GET /toko?limit=&page=&nama=
+// GET /toko/slug/:slug (public)
+// A former slug answers 301 to the store's current slug.
+func (h *Handler) GetBySlug(c *fiber.Ctx) error {
+	data, cur, err := h.svc.GetBySlug(c.Params("slug"))
+	if err != nil {
+		if errors.Is(err, tokosvc.ErrNotFound) {
+			return fail(c, fiber.StatusNotFound, "GET", "Toko tidak ditemukan")
+		}
+		return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	if cur != "" {
+		loc := "/toko/slug/" + url.PathEscape(cur)
+		if q := string(c.Request().URI().QueryString()); q != "" {
+			loc += "?" + q
+		}
+		return c.Redirect(loc, fiber.StatusMovedPermanently)
+	}
+	id, _ := data["id"].(uint)
+	if code, err := h.addProducts(c, data, id); err != nil {
+		return fail(c, code, "GET", err.Error())
+	}
+	if err := h.addFollowing(c, data, id); err != nil {
+		return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	return respondOK(c, "GET", data)
+}
+
+// GET /toko?limit=&page=&nama=&verified=
 func (h *Handler) List(c *fiber.Ctx) error {
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	name := c.Query("nama", "")
+	var verified *bool
+	if v := strings.TrimSpace(c.Query("verified", "")); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fail(c, fiber.StatusBadRequest, "GET", "verified harus true atau false")
+		}
+		verified = &b
+	}
+
+	data, err := h.svc.List(limit, page, name, verified)
+	if err != nil {
+		return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	return respondOK(c, "GET", data)
+}
+
+// PUT /toko/:id_toko/operasional
+// Replaces the opening hours and vacation mode of a store.
+func (h *Handler) UpdateOperasional(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "UPDATE", "Unauthorized")
+	}
+	id64, err := strconv.ParseUint(c.Params("id_toko"), 10, 64)
+	if err != nil || id64 == 0 {
+		return fail(c, fiber.StatusBadRequest, "UPDATE", "id_toko tidak valid")
+	}
+	var payload tokosvc.Operasional
+	if err := c.BodyParser(&payload); err != nil {
+		return fail(c, fiber.StatusBadRequest, "UPDATE", "Invalid JSON")
+	}
+	data, err := h.svc.UpdateOperasional(uint(id64), uid, payload)
+	if err != nil {
+		if errors.Is(err, tokosvc.ErrNotFound) || errors.Is(err, tokosvc.ErrForbidden) {
+			return h.respondErr(c, "UPDATE", err)
+		}
+		return fail(c, fiber.StatusBadRequest, "UPDATE", err.Error())
+	}
+	return respondOK(c, "UPDATE", data)
+}
+
+// PUT /toko/:id_toko/alamat
+// Sets the origin address the store ships from.
+func (h *Handler) UpdateAlamatAsal(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "UPDATE", "Unauthorized")
+	}
+	id64, err := strconv.ParseUint(c.Params("id_toko"), 10, 64)
+	if err != nil || id64 == 0 {
+		return fail(c, fiber.StatusBadRequest, "UPDATE", "id_toko tidak valid")
+	}
+	var payload tokosvc.AlamatAsalRequest
+	if err := c.BodyParser(&payload); err != nil {
+		return fail(c, fiber.StatusBadRequest, "UPDATE", "Invalid JSON")
+	}
+	ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+	defer cancel()
+	data, err := h.svc.UpdateAlamatAsal(ctx, uint(id64), uid, payload)
+	if err != nil {
+		switch {
+		case errors.Is(err, tokosvc.ErrNotFound), errors.Is(err, tokosvc.ErrForbidden):
+			return h.respondErr(c, "UPDATE", err)
+		case errors.Is(err, tokosvc.ErrWilayahUnavailable):
+			return fail(c, fiber.StatusBadGateway, "UPDATE", err.Error())
+		}
+		return fail(c, fiber.StatusBadRequest, "UPDATE", err.Error())
+	}
+	return respondOK(c, "UPDATE", data)
+}
+
+// FollowHandler returns the handler of POST /toko/:id_toko/follow (follow
+// true) and DELETE /toko/:id_toko/follow.
+func (h *Handler) FollowHandler(follow bool) fiber.Handler {
+	verb := "POST"
+	if !follow {
+		verb = "DELETE"
+	}
+	return func(c *fiber.Ctx) error {
+		uid, ok := jwtUserID(c)
+		if !ok {
+			return fail(c, fiber.StatusUnauthorized, verb, "Unauthorized")
+		}
+		id64, err := strconv.ParseUint(c.Params("id_toko"), 10, 64)
+		if err != nil || id64 == 0 {
+			return fail(c, fiber.StatusBadRequest, verb, "id_toko tidak valid")
+		}
+		var data map[string]interface{}
+		if follow {
+			data, err = h.svc.Follow(uint(id64), uid)
+		} else {
+			data, err = h.svc.Unfollow(uint(id64), uid)
+		}
+		if err != nil {
+			return h.respondErr(c, verb, err)
+		}
+		return respondOK(c, verb, data)
+	}
+}
+
+// GET /toko/my/stores
+func (h *Handler) MyStores(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
+	}
+	data, err := h.svc.MyStores(uid)
+	if err != nil {
+		return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	return respondOK(c, "GET", data)
+}
+
+// GET /toko/:id_toko/members
+func (h *Handler) ListMembers(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
+	}
+	id64, err := strconv.ParseUint(c.Params("id_toko"), 10, 64)
+	if err != nil || id64 == 0 {
+		return fail(c, fiber.StatusBadRequest, "GET", "id_toko tidak valid")
+	}
+	data, err := h.svc.ListMembers(uint(id64), uid)
+	if err != nil {
+		return h.respondErr(c, "GET", err)
+	}
+	return respondOK(c, "GET", data)
+}
+
+// PUT /toko/:id_toko/members/:user_id
+func (h *Handler) UpdateMember(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "UPDATE", "Unauthorized")
+	}
+	id64, err := strconv.ParseUint(c.Params("id_toko"), 10, 64)
+	if err != nil || id64 == 0 {
+		return fail(c, fiber.StatusBadRequest, "UPDATE", "id_toko tidak valid")
+	}
+	member64, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil || member64 == 0 {
+		return fail(c, fiber.StatusBadRequest, "UPDATE", "user_id tidak valid")
+	}
+	var payload struct {
+		Role string `json:"role"`
+	}
+	if err := c.BodyParser(&payload); err != nil {
+		return fail(c, fiber.StatusBadRequest, "UPDATE", "Invalid JSON")
+	}
+	if err := h.svc.UpdateMember(uint(id64), uid, uint(member64), strings.TrimSpace(payload.Role)); err != nil {
+		if errors.Is(err, tokosvc.ErrNotFound) {
+			return fail(c, fiber.StatusNotFound, "UPDATE", "Anggota tidak ditemukan")
+		}
+		if errors.Is(err, tokosvc.ErrForbidden) {
+			return h.respondErr(c, "UPDATE", err)
+		}
+		return fail(c, fiber.StatusBadRequest, "UPDATE", err.Error())
+	}
+	return respondOK(c, "UPDATE", "Update Succeed")
+}
+
+// DELETE /toko/:id_toko/members/:user_id
+// A member may remove themselves to leave the store.
+func (h *Handler) RemoveMember(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "DELETE", "Unauthorized")
+	}
+	id64, err := strconv.ParseUint(c.Params("id_toko"), 10, 64)
+	if err != nil || id64 == 0 {
+		return fail(c, fiber.StatusBadRequest, "DELETE", "id_toko tidak valid")
+	}
+	member64, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil || member64 == 0 {
+		return fail(c, fiber.StatusBadRequest, "DELETE", "user_id tidak valid")
+	}
+	if err := h.svc.RemoveMember(uint(id64), uid, uint(member64)); err != nil {
+		if errors.Is(err, tokosvc.ErrNotFound) {
+			return fail(c, fiber.StatusNotFound, "DELETE", "Anggota tidak ditemukan")
+		}
+		return h.respondErr(c, "DELETE", err)
+	}
+	return respondOK(c, "DELETE", "")
+}
+
+// GET /toko/:id_toko/invitations
+func (h *Handler) ListInvitations(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
+	}
+	id64, err := strconv.ParseUint(c.Params("id_toko"), 10, 64)
+	if err != nil || id64 == 0 {
+		return fail(c, fiber.StatusBadRequest, "GET", "id_toko tidak valid")
+	}
+	data, err := h.svc.ListInvitations(uint(id64), uid)
+	if err != nil {
+		return h.respondErr(c, "GET", err)
+	}
+	return respondOK(c, "GET", data)
+}
+
+// POST /toko/:id_toko/invitations
+func (h *Handler) Invite(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "POST", "Unauthorized")
+	}
+	id64, err := strconv.ParseUint(c.Params("id_toko"), 10, 64)
+	if err != nil || id64 == 0 {
+		return fail(c, fiber.StatusBadRequest, "POST", "id_toko tidak valid")
+	}
+	var payload struct {
+		Kontak string `json:"kontak"`
+		Role   string `json:"role"`
+	}
+	if err := c.BodyParser(&payload); err != nil {
+		return fail(c, fiber.StatusBadRequest, "POST", "Invalid JSON")
+	}
+	inv, err := h.svc.Invite(uint(id64), uid, payload.Kontak, strings.TrimSpace(payload.Role))
+	if err != nil {
+		switch {
+		case errors.Is(err, tokosvc.ErrNotFound), errors.Is(err, tokosvc.ErrForbidden),
+			errors.Is(err, tokosvc.ErrInvited), errors.Is(err, tokosvc.ErrMember):
+			return h.respondErr(c, "POST", err)
+		}
+		return fail(c, fiber.StatusBadRequest, "POST", err.Error())
+	}
+	return respondOK(c, "POST", inv)
+}
+
+// DELETE /toko/:id_toko/invitations/:id
+func (h *Handler) CancelInvitation(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "DELETE", "Unauthorized")
+	}
+	id64, err := strconv.ParseUint(c.Params("id_toko"), 10, 64)
+	if err != nil || id64 == 0 {
+		return fail(c, fiber.StatusBadRequest, "DELETE", "id_toko tidak valid")
+	}
+	inv64, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || inv64 == 0 {
+		return fail(c, fiber.StatusBadRequest, "DELETE", "id tidak valid")
+	}
+	if err := h.svc.CancelInvitation(uint(id64), uid, uint(inv64)); err != nil {
+		if errors.Is(err, tokosvc.ErrNotFound) {
+			return fail(c, fiber.StatusNotFound, "DELETE", "Undangan tidak ditemukan")
+		}
+		return h.respondErr(c, "DELETE", err)
+	}
+	return respondOK(c, "DELETE", "")
+}
 
-	data, err := h.svc.List(limit, page, name)
+// GET /toko/invitations: invitations sent to the user's email or phone
+func (h *Handler) MyInvitations(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
+	}
+	data, err := h.svc.MyInvitations(uid)
 	if err != nil {
 		return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
 	}
 	return respondOK(c, "GET", data)
 }
 
+// AnswerInvitation returns the handler of POST /toko/invitations/:id/accept
+// (accept true) and /decline.
+func (h *Handler) AnswerInvitation(accept bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		uid, ok := jwtUserID(c)
+		if !ok {
+			return fail(c, fiber.StatusUnauthorized, "POST", "Unauthorized")
+		}
+		id64, err := strconv.ParseUint(c.Params("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			return fail(c, fiber.StatusBadRequest, "POST", "id tidak valid")
+		}
+		inv, err := h.svc.AnswerInvitation(uid, uint(id64), accept)
+		if err != nil {
+			if errors.Is(err, tokosvc.ErrNotFound) {
+				return fail(c, fiber.StatusNotFound, "POST", "Undangan tidak ditemukan")
+			}
+			return h.respondErr(c, "POST", err)
+		}
+		return respondOK(c, "POST", inv)
+	}
+}
+
+// dokumenTypes maps the sniffed content types accepted as verification
+// documents to their file extension.
+var dokumenTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+// dokumenTTL is how long the document links of a verification request work.
+const dokumenTTL = 15 * time.Minute
+
+// saveDokumen stores uploaded verification documents as private files of a
+// toko. Files stored before a failure are removed again.
+func (h *Handler) saveDokumen(ctx context.Context, tokoID uint, files []*multipart.FileHeader) ([]tokosvc.Dokumen, error) {
+	var docs []tokosvc.Dokumen
+	for i, f := range files {
+		doc, err := h.saveOneDokumen(ctx, tokoID, i, f)
+		if err != nil {
+			h.removeDokumen(ctx, docs)
+			return nil, fmt.Errorf("%s: %w", f.Filename, err)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+func (h *Handler) saveOneDokumen(ctx context.Context, tokoID uint, i int, f *multipart.FileHeader) (tokosvc.Dokumen, error) {
+	if f.Size > media.MaxFileSize {
+		return tokosvc.Dokumen{}, media.ErrTooLarge
+	}
+	r, err := f.Open()
+	if err != nil {
+		return tokosvc.Dokumen{}, err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(io.LimitReader(r, media.MaxFileSize+1))
+	if err != nil {
+		return tokosvc.Dokumen{}, err
+	}
+	if len(data) > media.MaxFileSize {
+		return tokosvc.Dokumen{}, media.ErrTooLarge
+	}
+	ct := http.DetectContentType(data)
+	ext, ok := dokumenTypes[ct]
+	if !ok {
+		return tokosvc.Dokumen{}, errors.New("dokumen harus jpg, png atau pdf")
+	}
+	key := fmt.Sprintf("%stoko-verifikasi/%d/%d-%d%s", storage.PrivatePrefix, tokoID, time.Now().UnixNano(), i, ext)
+	if err := h.store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), ct); err != nil {
+		return tokosvc.Dokumen{}, errors.New("gagal menyimpan file dokumen")
+	}
+	return tokosvc.Dokumen{Nama: filepath.Base(f.Filename), ContentType: ct, Ukuran: int64(len(data)), Key: key}, nil
+}
+
+func (h *Handler) removeDokumen(ctx context.Context, docs []tokosvc.Dokumen) {
+	for _, d := range docs {
+		_ = h.store.Delete(ctx, d.Key)
+	}
+}
+
+// signDokumen fills in the short-lived links of a request's documents.
+func (h *Handler) signDokumen(v *tokosvc.Verification) error {
+	for i := range v.Dokumen {
+		u, err := h.store.SignedURL(v.Dokumen[i].Key, dokumenTTL)
+		if err != nil {
+			return err
+		}
+		v.Dokumen[i].URL = u
+	}
+	return nil
+}
+
+// POST /toko/:id_toko/verifikasi (multipart: dokumen files, catatan)
+// Sends the store's documents to the admin review queue.
+func (h *Handler) RequestVerification(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "POST", "Unauthorized")
+	}
+	id64, err := strconv.ParseUint(c.Params("id_toko"), 10, 64)
+	if err != nil || id64 == 0 {
+		return fail(c, fiber.StatusBadRequest, "POST", "id_toko tidak valid")
+	}
+	form, err := c.MultipartForm()
+	if err != nil {
+		return fail(c, fiber.StatusBadRequest, "POST", "dokumen wajib diunggah sebagai multipart/form-data")
+	}
+	files := form.File["dokumen"]
+	if len(files) == 0 {
+		return fail(c, fiber.StatusBadRequest, "POST", "dokumen wajib diunggah")
+	}
+	if len(files) > tokosvc.MaxDokumen {
+		return fail(c, fiber.StatusBadRequest, "POST", fmt.Sprintf("dokumen maksimal %d file", tokosvc.MaxDokumen))
+	}
+	// check access before anything is written to storage
+	if err := h.svc.CanRequestVerification(uint(id64), uid); err != nil {
+		return h.verificationErr(c, err)
+	}
+	docs, err := h.saveDokumen(c.UserContext(), uint(id64), files)
+	if err != nil {
+		return fail(c, fiber.StatusBadRequest, "POST", err.Error())
+	}
+	v, err := h.svc.RequestVerification(uint(id64), uid, c.FormValue("catatan"), docs)
+	if err != nil {
+		h.removeDokumen(c.UserContext(), docs)
+		return h.verificationErr(c, err)
+	}
+	if err := h.signDokumen(v); err != nil {
+		return fail(c, fiber.StatusInternalServerError, "POST", err.Error())
+	}
+	return respondOK(c, "POST", v)
+}
+
+// verificationErr maps an error of a verification request to a response.
+func (h *Handler) verificationErr(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, tokosvc.ErrNotFound), errors.Is(err, tokosvc.ErrForbidden):
+		return h.respondErr(c, "POST", err)
+	case errors.Is(err, tokosvc.ErrVerified), errors.Is(err, tokosvc.ErrVerificationPending):
+		return fail(c, fiber.StatusConflict, "POST", err.Error())
+	}
+	return fail(c, fiber.StatusBadRequest, "POST", err.Error())
+}
+
+// GET /toko/:id_toko/verifikasi
+// The store's badge and its latest verification request.
+func (h *Handler) VerificationStatus(c *fiber.Ctx) error {
+	uid, ok := jwtUserID(c)
+	if !ok {
+		return fail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
+	}
+	id64, err := strconv.ParseUint(c.Params("id_toko"), 10, 64)
+	if err != nil || id64 == 0 {
+		return fail(c, fiber.StatusBadRequest, "GET", "id_toko tidak valid")
+	}
+	st, err := h.svc.VerificationStatus(uint(id64), uid)
+	if err != nil {
+		return h.respondErr(c, "GET", err)
+	}
+	if st.Pengajuan != nil {
+		if err := h.signDokumen(st.Pengajuan); err != nil {
+			return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
+		}
+	}
+	return respondOK(c, "GET", st)
+}
+
+// GET /admin/toko/verifikasi?status=pending (admin)
+func (h *Handler) ListVerifications(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	res, err := h.svc.ListVerifications(c.Query("status", ""), limit, page)
+	if err != nil {
+		if errors.Is(err, tokosvc.ErrVerifyStatus) {
+			return fail(c, fiber.StatusBadRequest, "GET", err.Error())
+		}
+		return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
+	}
+	for i := range res.Items {
+		if err := h.signDokumen(&res.Items[i]); err != nil {
+			return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
+		}
+	}
+	return respondOK(c, "GET", fiber.Map{
+		"items":      res.Items,
+		"total":      res.Total,
+		"page":       res.Page,
+		"limit":      res.Limit,
+		"total_page": (res.Total + int64(res.Limit) - 1) / int64(res.Limit),
+	})
+}
+
+// ReviewVerification returns the handler of POST
+// /admin/toko/verifikasi/:id/approve (approve true) and /reject (admin).
+func (h *Handler) ReviewVerification(approve bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		uid, ok := jwtUserID(c)
+		if !ok {
+			return fail(c, fiber.StatusUnauthorized, "POST", "Unauthorized")
+		}
+		id64, err := strconv.ParseUint(c.Params("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			return fail(c, fiber.StatusBadRequest, "POST", "id tidak valid")
+		}
+		var body struct {
+			Alasan string `json:"alasan"`
+		}
+		if !approve {
+			if err := c.BodyParser(&body); err != nil {
+				return fail(c, fiber.StatusBadRequest, "POST", "Invalid JSON")
+			}
+		}
+		v, err := h.svc.ReviewVerification(uint(id64), uid, approve, body.Alasan)
+		if err != nil {
+			switch {
+			case errors.Is(err, tokosvc.ErrNotFound):
+				return fail(c, fiber.StatusNotFound, "POST", "Pengajuan verifikasi tidak ditemukan")
+			case errors.Is(err, tokosvc.ErrVerificationClosed):
+				return fail(c, fiber.StatusConflict, "POST", err.Error())
+			}
+			return fail(c, fiber.StatusBadRequest, "POST", err.Error())
+		}
+		if err := h.signDokumen(v); err != nil {
+			return fail(c, fiber.StatusInternalServerError, "POST", err.Error())
+		}
+		return respondOK(c, "POST", v)
+	}
+}
+
 // ---------- Middleware ----------
 
 // JWTMiddleware validates JWT (HS256) from header. Supports:
 // - token: <JWT>
 // - Authorization: Bearer <JWT>
 // and sets Locals("user_id") for downstream handlers.
-func JWTMiddleware(secret string) fiber.Handler {
+func JWTMiddleware(secret string, sessions authsvc.SessionChecker) fiber.Handler {
     return func(c *fiber.Ctx) error {
         // Try custom header 'token' first
         tok := strings.TrimSpace(c.Get("token"))
@@ -291,7 +869,17 @@ func JWTMiddleware(secret string) fiber.Handler {
             if uid == 0 {
                 return fail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
             }
+            // the token's session must not be logged out
+            sid := authsvc.SessionID(claims)
+            active, err := sessions.SessionActive(sid, uid)
+            if err != nil {
+                return fail(c, fiber.StatusInternalServerError, "GET", err.Error())
+            }
+            if !active {
+                return fail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
+            }
             c.Locals("user_id", uid)
+            c.Locals("session_id", sid)
             return c.Next()
         }
         return fail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
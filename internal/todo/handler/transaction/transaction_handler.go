@@ -1,16 +1,23 @@
 package transaction
 
 import (
+    "errors"
     "strconv"
+    "strings"
 
+    prodrepo "project-evermos/internal/todo/repository/product"
+    tokosvc "project-evermos/internal/todo/service/toko"
     svc "project-evermos/internal/todo/service/transaction"
 
     "github.com/gofiber/fiber/v2"
 )
 
-type Handler struct { svc *svc.Service }
+type Handler struct {
+    svc    *svc.Service
+    access *tokosvc.Access
+}
 
-func NewHandler(s *svc.Service) *Handler { return &Handler{svc: s} }
+func NewHandler(s *svc.Service, access *tokosvc.Access) *Handler { return &Handler{svc: s, access: access} }
 
 // Response helpers to keep consistent format
 func respondOK(c *fiber.Ctx, verb string, data interface{}) error {
@@ -61,6 +68,35 @@ func (h *Handler) List(c *fiber.Ctx) error {
     return respondOK(c, "GET", resp)
 }
 
+// GET /toko/my/orders
+// Orders of the store the user acts on (X-Toko-ID, else their own store);
+// needs a role allowed to handle orders.
+func (h *Handler) ListSeller(c *fiber.Ctx) error {
+    uid, ok := jwtUserID(c)
+    if !ok { return respondFail(c, fiber.StatusUnauthorized, "GET", []string{"Unauthorized"}) }
+
+    tokoID, _ := strconv.ParseUint(strings.TrimSpace(c.Get(tokosvc.HeaderToko)), 10, 64)
+    t, _, err := h.access.Store(uid, uint(tokoID), tokosvc.PermOrders)
+    switch {
+    case errors.Is(err, tokosvc.ErrNotFound):
+        return respondFail(c, fiber.StatusNotFound, "GET", []string{"Toko tidak ditemukan"})
+    case errors.Is(err, tokosvc.ErrForbidden):
+        return respondFail(c, fiber.StatusForbidden, "GET", []string{"Tidak memiliki izin melihat pesanan toko ini"})
+    case errors.Is(err, tokosvc.ErrTokoRequired):
+        return respondFail(c, fiber.StatusBadRequest, "GET", []string{err.Error()})
+    case err != nil:
+        return respondFail(c, fiber.StatusInternalServerError, "GET", []string{err.Error()})
+    }
+
+    limit, _ := strconv.Atoi(c.Query("limit", "10"))
+    page, _ := strconv.Atoi(c.Query("page", "1"))
+
+    resp, err := h.svc.ListForToko(t.ID, limit, page)
+    if err != nil { return respondFail(c, fiber.StatusBadRequest, "GET", []string{err.Error()}) }
+
+    return respondOK(c, "GET", resp)
+}
+
 // GET /trx/:id
 func (h *Handler) GetByID(c *fiber.Ctx) error {
     uid, ok := jwtUserID(c)
@@ -96,6 +132,12 @@ func (h *Handler) Create(c *fiber.Ctx) error {
 
     id, err := h.svc.Create(uid, req)
     if err != nil {
+        if errors.Is(err, svc.ErrTokoClosed) {
+            return respondFail(c, fiber.StatusConflict, "POST", []string{err.Error()})
+        }
+        if errors.Is(err, prodrepo.ErrInsufficientStock) {
+            return respondFail(c, fiber.StatusConflict, "POST", []string{"stok tidak cukup"})
+        }
         switch err.Error() {
         case "alamat not owned by user":
             return respondFail(c, fiber.StatusForbidden, "POST", []string{"Alamat bukan milik user"})
@@ -103,10 +145,47 @@ func (h *Handler) Create(c *fiber.Ctx) error {
             return respondFail(c, fiber.StatusBadRequest, "POST", []string{"Alamat tidak ditemukan"})
         case "product not found":
             return respondFail(c, fiber.StatusBadRequest, "POST", []string{"Product tidak valid"})
+        case "variant not found":
+            return respondFail(c, fiber.StatusBadRequest, "POST", []string{"Varian tidak valid"})
+        case svc.ErrSaleQuota.Error():
+            return respondFail(c, fiber.StatusConflict, "POST", []string{"Kuota flash sale habis, silakan checkout ulang dengan harga normal"})
+        case "variant_id required":
+            return respondFail(c, fiber.StatusBadRequest, "POST", []string{"variant_id wajib diisi untuk produk bervarian"})
         default:
             return respondFail(c, fiber.StatusBadRequest, "POST", []string{err.Error()})
         }
     }
 
     return respondOK(c, "POST", id)
-}
\ No newline at end of file
+}
+// POST /trx/:id/ulasan
+func (h *Handler) Rate(c *fiber.Ctx) error {
+    uid, ok := jwtUserID(c)
+    if !ok { return respondFail(c, fiber.StatusUnauthorized, "POST", []string{"Unauthorized"}) }
+
+    id64, _ := strconv.ParseUint(c.Params("id"), 10, 64)
+    if id64 == 0 { return respondFail(c, fiber.StatusBadRequest, "POST", []string{"invalid id"}) }
+
+    var req svc.UlasanRequest
+    if err := c.BodyParser(&req); err != nil {
+        return respondFail(c, fiber.StatusBadRequest, "POST", []string{"invalid payload"})
+    }
+
+    u, err := h.svc.Rate(uint(id64), uid, req)
+    if err != nil {
+        switch err.Error() {
+        case "forbidden":
+            return respondFail(c, fiber.StatusForbidden, "POST", []string{"Forbidden"})
+        case "not found":
+            return respondFail(c, fiber.StatusNotFound, "POST", []string{"No Data Trx"})
+        case "detail not found":
+            return respondFail(c, fiber.StatusBadRequest, "POST", []string{"id_detail_trx tidak valid"})
+        case svc.ErrRated.Error():
+            return respondFail(c, fiber.StatusConflict, "POST", []string{"Item ini sudah diberi ulasan"})
+        default:
+            return respondFail(c, fiber.StatusBadRequest, "POST", []string{err.Error()})
+        }
+    }
+
+    return respondOK(c, "POST", u)
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	authsvc "project-evermos/internal/todo/service/auth"
 	svc "project-evermos/internal/todo/service/users"
 
 	"github.com/gofiber/fiber/v2"
@@ -73,7 +74,7 @@ func jwtUserID(c *fiber.Ctx) (uint, bool) {
 
 // --- middleware ---
 // Per requirement: baca header 'token: <JWT>' (bukan Authorization: Bearer)
-func JWTMiddleware(secret string) fiber.Handler {
+func JWTMiddleware(secret string, sessions authsvc.SessionChecker) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Try custom header 'token' first
 		tok := strings.TrimSpace(c.Get("token"))
@@ -130,13 +131,35 @@ func JWTMiddleware(secret string) fiber.Handler {
 			if uid == 0 {
 				return respondFail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
 			}
+			// the token's session must not be logged out
+			sid := authsvc.SessionID(claims)
+			active, err := sessions.SessionActive(sid, uid)
+			if err != nil {
+				return respondFail(c, fiber.StatusInternalServerError, "GET", err.Error())
+			}
+			if !active {
+				return respondFail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
+			}
 			c.Locals("user_id", uid)
+			c.Locals("session_id", sid)
 			return c.Next()
 		}
 		return respondFail(c, fiber.StatusUnauthorized, "GET", "Unauthorized")
 	}
 }
 
+// OptionalJWTMiddleware is JWTMiddleware for public endpoints: a request
+// without token passes anonymously, but a token that is sent must be valid.
+func OptionalJWTMiddleware(secret string, sessions authsvc.SessionChecker) fiber.Handler {
+	auth := JWTMiddleware(secret, sessions)
+	return func(c *fiber.Ctx) error {
+		if strings.TrimSpace(c.Get("token")) == "" && strings.TrimSpace(c.Get("Authorization")) == "" {
+			return c.Next()
+		}
+		return auth(c)
+	}
+}
+
 // --- handlers ---
 // GET /user
 func (h *Handler) GetProfile(c *fiber.Ctx) error {
@@ -197,7 +220,8 @@ func (h *Handler) UpdateProfile(c *fiber.Ctx) error {
 			return respondFail(c, fiber.StatusBadRequest, "GET", "tanggal_Lahir format harus dd/MM/yyyy")
 		}
 	}
-	if err := h.s.UpdateProfile(uid, svc.UpdateProfileInput{
+	sid, _ := c.Locals("session_id").(uint)
+	if err := h.s.UpdateProfile(uid, sid, svc.UpdateProfileInput{
 		Nama:         body.Nama,
 		KataSandi:    body.KataSandi,
 		NoTelp:       body.NoTelp,
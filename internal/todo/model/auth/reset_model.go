@@ -0,0 +1,18 @@
+package auth
+
+import "time"
+
+// PasswordReset is a one-time code to reset a forgotten password (table
+// reset_kata_sandi). Only the bcrypt hash of the code is stored.
+type PasswordReset struct {
+	ID        uint       `gorm:"column:id;primaryKey"`
+	IDUser    uint       `gorm:"column:id_user;not null"`
+	KodeHash  string     `gorm:"column:kode_hash;size:255;not null"`
+	Kanal     string     `gorm:"column:kanal;size:10;not null"`
+	Percobaan int        `gorm:"column:percobaan"`
+	ExpiresAt time.Time  `gorm:"column:expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+}
+
+func (PasswordReset) TableName() string { return "reset_kata_sandi" }
@@ -0,0 +1,48 @@
+package auth
+
+import "time"
+
+// Reasons a login session was revoked.
+const (
+	RevokeLogout    = "logout"
+	RevokeLogoutAll = "logout_all"
+	RevokeReuse     = "reuse"
+	RevokeDevice    = "device"
+	RevokePassword  = "password_changed"
+)
+
+// Session is a login session (table sesi_login). Access tokens carry its id;
+// it ends when revoked or when its refresh token expires unused.
+type Session struct {
+	ID           uint       `gorm:"column:id;primaryKey"`
+	IDUser       uint       `gorm:"column:id_user;not null"`
+	UserAgent    string     `gorm:"column:user_agent;size:255"`
+	IP           string     `gorm:"column:ip;size:45"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	LastActiveAt time.Time  `gorm:"column:last_active_at"`
+	ExpiresAt    time.Time  `gorm:"column:expires_at"`
+	RevokedAt    *time.Time `gorm:"column:revoked_at"`
+	RevokeReason *string    `gorm:"column:revoke_reason;size:20"`
+}
+
+func (Session) TableName() string { return "sesi_login" }
+
+// Active reports whether the session can still be used at now.
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RefreshToken is one refresh token of a session (table refresh_token).
+// Only its SHA-256 hash is stored; UsedAt is set once it was rotated, so a
+// second use means the token leaked.
+type RefreshToken struct {
+	ID        uint       `gorm:"column:id;primaryKey"`
+	IDSesi    uint       `gorm:"column:id_sesi;not null"`
+	TokenHash string     `gorm:"column:token_hash;size:64;not null"`
+	ExpiresAt time.Time  `gorm:"column:expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	Session   *Session   `gorm:"foreignKey:IDSesi"`
+}
+
+func (RefreshToken) TableName() string { return "refresh_token" }
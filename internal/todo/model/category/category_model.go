@@ -10,4 +10,29 @@ type Category struct {
 	UpdatedAt    *time.Time `gorm:"column:updated_at"`
 }
 
-func (Category) TableName() string { return "category" }
\ No newline at end of file
+func (Category) TableName() string { return "category" }
+
+// Attribute types
+const (
+	AttrText    = "text"
+	AttrNumber  = "number"
+	AttrEnum    = "enum"
+	AttrBoolean = "boolean"
+)
+
+// Attribute is one field of a category's product attribute schema.
+// NilaiJSON holds the allowed values of an enum as a JSON array of strings.
+type Attribute struct {
+	ID         uint      `gorm:"column:id;primaryKey"`
+	IDCategory uint      `gorm:"column:id_category"`
+	Kode       string    `gorm:"column:kode"`
+	Nama       string    `gorm:"column:nama"`
+	Tipe       string    `gorm:"column:tipe"`
+	NilaiJSON  string    `gorm:"column:nilai_json"`
+	Wajib      bool      `gorm:"column:wajib"`
+	Urutan     int       `gorm:"column:urutan"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
+}
+
+func (Attribute) TableName() string { return "atribut_category" }
@@ -0,0 +1,95 @@
+package flashsale
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Campaign states, derived from the window and the current time
+const (
+	StatusUpcoming = "upcoming"
+	StatusActive   = "active"
+	StatusEnded    = "ended"
+)
+
+// FlashSale is a campaign that sells products at a sale price between Mulai
+// and Selesai.
+type FlashSale struct {
+	ID        uint      `gorm:"primaryKey;column:id"`
+	Nama      string    `gorm:"column:nama"`
+	Mulai     time.Time `gorm:"column:mulai"`
+	Selesai   time.Time `gorm:"column:selesai"`
+	CreatedBy *uint     `gorm:"column:created_by"` // admin user id
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+
+	Items []Item `gorm:"foreignKey:IDFlashSale;references:ID"`
+}
+
+func (FlashSale) TableName() string { return "flash_sale" }
+
+// Status returns the state of the campaign at now.
+func (f *FlashSale) Status(now time.Time) string {
+	switch {
+	case now.Before(f.Mulai):
+		return StatusUpcoming
+	case now.Before(f.Selesai):
+		return StatusActive
+	}
+	return StatusEnded
+}
+
+// DiscountPercent returns the discount of sale against normal, rounded
+// down to whole percent.
+func DiscountPercent(normal, sale int) int {
+	if normal <= 0 || sale >= normal {
+		return 0
+	}
+	return (normal - sale) * 100 / normal
+}
+
+// Item is the sale price of a product, or of one variant of a product with
+// variants, in a campaign. At most Kuota units are sold at HargaSale;
+// Terjual counts the units sold so far.
+type Item struct {
+	ID          uint      `gorm:"primaryKey;column:id"`
+	IDFlashSale uint      `gorm:"column:id_flash_sale"`
+	IDProduk    uint      `gorm:"column:id_produk"`
+	IDVarian    *uint     `gorm:"column:id_varian"`
+	HargaSale   int       `gorm:"column:harga_sale"`
+	Kuota       int       `gorm:"column:kuota"`
+	Terjual     int       `gorm:"column:terjual"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+
+	Produk *ProductRef `gorm:"foreignKey:IDProduk;references:ID"`
+	Varian *VariantRef `gorm:"foreignKey:IDVarian;references:ID"`
+}
+
+func (Item) TableName() string { return "flash_sale_item" }
+
+// ProductRef is the product of a campaign item.
+type ProductRef struct {
+	ID            uint           `gorm:"primaryKey;column:id"`
+	NamaProduk    string         `gorm:"column:nama_produk"`
+	Slug          string         `gorm:"column:slug"`
+	HargaKonsumen string         `gorm:"column:harga konsumen"`
+	IDToko        uint           `gorm:"column:id_toko"`
+	DeletedAt     gorm.DeletedAt `gorm:"column:deleted_at"`
+
+	Variants []VariantRef `gorm:"foreignKey:IDProduk;references:ID"`
+}
+
+func (ProductRef) TableName() string { return "produk" }
+
+// VariantRef is the variant of a campaign item.
+type VariantRef struct {
+	ID            uint   `gorm:"primaryKey;column:id"`
+	IDProduk      uint   `gorm:"column:id_produk"`
+	SKU           string `gorm:"column:sku"`
+	NamaVarian    string `gorm:"column:nama_varian"`
+	HargaKonsumen int    `gorm:"column:harga_konsumen"`
+}
+
+func (VariantRef) TableName() string { return "varian_produk" }
@@ -0,0 +1,38 @@
+package job
+
+import "time"
+
+// Job status values
+const (
+	StatusQueued  = "queued"
+	StatusRunning = "running"
+	StatusDone    = "done"
+	StatusFailed  = "failed"
+)
+
+// Job types
+const (
+	TypeProductImport = "product_import"
+)
+
+// Job is a background task (e.g. a product import) with its progress.
+// ReportKey is the storage key of the error report, if any rows failed.
+type Job struct {
+	ID           uint       `gorm:"primaryKey;column:id"`
+	Tipe         string     `gorm:"column:tipe"`
+	IDUser       uint       `gorm:"column:id_user"`
+	IDToko       *uint      `gorm:"column:id_toko"`
+	Status       string     `gorm:"column:status"`
+	DryRun       bool       `gorm:"column:dry_run"`
+	Total        int        `gorm:"column:total"`
+	Processed    int        `gorm:"column:processed"`
+	Succeeded    int        `gorm:"column:succeeded"`
+	Failed       int        `gorm:"column:failed"`
+	ErrorMessage string     `gorm:"column:error_message"`
+	ReportKey    string     `gorm:"column:report_key"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at"`
+	FinishedAt   *time.Time `gorm:"column:finished_at"`
+}
+
+func (Job) TableName() string { return "job" }
@@ -0,0 +1,28 @@
+package notification
+
+import "time"
+
+// Notification types
+const (
+	TypeLowStock            = "low_stock"
+	TypeProductApproved     = "product_approved"
+	TypeProductRejected     = "product_rejected"
+	TypeStoreInvitation     = "store_invitation"
+	TypeStoreVerified       = "store_verified"
+	TypeStoreVerifyRejected = "store_verification_rejected"
+)
+
+// Notification is a message for a user. DataJSON holds type-specific ids,
+// e.g. {"product_id":10} for a low stock alert.
+type Notification struct {
+	ID        uint       `gorm:"primaryKey;column:id"`
+	IDUser    uint       `gorm:"column:id_user"`
+	Tipe      string     `gorm:"column:tipe"`
+	Judul     string     `gorm:"column:judul"`
+	Pesan     string     `gorm:"column:pesan"`
+	DataJSON  string     `gorm:"column:data_json"`
+	DibacaAt  *time.Time `gorm:"column:dibaca_at"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+}
+
+func (Notification) TableName() string { return "notifikasi" }
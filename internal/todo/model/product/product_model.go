@@ -2,6 +2,10 @@ package product
 
 import (
     "time"
+
+    tokomodel "project-evermos/internal/todo/model/toko"
+
+    "gorm.io/gorm"
 )
 
 type Product struct {
@@ -11,29 +15,150 @@ type Product struct {
     HargaReseller string    `gorm:"column:harga reseller"`
     HargaKonsumen string    `gorm:"column:harga konsumen"`
     Stok          int       `gorm:"column:stok"`
+    // StokMinimum is the low stock threshold; an alert opens at or below it
+    StokMinimum   int       `gorm:"column:stok_minimum"`
     Deskripsi     string    `gorm:"column:deskripsi"`
+    // Berat is the shipping weight in grams; Panjang/Lebar/Tinggi are the
+    // packed size in cm. Products created before they were required are 0.
+    Berat         int       `gorm:"column:berat"`
+    Panjang       int       `gorm:"column:panjang"`
+    Lebar         int       `gorm:"column:lebar"`
+    Tinggi        int       `gorm:"column:tinggi"`
     CreatedAt     time.Time `gorm:"column:created_at"`
     UpdatedAt     time.Time `gorm:"column:updated_at"`
     IDToko        uint      `gorm:"column:id_toko"`
     IDCategory    uint      `gorm:"column:id_category"`
+    // Status is the moderation state; only published products are public
+    Status          string     `gorm:"column:status"`
+    AlasanPenolakan *string    `gorm:"column:alasan_penolakan"` // set when rejected
+    ReviewedBy      *uint      `gorm:"column:reviewed_by"`      // admin user id
+    ReviewedAt      *time.Time `gorm:"column:reviewed_at"`
+    // DeletedAt marks a product as trashed; purged after the retention period
+    DeletedAt     gorm.DeletedAt `gorm:"column:deleted_at;index"`
 
     Toko     *TokoRef     `gorm:"foreignKey:IDToko;references:ID"`
     Category *CategoryRef `gorm:"foreignKey:IDCategory;references:ID"`
     Photos   []Photo      `gorm:"foreignKey:IDProduk;references:ID"`
+    Options  []Option     `gorm:"foreignKey:IDProduk;references:ID"`
+    Variants []Variant    `gorm:"foreignKey:IDProduk;references:ID"`
+    Attributes []AttributeValue `gorm:"foreignKey:IDProduk;references:ID"`
+    // Sales are the flash sale prices running now, loaded with ActiveSales
+    Sales    []SaleItem   `gorm:"foreignKey:IDProduk;references:ID"`
 }
 
 func (Product) TableName() string { return "produk" }
 
+// Sale returns the running flash sale price of the product, or of variant v,
+// or nil when there is none or its quota is used up.
+func (p *Product) Sale(v *Variant) *SaleItem {
+    for i := range p.Sales {
+        s := &p.Sales[i]
+        if s.Terjual >= s.Kuota { continue }
+        if v == nil && s.IDVarian == nil { return s }
+        if v != nil && s.IDVarian != nil && *s.IDVarian == v.ID { return s }
+    }
+    return nil
+}
+
+// Dimensi is the shipping weight (grams) and packed size (cm) of one unit.
+type Dimensi struct {
+    Berat   int
+    Panjang int
+    Lebar   int
+    Tinggi  int
+}
+
+// Dimensi returns the shipping weight and size of one unit of the product,
+// or of variant v, whose own values override the product's.
+func (p *Product) Dimensi(v *Variant) Dimensi {
+    d := Dimensi{Berat: p.Berat, Panjang: p.Panjang, Lebar: p.Lebar, Tinggi: p.Tinggi}
+    if v == nil { return d }
+    if v.Berat != nil { d.Berat = *v.Berat }
+    if v.Panjang != nil { d.Panjang = *v.Panjang }
+    if v.Lebar != nil { d.Lebar = *v.Lebar }
+    if v.Tinggi != nil { d.Tinggi = *v.Tinggi }
+    return d
+}
+
+// Moderation states. A seller creates a draft or submits it for review; an
+// admin publishes or rejects it. Content edits of a published product send
+// it back to review.
+const (
+    StatusDraft         = "draft"
+    StatusPendingReview = "pending_review"
+    StatusPublished     = "published"
+    StatusRejected      = "rejected"
+)
+
 type Photo struct {
     ID        uint      `gorm:"primaryKey;column:id"`
     IDProduk  uint      `gorm:"column:id_produk"`
-    URL       string    `gorm:"column:url"`
+    IDVarian  *uint     `gorm:"column:id_varian"` // nil = foto produk umum
+    URL       string    `gorm:"column:url"`       // normalized image
+    SizesJSON string    `gorm:"column:sizes_json"` // media.Sizes of all renditions
+    Urutan    int       `gorm:"column:urutan"`
+    IsPrimary bool      `gorm:"column:is_primary"` // foto sampul produk
     UpdatedAt time.Time `gorm:"column:updated_at"`
     CreatedAt time.Time `gorm:"column:created_at"`
 }
 
 func (Photo) TableName() string { return "foto_produk" }
 
+// Option is a variant dimension of a product (e.g. Ukuran, Warna).
+// NilaiJSON holds the allowed values as a JSON array of strings.
+type Option struct {
+    ID        uint      `gorm:"primaryKey;column:id"`
+    IDProduk  uint      `gorm:"column:id_produk"`
+    NamaOpsi  string    `gorm:"column:nama_opsi"`
+    NilaiJSON string    `gorm:"column:nilai_json"`
+    Urutan    int       `gorm:"column:urutan"`
+    CreatedAt time.Time `gorm:"column:created_at"`
+    UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+func (Option) TableName() string { return "opsi_produk" }
+
+// Variant is a sellable SKU of a product with its own price, stock and photos.
+// OpsiJSON holds the chosen option values as a JSON object, e.g. {"Ukuran":"M"}.
+type Variant struct {
+    ID            uint      `gorm:"primaryKey;column:id"`
+    IDProduk      uint      `gorm:"column:id_produk"`
+    SKU           string    `gorm:"column:sku"`
+    NamaVarian    string    `gorm:"column:nama_varian"`
+    OpsiJSON      string    `gorm:"column:opsi_json"`
+    HargaReseller int       `gorm:"column:harga_reseller"`
+    HargaKonsumen int       `gorm:"column:harga_konsumen"`
+    Stok          int       `gorm:"column:stok"`
+    // shipping weight (grams) and size (cm); nil = the product's value
+    Berat         *int      `gorm:"column:berat"`
+    Panjang       *int      `gorm:"column:panjang"`
+    Lebar         *int      `gorm:"column:lebar"`
+    Tinggi        *int      `gorm:"column:tinggi"`
+    CreatedAt     time.Time `gorm:"column:created_at"`
+    UpdatedAt     time.Time `gorm:"column:updated_at"`
+
+    Photos []Photo `gorm:"foreignKey:IDVarian;references:ID"`
+}
+
+func (Variant) TableName() string { return "varian_produk" }
+
+// SaleItem is a flash sale price of a product (or variant) with the window
+// of its campaign (flash_sale), which is read through a join.
+type SaleItem struct {
+    ID           uint      `gorm:"primaryKey;column:id"`
+    IDFlashSale  uint      `gorm:"column:id_flash_sale"`
+    IDProduk     uint      `gorm:"column:id_produk"`
+    IDVarian     *uint     `gorm:"column:id_varian"`
+    HargaSale    int       `gorm:"column:harga_sale"`
+    Kuota        int       `gorm:"column:kuota"`
+    Terjual      int       `gorm:"column:terjual"`
+    NamaCampaign string    `gorm:"->;column:nama_campaign"`
+    Mulai        time.Time `gorm:"->;column:mulai"`
+    Selesai      time.Time `gorm:"->;column:selesai"`
+}
+
+func (SaleItem) TableName() string { return "flash_sale_item" }
+
 type CategoryRef struct {
     ID           uint      `gorm:"primaryKey;column:id"`
     NamaCategory string    `gorm:"column:nama_category"`
@@ -43,12 +168,123 @@ type CategoryRef struct {
 
 func (CategoryRef) TableName() string { return "category" }
 
+// AttributeRef is an attribute of a category's schema (atribut_category).
+// NilaiJSON holds the allowed values of an enum as a JSON array of strings.
+type AttributeRef struct {
+    ID         uint   `gorm:"primaryKey;column:id"`
+    IDCategory uint   `gorm:"column:id_category"`
+    Kode       string `gorm:"column:kode"`
+    Nama       string `gorm:"column:nama"`
+    Tipe       string `gorm:"column:tipe"`
+    NilaiJSON  string `gorm:"column:nilai_json"`
+    Wajib      bool   `gorm:"column:wajib"`
+    Urutan     int    `gorm:"column:urutan"`
+}
+
+func (AttributeRef) TableName() string { return "atribut_category" }
+
+// AttributeValue is a product's value of one attribute of its category.
+// Numbers and booleans are stored normalized, enums as the allowed value.
+type AttributeValue struct {
+    ID        uint      `gorm:"primaryKey;column:id"`
+    IDProduk  uint      `gorm:"column:id_produk"`
+    IDAtribut uint      `gorm:"column:id_atribut"`
+    Nilai     string    `gorm:"column:nilai"`
+    CreatedAt time.Time `gorm:"column:created_at"`
+
+    Atribut *AttributeRef `gorm:"foreignKey:IDAtribut;references:ID"`
+}
+
+func (AttributeValue) TableName() string { return "atribut_produk" }
+
 type TokoRef struct {
     ID        uint      `gorm:"primaryKey;column:id"`
     NamaToko  string    `gorm:"column:nama_toko"`
+    Slug      *string   `gorm:"column:slug"`
     UrlFoto   string    `gorm:"column:url_foto"`
+    tokomodel.Operasional `gorm:"embedded"`
+    tokomodel.AlamatAsal  `gorm:"embedded"`
+    Verified  bool      `gorm:"column:verified"`
     UpdatedAt time.Time `gorm:"column:updated_at"`
     CreatedAt time.Time `gorm:"column:created_at"`
 }
 
-func (TokoRef) TableName() string { return "toko" }
\ No newline at end of file
+func (TokoRef) TableName() string { return "toko" }
+
+// SlugLama is a former slug of a product, kept so old links can redirect.
+type SlugLama struct {
+    ID        uint      `gorm:"primaryKey;column:id"`
+    IDProduk  uint      `gorm:"column:id_produk"`
+    Slug      string    `gorm:"column:slug"`
+    CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (SlugLama) TableName() string { return "produk_slug_lama" }
+
+// Stock movement types
+const (
+    MovementInitial    = "initial"
+    MovementSale       = "sale"
+    MovementCancel     = "cancel"
+    MovementAdjustment = "adjustment"
+    MovementImport     = "import"
+)
+
+// StockMovement records one change of product (or variant) stock.
+// Jumlah is the signed delta; StokSebelum/StokSesudah are the variant's
+// stock when IDVarian is set, otherwise the product's.
+type StockMovement struct {
+    ID          uint      `gorm:"primaryKey;column:id"`
+    IDProduk    uint      `gorm:"column:id_produk"`
+    IDVarian    *uint     `gorm:"column:id_varian"`
+    SKU         string    `gorm:"column:sku"`         // kept after the variant is deleted
+    NamaVarian  string    `gorm:"column:nama_varian"`
+    Tipe        string    `gorm:"column:tipe"`
+    Jumlah      int       `gorm:"column:jumlah"`
+    StokSebelum int       `gorm:"column:stok_sebelum"`
+    StokSesudah int       `gorm:"column:stok_sesudah"`
+    Alasan      string    `gorm:"column:alasan"`
+    IDUser      *uint     `gorm:"column:id_user"` // actor
+    IDTrx       *uint     `gorm:"column:id_trx"`
+    CreatedAt   time.Time `gorm:"column:created_at"`
+}
+
+func (StockMovement) TableName() string { return "stock_movement" }
+
+// StockAlert is raised when a product (or variant) stock reaches its
+// product's StokMinimum, and resolved when the stock rises above it again.
+// At most one alert per product/variant is open at a time.
+type StockAlert struct {
+    ID         uint       `gorm:"primaryKey;column:id"`
+    IDToko     uint       `gorm:"column:id_toko"`
+    IDProduk   uint       `gorm:"column:id_produk"`
+    IDVarian   *uint      `gorm:"column:id_varian"`
+    Stok       int        `gorm:"column:stok"`  // stock when raised
+    Batas      int        `gorm:"column:batas"` // threshold when raised
+    CreatedAt  time.Time  `gorm:"column:created_at"`
+    ResolvedAt *time.Time `gorm:"column:resolved_at"`
+}
+
+func (StockAlert) TableName() string { return "stock_alert" }
+
+// Price history sources
+const (
+    PriceInitial  = "initial"
+    PriceUpdate   = "update"
+    PriceOrder    = "order"    // price charged at checkout
+    PriceSnapshot = "snapshot" // price when price history was introduced
+)
+
+// PriceHistory records a product (or variant) price from CreatedAt on.
+type PriceHistory struct {
+    ID            uint      `gorm:"primaryKey;column:id"`
+    IDProduk      uint      `gorm:"column:id_produk"`
+    IDVarian      *uint     `gorm:"column:id_varian"`
+    HargaReseller int       `gorm:"column:harga_reseller"`
+    HargaKonsumen int       `gorm:"column:harga_konsumen"`
+    Sumber        string    `gorm:"column:sumber"`
+    IDUser        *uint     `gorm:"column:id_user"` // actor
+    CreatedAt     time.Time `gorm:"column:created_at"`
+}
+
+func (PriceHistory) TableName() string { return "price_history" }
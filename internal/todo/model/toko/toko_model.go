@@ -7,9 +7,162 @@ type Toko struct {
 	ID        uint      `gorm:"primaryKey;column:id"`
 	IDUser    uint      `gorm:"column:id_user"`
 	NamaToko  string    `gorm:"column:nama_toko"`
+	Slug      string    `gorm:"column:slug"`
 	UrlFoto   string    `gorm:"column:url_foto"`
+	// FotoSizesJSON holds the media.Sizes of an uploaded photo
+	FotoSizesJSON string `gorm:"column:foto_sizes_json"`
+	Operasional   `gorm:"embedded"`
+	AlamatAsal    `gorm:"embedded"`
+	// Verified is the official store badge, granted by an admin on a
+	// verification request
+	Verified   bool       `gorm:"column:verified"`
+	VerifiedAt *time.Time `gorm:"column:verified_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 }
 
-func (Toko) TableName() string { return "toko" }
\ No newline at end of file
+func (Toko) TableName() string { return "toko" }
+
+// Operasional are the opening hours and vacation mode of a toko. Without
+// hours the toko is always open, unless on vacation.
+type Operasional struct {
+	// JamOperasionalJSON holds the weekly hours, see service/toko.Jam
+	JamOperasionalJSON string     `gorm:"column:jam_operasional"`
+	ZonaWaktu          string     `gorm:"column:zona_waktu"` // WIB, WITA or WIT
+	Libur              bool       `gorm:"column:libur"`
+	LiburSampai        *time.Time `gorm:"column:libur_sampai"` // vacation ends by itself at this time
+	PesanLibur         string     `gorm:"column:pesan_libur"`
+}
+
+// AlamatAsal is the pickup address a toko ships from. The province and city
+// names are copied from the address service when the address is set.
+type AlamatAsal struct {
+	IDProvinsi   string `gorm:"column:asal_id_provinsi"`
+	NamaProvinsi string `gorm:"column:asal_nama_provinsi"`
+	IDKota       string `gorm:"column:asal_id_kota"`
+	NamaKota     string `gorm:"column:asal_nama_kota"`
+	DetailAlamat string `gorm:"column:asal_detail_alamat"`
+	KodePos      string `gorm:"column:asal_kode_pos"`
+}
+
+// Set reports whether the toko has an origin address.
+func (a AlamatAsal) Set() bool { return a.IDKota != "" }
+
+// SlugLama is a former slug of a toko, kept so old links can redirect.
+type SlugLama struct {
+	ID        uint      `gorm:"primaryKey;column:id"`
+	IDToko    uint      `gorm:"column:id_toko"`
+	Slug      string    `gorm:"column:slug"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (SlugLama) TableName() string { return "toko_slug_lama" }
+
+// Stats are the public figures shown on a store page.
+type Stats struct {
+	JumlahProduk int64    // published products
+	TotalTerjual int64    // units sold over all orders
+	Rating       *float64 // average buyer rating, nil without ratings
+	JumlahUlasan int64
+	Pengikut     int64 // users following the store
+}
+
+// Follower is a user following a store; new products of followed stores
+// show up in their feed.
+type Follower struct {
+	ID        uint      `gorm:"primaryKey;column:id"`
+	IDToko    uint      `gorm:"column:id_toko"`
+	IDUser    uint      `gorm:"column:id_user"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (Follower) TableName() string { return "toko_follower" }
+
+// Roles of a store member. The owner is the user the store belongs to
+// (Toko.IDUser); the other roles are granted by invitation.
+const (
+	RoleOwner        = "owner"
+	RoleManager      = "manager"
+	RoleStaffOrders  = "staff-orders"
+	RoleStaffCatalog = "staff-catalog"
+)
+
+// Member is a user who helps run a store with a role other than owner.
+type Member struct {
+	ID        uint      `gorm:"primaryKey;column:id"`
+	IDToko    uint      `gorm:"column:id_toko"`
+	IDUser    uint      `gorm:"column:id_user"`
+	Role      string    `gorm:"column:role"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+func (Member) TableName() string { return "toko_member" }
+
+// MemberRow is a member (or the owner) with their user data.
+type MemberRow struct {
+	IDUser    uint
+	Nama      string
+	Email     string
+	NoTelp    string
+	Role      string
+	CreatedAt time.Time
+}
+
+// Invitation statuses
+const (
+	InvitePending   = "pending"
+	InviteAccepted  = "accepted"
+	InviteDeclined  = "declined"
+	InviteCancelled = "cancelled"
+)
+
+// Invitation asks the user with email or phone Kontak to join a store.
+// IDUser is set to the user who answered it.
+type Invitation struct {
+	ID        uint      `gorm:"primaryKey;column:id"`
+	IDToko    uint      `gorm:"column:id_toko"`
+	Kontak    string    `gorm:"column:kontak"`
+	Role      string    `gorm:"column:role"`
+	Status    string    `gorm:"column:status"`
+	InvitedBy *uint     `gorm:"column:invited_by"`
+	IDUser    *uint     `gorm:"column:id_user"`
+	ExpiresAt time.Time `gorm:"column:expires_at"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+	Toko      *Toko     `gorm:"foreignKey:IDToko"`
+}
+
+func (Invitation) TableName() string { return "toko_undangan" }
+
+// Membership is a store a user belongs to, with their role there.
+type Membership struct {
+	Toko Toko
+	Role string
+}
+
+// Verification request statuses
+const (
+	VerifyPending  = "pending"
+	VerifyApproved = "approved"
+	VerifyRejected = "rejected"
+)
+
+// Verification is a request of a store for the verified badge, reviewed by
+// an admin. DokumenJSON lists the uploaded documents, see service/toko.Dokumen.
+type Verification struct {
+	ID          uint       `gorm:"primaryKey;column:id"`
+	IDToko      uint       `gorm:"column:id_toko"`
+	IDUser      uint       `gorm:"column:id_user"` // who sent the request
+	Status      string     `gorm:"column:status"`
+	DokumenJSON string     `gorm:"column:dokumen_json"`
+	Catatan     string     `gorm:"column:catatan"`
+	Alasan      string     `gorm:"column:alasan"` // rejection reason
+	ReviewedBy  *uint      `gorm:"column:reviewed_by"`
+	ReviewedAt  *time.Time `gorm:"column:reviewed_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at"`
+	Toko        *Toko      `gorm:"foreignKey:IDToko"`
+}
+
+func (Verification) TableName() string { return "toko_verifikasi" }
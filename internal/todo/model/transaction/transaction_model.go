@@ -43,8 +43,34 @@ type LogProduk struct {
     IDToko        uint       `gorm:"column:id_toko"`
     IDCategory    uint       `gorm:"column:id_category"`
     PhotosJSON    string     `gorm:"column:photos_json"` // JSON array of photo URLs
+    IDVarian      *uint      `gorm:"column:id_varian"`   // nil when product has no variants
+    SKU           string     `gorm:"column:sku"`
+    NamaVarian    string     `gorm:"column:nama_varian"`
+    OpsiJSON      string     `gorm:"column:opsi_json"` // JSON object of chosen option values
+    Berat         int        `gorm:"column:berat"`     // grams per unit, variant value when set
+    Panjang       int        `gorm:"column:panjang"`   // cm
+    Lebar         int        `gorm:"column:lebar"`
+    Tinggi        int        `gorm:"column:tinggi"`
+    IDFlashSale   *uint      `gorm:"column:id_flash_sale"` // campaign whose price was paid
+    HargaNormal   *int       `gorm:"column:harga_normal"`  // consumer price without the flash sale
     UpdatedAt     *time.Time `gorm:"column:updated_at"`
     CreatedAt     *time.Time `gorm:"column:created_at"`
 }
 
-func (LogProduk) TableName() string { return "log_produk" }
\ No newline at end of file
+func (LogProduk) TableName() string { return "log_produk" }
+
+// Ulasan is a buyer's rating of one order item. IDProduk and IDToko are
+// copied from the order snapshot.
+type Ulasan struct {
+    ID          uint      `gorm:"primaryKey;column:id"`
+    IDDetailTrx uint      `gorm:"column:id_detail_trx"`
+    IDUser      uint      `gorm:"column:id_user"`
+    IDProduk    uint      `gorm:"column:id_produk"`
+    IDToko      uint      `gorm:"column:id_toko"`
+    Rating      int       `gorm:"column:rating"` // 1-5
+    Komentar    *string   `gorm:"column:komentar"`
+    CreatedAt   time.Time `gorm:"column:created_at"`
+    UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+func (Ulasan) TableName() string { return "ulasan" }
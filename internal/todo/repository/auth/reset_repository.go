@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	authmodel "project-evermos/internal/todo/model/auth"
+)
+
+// ErrResetUsed is returned when a reset code was used meanwhile.
+var ErrResetUsed = errors.New("reset code used")
+
+// LatestReset returns the newest reset code of a user, or nil.
+func (r *Repository) LatestReset(userID uint) (*authmodel.PasswordReset, error) {
+	var p authmodel.PasswordReset
+	if err := r.db.Where("id_user = ?", userID).Order("id DESC").First(&p).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// CreateReset saves a reset code; earlier unused codes of the user stop
+// working.
+func (r *Repository) CreateReset(p *authmodel.PasswordReset) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&authmodel.PasswordReset{}).Where("id_user = ? AND used_at IS NULL", p.IDUser).
+			Update("used_at", p.CreatedAt).Error; err != nil {
+			return err
+		}
+		return tx.Create(p).Error
+	})
+}
+
+// CountResetAttempt records a try of a reset code; false when the code
+// already had max tries.
+func (r *Repository) CountResetAttempt(id uint, max int) (bool, error) {
+	res := r.db.Model(&authmodel.PasswordReset{}).Where("id = ? AND percobaan < ?", id, max).
+		Update("percobaan", gorm.Expr("percobaan + 1"))
+	return res.RowsAffected > 0, res.Error
+}
+
+// ResetPassword spends a reset code, sets the user's new password hash and
+// ends all of the user's sessions. Returns ErrResetUsed when the code was
+// used meanwhile.
+func (r *Repository) ResetPassword(p *authmodel.PasswordReset, passwordHash string) error {
+	now := time.Now()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&authmodel.PasswordReset{}).Where("id = ? AND used_at IS NULL", p.ID).Update("used_at", now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrResetUsed
+		}
+		if err := tx.Model(&authmodel.User{}).Where("id = ?", p.IDUser).
+			Updates(map[string]interface{}{"kata_sandi": passwordHash, "updated_at": now}).Error; err != nil {
+			return err
+		}
+		_, err := RevokeSessions(tx, p.IDUser, 0, authmodel.RevokePassword)
+		return err
+	})
+}
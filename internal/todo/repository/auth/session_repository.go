@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	authmodel "project-evermos/internal/todo/model/auth"
+)
+
+var (
+	// ErrTokenUsed is returned when rotating a refresh token that was
+	// rotated meanwhile.
+	ErrTokenUsed = errors.New("refresh token used")
+	// ErrSessionRevoked is returned when rotating a token of a revoked session.
+	ErrSessionRevoked = errors.New("session revoked")
+)
+
+// FindByID returns a user, or nil.
+func (r *Repository) FindByID(id uint) (*authmodel.User, error) {
+	var u authmodel.User
+	if err := r.db.Where("id = ?", id).First(&u).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &u, nil
+}
+
+// CreateSession saves a new session with its first refresh token.
+func (r *Repository) CreateSession(s *authmodel.Session, t *authmodel.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(s).Error; err != nil {
+			return err
+		}
+		t.IDSesi = s.ID
+		return tx.Create(t).Error
+	})
+}
+
+// FindRefreshToken returns a refresh token by hash with its session, or nil.
+func (r *Repository) FindRefreshToken(hash string) (*authmodel.RefreshToken, error) {
+	var t authmodel.RefreshToken
+	if err := r.db.Preload("Session").Where("token_hash = ?", hash).First(&t).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// RotateRefreshToken marks old as used, saves next in its place and extends
+// the session to next's expiry. Returns ErrTokenUsed when old was rotated
+// by a concurrent request and ErrSessionRevoked when the session ended.
+func (r *Repository) RotateRefreshToken(old, next *authmodel.RefreshToken, userAgent, ip string) error {
+	now := next.CreatedAt
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&authmodel.RefreshToken{}).Where("id = ? AND used_at IS NULL", old.ID).Update("used_at", now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrTokenUsed
+		}
+		res = tx.Model(&authmodel.Session{}).Where("id = ? AND revoked_at IS NULL", old.IDSesi).
+			Updates(map[string]interface{}{"last_active_at": now, "expires_at": next.ExpiresAt, "user_agent": userAgent, "ip": ip})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrSessionRevoked
+		}
+		next.IDSesi = old.IDSesi
+		return tx.Create(next).Error
+	})
+}
+
+// RevokeSession ends a session; revoking an ended session is a no-op.
+func (r *Repository) RevokeSession(id uint, reason string) error {
+	return r.db.Model(&authmodel.Session{}).Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoke_reason": reason}).Error
+}
+
+// RevokeUserSessions ends every active session of a user and returns how
+// many were ended.
+func (r *Repository) RevokeUserSessions(userID uint, reason string) (int64, error) {
+	return RevokeSessions(r.db, userID, 0, reason)
+}
+
+// RevokeSessions ends the active sessions of a user except exceptID (0 for
+// none) with tx, so it can be part of the change that caused it, and
+// returns how many were ended.
+func RevokeSessions(tx *gorm.DB, userID, exceptID uint, reason string) (int64, error) {
+	now := time.Now()
+	res := tx.Model(&authmodel.Session{}).Where("id_user = ? AND id <> ? AND revoked_at IS NULL AND expires_at > ?", userID, exceptID, now).
+		Updates(map[string]interface{}{"revoked_at": now, "revoke_reason": reason})
+	return res.RowsAffected, res.Error
+}
+
+// ListSessions returns the active sessions of a user, most recently used
+// first.
+func (r *Repository) ListSessions(userID uint) ([]authmodel.Session, error) {
+	var rows []authmodel.Session
+	err := r.db.Where("id_user = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_active_at DESC, id DESC").Find(&rows).Error
+	return rows, err
+}
+
+// RevokeUserSession ends one active session of a user; false when the user
+// has no such session.
+func (r *Repository) RevokeUserSession(userID, id uint, reason string) (bool, error) {
+	now := time.Now()
+	res := r.db.Model(&authmodel.Session{}).Where("id = ? AND id_user = ? AND revoked_at IS NULL AND expires_at > ?", id, userID, now).
+		Updates(map[string]interface{}{"revoked_at": now, "revoke_reason": reason})
+	return res.RowsAffected > 0, res.Error
+}
+
+// TouchSession records activity on a session, at most once per interval.
+func (r *Repository) TouchSession(id uint, now time.Time, interval time.Duration) error {
+	return r.db.Model(&authmodel.Session{}).Where("id = ? AND last_active_at < ?", id, now.Add(-interval)).
+		Update("last_active_at", now).Error
+}
+
+// SessionActive reports whether a session of the user is neither revoked
+// nor expired.
+func (r *Repository) SessionActive(id, userID uint) (bool, error) {
+	var cnt int64
+	err := r.db.Model(&authmodel.Session{}).
+		Where("id = ? AND id_user = ? AND revoked_at IS NULL AND expires_at > ?", id, userID, time.Now()).
+		Count(&cnt).Error
+	return cnt > 0, err
+}
@@ -1,8 +1,10 @@
 package category
 
 import (
+	"encoding/json"
 	"errors"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 	model "project-evermos/internal/todo/model/category"
@@ -73,4 +75,72 @@ func (r *Repository) Delete(id uint) error {
 		return err
 	}
 	return r.db.Delete(&c).Error
-}
\ No newline at end of file
+}
+
+// ListAttributes returns the attribute schema of a category in display order.
+func (r *Repository) ListAttributes(categoryID uint) ([]model.Attribute, error) {
+	var rows []model.Attribute
+	if err := r.db.Where("id_category = ?", categoryID).Order("urutan ASC, id ASC").Find(&rows).Error; err != nil { return nil, err }
+	return rows, nil
+}
+
+// ReplaceAttributes sets the attribute schema of a category, matched by kode:
+// existing attributes are updated in place (keeping product values), new
+// ones are added and missing ones deleted together with their product values.
+func (r *Repository) ReplaceAttributes(categoryID uint, attrs []model.Attribute) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var existing []model.Attribute
+		if err := tx.Where("id_category = ?", categoryID).Find(&existing).Error; err != nil { return err }
+		byKode := make(map[string]model.Attribute, len(existing))
+		for _, a := range existing { byKode[a.Kode] = a }
+		now := time.Now()
+		keep := map[uint]bool{}
+		for i := range attrs {
+			a := &attrs[i]
+			a.IDCategory = categoryID
+			a.Urutan = i
+			a.UpdatedAt = now
+			if old, ok := byKode[a.Kode]; ok {
+				a.ID = old.ID
+				a.CreatedAt = old.CreatedAt
+				if err := tx.Model(&model.Attribute{}).Where("id = ?", old.ID).Updates(map[string]interface{}{
+					"nama":       a.Nama,
+					"tipe":       a.Tipe,
+					"nilai_json": a.NilaiJSON,
+					"wajib":      a.Wajib,
+					"urutan":     a.Urutan,
+					"updated_at": now,
+				}).Error; err != nil { return err }
+				// values that are no longer valid for a changed type/enum are dropped
+				if old.Tipe != a.Tipe || old.NilaiJSON != a.NilaiJSON {
+					if err := dropInvalidValues(tx, *a); err != nil { return err }
+				}
+			} else {
+				a.CreatedAt = now
+				if err := tx.Create(a).Error; err != nil { return err }
+			}
+			keep[a.ID] = true
+		}
+		for _, old := range existing {
+			if keep[old.ID] { continue }
+			if err := tx.Delete(&model.Attribute{}, old.ID).Error; err != nil { return err }
+		}
+		return nil
+	})
+}
+
+// dropInvalidValues deletes product values of a that its type or allowed
+// values no longer accept.
+func dropInvalidValues(tx *gorm.DB, a model.Attribute) error {
+	switch a.Tipe {
+	case model.AttrEnum:
+		var allowed []string
+		_ = json.Unmarshal([]byte(a.NilaiJSON), &allowed)
+		return tx.Exec("DELETE FROM atribut_produk WHERE id_atribut = ? AND nilai NOT IN ?", a.ID, append(allowed, "")).Error
+	case model.AttrNumber:
+		return tx.Exec("DELETE FROM atribut_produk WHERE id_atribut = ? AND nilai NOT REGEXP ?", a.ID, `^-?[0-9]+([.][0-9]+)?$`).Error
+	case model.AttrBoolean:
+		return tx.Exec("DELETE FROM atribut_produk WHERE id_atribut = ? AND nilai NOT IN ?", a.ID, []string{"true", "false"}).Error
+	}
+	return nil
+}
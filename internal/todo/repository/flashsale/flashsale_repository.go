@@ -0,0 +1,157 @@
+package flashsale
+
+import (
+	"errors"
+	"time"
+
+	model "project-evermos/internal/todo/model/flashsale"
+
+	"gorm.io/gorm"
+)
+
+// ErrStarted is returned when changing a campaign that has already started.
+var ErrStarted = errors.New("flash sale already started")
+
+// Repository handles data access for flash sale campaigns.
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository { return &Repository{db: db} }
+
+// withItems preloads the items of a campaign with their product and variant.
+func withItems(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Produk", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Items.Varian")
+}
+
+// Create saves a campaign with its items.
+func (r *Repository) Create(f *model.FlashSale) error {
+	return r.db.Create(f).Error
+}
+
+// Update rewrites a campaign that has not started yet and replaces its
+// items. Returns ErrStarted when it started meanwhile.
+func (r *Repository) Update(f *model.FlashSale) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.FlashSale{}).Where("id = ? AND mulai > ?", f.ID, time.Now()).Updates(map[string]interface{}{
+			"nama":       f.Nama,
+			"mulai":      f.Mulai,
+			"selesai":    f.Selesai,
+			"updated_at": f.UpdatedAt,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrStarted
+		}
+		if err := tx.Where("id_flash_sale = ?", f.ID).Delete(&model.Item{}).Error; err != nil {
+			return err
+		}
+		for i := range f.Items {
+			f.Items[i].IDFlashSale = f.ID
+		}
+		if len(f.Items) == 0 {
+			return nil
+		}
+		return tx.Create(&f.Items).Error
+	})
+}
+
+// GetByID returns a campaign with its items, or nil.
+func (r *Repository) GetByID(id uint) (*model.FlashSale, error) {
+	var f model.FlashSale
+	if err := r.db.Scopes(withItems).Where("id = ?", id).First(&f).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+// List returns campaigns in a state ("" = all) at now; running and upcoming
+// campaigns soonest first, ended ones most recent first.
+func (r *Repository) List(status string, now time.Time, limit, page int) ([]model.FlashSale, int64, error) {
+	var items []model.FlashSale
+	var total int64
+	q := r.db.Model(&model.FlashSale{})
+	order := "mulai DESC, id DESC"
+	switch status {
+	case model.StatusUpcoming:
+		q = q.Where("mulai > ?", now)
+		order = "mulai ASC, id ASC"
+	case model.StatusActive:
+		q = q.Where("mulai <= ? AND selesai > ?", now, now)
+		order = "selesai ASC, id ASC"
+	case model.StatusEnded:
+		q = q.Where("selesai <= ?", now)
+		order = "selesai DESC, id DESC"
+	}
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+	if err := q.Scopes(withItems).Order(order).Limit(limit).Offset(offset).Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// Delete removes a campaign that has not started yet with its items.
+// Returns ErrStarted when it started meanwhile.
+func (r *Repository) Delete(id uint) error {
+	res := r.db.Where("id = ? AND mulai > ?", id, time.Now()).Delete(&model.FlashSale{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrStarted
+	}
+	return nil
+}
+
+// End stops a running campaign now. Returns false when it is not running.
+func (r *Repository) End(id uint) (bool, error) {
+	now := time.Now()
+	res := r.db.Model(&model.FlashSale{}).Where("id = ? AND mulai <= ? AND selesai > ?", id, now, now).
+		Updates(map[string]interface{}{"selesai": now, "updated_at": now})
+	return res.RowsAffected == 1, res.Error
+}
+
+// GetProduct returns a product with its variants for a campaign item, or
+// nil when it does not exist or is trashed.
+func (r *Repository) GetProduct(id uint) (*model.ProductRef, error) {
+	var p model.ProductRef
+	if err := r.db.Preload("Variants").Where("id = ?", id).First(&p).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Overlaps reports whether another campaign (not excludeID) with a window
+// overlapping [mulai, selesai) already sells the product or variant.
+func (r *Repository) Overlaps(productID uint, variantID *uint, mulai, selesai time.Time, excludeID uint) (bool, error) {
+	q := r.db.Model(&model.Item{}).
+		Joins("JOIN flash_sale fs ON fs.id = flash_sale_item.id_flash_sale").
+		Where("flash_sale_item.id_produk = ? AND fs.id <> ? AND fs.mulai < ? AND fs.selesai > ?", productID, excludeID, selesai, mulai)
+	if variantID != nil {
+		q = q.Where("flash_sale_item.id_varian = ?", *variantID)
+	} else {
+		q = q.Where("flash_sale_item.id_varian IS NULL")
+	}
+	var cnt int64
+	if err := q.Count(&cnt).Error; err != nil {
+		return false, err
+	}
+	return cnt > 0, nil
+}
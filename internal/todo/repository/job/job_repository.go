@@ -0,0 +1,63 @@
+package job
+
+import (
+	"errors"
+	"time"
+
+	model "project-evermos/internal/todo/model/job"
+
+	"gorm.io/gorm"
+)
+
+// Repository handles data access for background jobs.
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository { return &Repository{db: db} }
+
+func (r *Repository) Create(j *model.Job) error {
+	return r.db.Create(j).Error
+}
+
+// GetByID returns nil, nil when the job does not exist.
+func (r *Repository) GetByID(id uint) (*model.Job, error) {
+	var j model.Job
+	if err := r.db.Where("id = ?", id).First(&j).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &j, nil
+}
+
+// SetStatus moves a job to a new status.
+func (r *Repository) SetStatus(id uint, status string) error {
+	return r.db.Model(&model.Job{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"status": status, "updated_at": time.Now()}).Error
+}
+
+// UpdateProgress stores the row counters of a running job.
+func (r *Repository) UpdateProgress(id uint, processed, succeeded, failed int) error {
+	return r.db.Model(&model.Job{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"processed":  processed,
+		"succeeded":  succeeded,
+		"failed":     failed,
+		"updated_at": time.Now(),
+	}).Error
+}
+
+// Finish stores the final state of a job.
+func (r *Repository) Finish(j *model.Job) error {
+	return r.db.Model(&model.Job{}).Where("id = ?", j.ID).Updates(map[string]interface{}{
+		"status":        j.Status,
+		"processed":     j.Processed,
+		"succeeded":     j.Succeeded,
+		"failed":        j.Failed,
+		"error_message": j.ErrorMessage,
+		"report_key":    j.ReportKey,
+		"updated_at":    j.UpdatedAt,
+		"finished_at":   j.FinishedAt,
+	}).Error
+}
@@ -0,0 +1,70 @@
+package notification
+
+import (
+	"time"
+
+	model "project-evermos/internal/todo/model/notification"
+
+	"gorm.io/gorm"
+)
+
+// Repository handles data access for user notifications.
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository { return &Repository{db: db} }
+
+// Create saves n with tx, so a notification can be part of the change that
+// caused it.
+func Create(tx *gorm.DB, n *model.Notification) error {
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now()
+	}
+	return tx.Create(n).Error
+}
+
+// List returns a user's notifications, newest first, and the unread count.
+func (r *Repository) List(userID uint, unreadOnly bool, limit, page int) ([]model.Notification, int64, int64, error) {
+	var items []model.Notification
+	var total, unread int64
+	// a new session so the unread filter below does not stick to q
+	q := r.db.Model(&model.Notification{}).Where("id_user = ?", userID).Session(&gorm.Session{})
+	if err := q.Where("dibaca_at IS NULL").Count(&unread).Error; err != nil {
+		return nil, 0, 0, err
+	}
+	if unreadOnly {
+		q = q.Where("dibaca_at IS NULL")
+	}
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, 0, err
+	}
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+	if err := q.Order("id DESC").Limit(limit).Offset(offset).Find(&items).Error; err != nil {
+		return nil, 0, 0, err
+	}
+	return items, total, unread, nil
+}
+
+// MarkRead marks one notification (id > 0) or all of a user's notifications
+// as read. Returns the number of rows changed.
+func (r *Repository) MarkRead(userID, id uint) (int64, error) {
+	q := r.db.Model(&model.Notification{}).Where("id_user = ? AND dibaca_at IS NULL", userID)
+	if id > 0 {
+		q = q.Where("id = ?", id)
+	}
+	res := q.Update("dibaca_at", time.Now())
+	return res.RowsAffected, res.Error
+}
+
+// Exists reports whether the notification belongs to the user.
+func (r *Repository) Exists(userID, id uint) (bool, error) {
+	var cnt int64
+	if err := r.db.Model(&model.Notification{}).Where("id = ? AND id_user = ?", id, userID).Count(&cnt).Error; err != nil {
+		return false, err
+	}
+	return cnt > 0, nil
+}
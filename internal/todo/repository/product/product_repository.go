@@ -1,12 +1,20 @@
 package product
 
 import (
+    "encoding/json"
     "errors"
+    "fmt"
+    "sort"
+    "strconv"
     "strings"
+    "time"
 
+    notifmodel "project-evermos/internal/todo/model/notification"
     prodmodel "project-evermos/internal/todo/model/product"
+    notifrepo "project-evermos/internal/todo/repository/notification"
 
     "gorm.io/gorm"
+    "gorm.io/gorm/clause"
 )
 
 type Repository struct{ db *gorm.DB }
@@ -29,12 +37,26 @@ func (r *Repository) List(filter ListFilter) ([]prodmodel.Product, int64, error)
     if filter.TokoID > 0 {
         q = q.Where("id_toko = ?", filter.TokoID)
     }
+    if filter.Status != "" {
+        if filter.OwnerTokoID > 0 {
+            q = q.Where("(status = ? OR id_toko = ?)", filter.Status, filter.OwnerTokoID)
+        } else {
+            q = q.Where("status = ?", filter.Status)
+        }
+    }
+    for _, kode := range sortedKeys(filter.Attributes) {
+        q = q.Where("EXISTS (SELECT 1 FROM atribut_produk ap JOIN atribut_category ac ON ac.id = ap.id_atribut WHERE ap.id_produk = produk.id AND ac.kode = ? AND ap.nilai IN ?)",
+            kode, filter.Attributes[kode])
+    }
     if filter.MinHarga != nil {
         q = q.Where("CAST(`harga konsumen` AS SIGNED) >= ?", *filter.MinHarga)
     }
     if filter.MaxHarga != nil {
         q = q.Where("CAST(`harga konsumen` AS SIGNED) <= ?", *filter.MaxHarga)
     }
+    if filter.Verified != nil {
+        q = q.Where("id_toko IN (?)", r.db.Table("toko").Select("id").Where("verified = ?", *filter.Verified))
+    }
 
     if err := q.Count(&count).Error; err != nil {
         return nil, 0, err
@@ -44,9 +66,7 @@ func (r *Repository) List(filter ListFilter) ([]prodmodel.Product, int64, error)
     if offset < 0 { offset = 0 }
 
     if err := q.Order("id DESC").Limit(filter.Limit).Offset(offset).
-        Preload("Photos").
-        Preload("Toko").
-        Preload("Category").
+        Scopes(withDetails).
         Find(&items).Error; err != nil {
         return nil, 0, err
     }
@@ -57,7 +77,7 @@ func (r *Repository) List(filter ListFilter) ([]prodmodel.Product, int64, error)
 func (r *Repository) GetByID(id uint) (*prodmodel.Product, error) {
     var p prodmodel.Product
     if err := r.db.Where("id = ?", id).
-        Preload("Photos").Preload("Toko").Preload("Category").
+        Scopes(withDetails).
         First(&p).Error; err != nil {
         if errors.Is(err, gorm.ErrRecordNotFound) {
             return nil, nil
@@ -67,68 +87,857 @@ func (r *Repository) GetByID(id uint) (*prodmodel.Product, error) {
     return &p, nil
 }
 
+// GetBySlug returns a (non-trashed) product by its current slug.
 func (r *Repository) GetBySlug(slug string) (*prodmodel.Product, error) {
     var p prodmodel.Product
-    if err := r.db.Where("slug = ?", slug).First(&p).Error; err != nil {
+    if err := r.db.Where("slug = ?", slug).
+        Scopes(withDetails).
+        First(&p).Error; err != nil {
         if errors.Is(err, gorm.ErrRecordNotFound) { return nil, nil }
         return nil, err
     }
     return &p, nil
 }
 
-// Create product and photos within transaction
-func (r *Repository) Create(p *prodmodel.Product, photos []prodmodel.Photo) error {
+// CurrentSlugByOld returns the current slug of the product that used to have
+// slug, or "" when none (or the product is trashed).
+func (r *Repository) CurrentSlugByOld(slug string) (string, error) {
+    var slugs []string
+    if err := r.db.Model(&prodmodel.Product{}).
+        Joins("JOIN produk_slug_lama l ON l.id_produk = produk.id").
+        Where("l.slug = ?", slug).Limit(1).
+        Pluck("produk.slug", &slugs).Error; err != nil {
+        return "", err
+    }
+    if len(slugs) == 0 { return "", nil }
+    return slugs[0], nil
+}
+
+// SlugTaken reports whether slug is used by another product, as current or
+// former slug. Trashed products keep their slugs reserved until purge.
+func (r *Repository) SlugTaken(slug string, selfID uint) (bool, error) {
+    var cnt int64
+    if err := r.db.Unscoped().Model(&prodmodel.Product{}).
+        Where("slug = ? AND id <> ?", slug, selfID).Count(&cnt).Error; err != nil {
+        return false, err
+    }
+    if cnt > 0 { return true, nil }
+    if err := r.db.Model(&prodmodel.SlugLama{}).
+        Where("slug = ? AND id_produk <> ?", slug, selfID).Count(&cnt).Error; err != nil {
+        return false, err
+    }
+    return cnt > 0, nil
+}
+
+// withDetails preloads associations needed to render a product.
+// Product-level photos exclude variant photos; those hang off each variant.
+func withDetails(db *gorm.DB) *gorm.DB {
+    return db.
+        Preload("Photos", func(db *gorm.DB) *gorm.DB { return db.Where("id_varian IS NULL").Order(photoOrder) }).
+        Preload("Toko").
+        Preload("Category").
+        Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order("urutan ASC, id ASC") }).
+        Preload("Variants", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+        Preload("Variants.Photos", func(db *gorm.DB) *gorm.DB { return db.Order(photoOrder) }).
+        Preload("Attributes.Atribut").
+        Preload("Sales", ActiveSales)
+}
+
+// ActiveSales narrows a Sales preload to flash sale items of campaigns
+// running now, with the campaign name and window.
+func ActiveSales(db *gorm.DB) *gorm.DB {
+    now := time.Now()
+    return db.Select("flash_sale_item.*, fs.nama AS nama_campaign, fs.mulai, fs.selesai").
+        Joins("JOIN flash_sale fs ON fs.id = flash_sale_item.id_flash_sale").
+        Where("fs.mulai <= ? AND fs.selesai > ?", now, now).
+        Order("flash_sale_item.id ASC")
+}
+
+// CategoryAttributes returns the attribute schema of a category in display order.
+func (r *Repository) CategoryAttributes(categoryID uint) ([]prodmodel.AttributeRef, error) {
+    var rows []prodmodel.AttributeRef
+    if err := r.db.Where("id_category = ?", categoryID).Order("urutan ASC, id ASC").Find(&rows).Error; err != nil {
+        return nil, err
+    }
+    return rows, nil
+}
+
+// AttributeSet is the full attribute value list of a product.
+type AttributeSet struct {
+    Values []prodmodel.AttributeValue
+}
+
+// replaceAttributes rewrites the attribute values of a product.
+func replaceAttributes(tx *gorm.DB, productID uint, set *AttributeSet) error {
+    if err := tx.Where("id_produk = ?", productID).Delete(&prodmodel.AttributeValue{}).Error; err != nil {
+        return err
+    }
+    if len(set.Values) == 0 { return nil }
+    now := time.Now()
+    for i := range set.Values {
+        set.Values[i].IDProduk = productID
+        set.Values[i].CreatedAt = now
+    }
+    return tx.Omit(clause.Associations).Create(&set.Values).Error
+}
+
+const photoOrder = "urutan ASC, id ASC"
+
+// nextPhotoOrder returns the urutan for the next photo appended to a product.
+func nextPhotoOrder(tx *gorm.DB, productID uint) (int, error) {
+    var max *int
+    if err := tx.Model(&prodmodel.Photo{}).Where("id_produk = ?", productID).
+        Select("MAX(urutan)").Scan(&max).Error; err != nil {
+        return 0, err
+    }
+    if max == nil { return 0, nil }
+    return *max + 1, nil
+}
+
+// VariantSet is the full option/variant definition of a product.
+// Variant photos are taken from each Variant.Photos.
+type VariantSet struct {
+    Options  []prodmodel.Option
+    Variants []prodmodel.Variant
+}
+
+// StockActor describes who changed stock and why, for ledger entries
+// written as a side effect of create/update.
+type StockActor struct {
+    UserID uint
+    Tipe   string
+    Alasan string
+}
+
+func (a StockActor) movement(productID uint, variantID *uint, delta int) *prodmodel.StockMovement {
+    m := &prodmodel.StockMovement{IDProduk: productID, IDVarian: variantID, Tipe: a.Tipe, Jumlah: delta, Alasan: a.Alasan}
+    if a.UserID > 0 {
+        uid := a.UserID
+        m.IDUser = &uid
+    }
+    return m
+}
+
+// Create product and photos within transaction.
+// Initial stock is written through the stock ledger.
+func (r *Repository) Create(p *prodmodel.Product, photos []prodmodel.Photo, vs *VariantSet, attrs *AttributeSet, actor StockActor) error {
     return r.db.Transaction(func(tx *gorm.DB) error {
-        if err := tx.Create(p).Error; err != nil {
+        initial := p.Stok
+        p.Stok = 0
+        if err := tx.Omit(clause.Associations).Create(p).Error; err != nil {
             return err
         }
         if len(photos) > 0 {
             for i := range photos {
                 photos[i].IDProduk = p.ID
+                photos[i].Urutan = i
             }
+            photos[0].IsPrimary = true
             if err := tx.Create(&photos).Error; err != nil {
                 return err
             }
         }
-        return nil
+        if vs != nil {
+            if _, err := replaceVariants(tx, p.ID, vs, actor); err != nil {
+                return err
+            }
+        }
+        if attrs != nil {
+            if err := replaceAttributes(tx, p.ID, attrs); err != nil {
+                return err
+            }
+        }
+        // product stock is derived from variant stock when variants exist
+        if vs == nil || len(vs.Variants) == 0 {
+            if err := applyStockChange(tx, actor.movement(p.ID, nil, initial)); err != nil {
+                return err
+            }
+        }
+        p.Stok = initial
+        return recordPrice(tx, p.ID, nil, atoi(p.HargaReseller), atoi(p.HargaKonsumen), actor.UserID)
     })
 }
 
-// Update product fields (partial)
-func (r *Repository) Update(p *prodmodel.Product, addPhotos []prodmodel.Photo) error {
-    return r.db.Transaction(func(tx *gorm.DB) error {
+// Update product fields (partial). Variants and attributes are replaced only
+// when vs and attrs are non-nil. Stock is not written here; use AdjustStock
+// so every change is recorded. Returns the photos of removed variants so the
+// caller can delete the files.
+func (r *Repository) Update(p *prodmodel.Product, addPhotos []prodmodel.Photo, vs *VariantSet, attrs *AttributeSet, actor StockActor) ([]prodmodel.Photo, error) {
+    var removed []prodmodel.Photo
+    err := r.db.Transaction(func(tx *gorm.DB) error {
+        if err := keepOldSlug(tx, p.ID, p.Slug); err != nil {
+            return err
+        }
+        var minimum []int
+        if err := tx.Model(&prodmodel.Product{}).Where("id = ?", p.ID).Pluck("stok_minimum", &minimum).Error; err != nil {
+            return err
+        }
         if err := tx.Model(&prodmodel.Product{}).Where("id = ?", p.ID).Updates(map[string]interface{}{
             "nama_produk":     p.NamaProduk,
             "slug":            p.Slug,
             "harga reseller":  p.HargaReseller,
             "harga konsumen":  p.HargaKonsumen,
-            "stok":            p.Stok,
+            "stok_minimum":    p.StokMinimum,
             "deskripsi":       p.Deskripsi,
+            "berat":           p.Berat,
+            "panjang":         p.Panjang,
+            "lebar":           p.Lebar,
+            "tinggi":          p.Tinggi,
             "id_toko":         p.IDToko,
             "id_category":     p.IDCategory,
         }).Error; err != nil {
             return err
         }
         if len(addPhotos) > 0 {
-            for i := range addPhotos { addPhotos[i].IDProduk = p.ID }
+            next, err := nextPhotoOrder(tx, p.ID)
+            if err != nil { return err }
+            var primaries int64
+            if err := tx.Model(&prodmodel.Photo{}).
+                Where("id_produk = ? AND id_varian IS NULL AND is_primary = ?", p.ID, true).
+                Count(&primaries).Error; err != nil {
+                return err
+            }
+            for i := range addPhotos {
+                addPhotos[i].IDProduk = p.ID
+                addPhotos[i].Urutan = next + i
+            }
+            addPhotos[0].IsPrimary = primaries == 0
             if err := tx.Create(&addPhotos).Error; err != nil { return err }
         }
-        return nil
+        if vs != nil {
+            photos, err := replaceVariants(tx, p.ID, vs, actor)
+            if err != nil { return err }
+            removed = photos
+        }
+        if attrs != nil {
+            if err := replaceAttributes(tx, p.ID, attrs); err != nil { return err }
+        }
+        if len(minimum) > 0 && minimum[0] != p.StokMinimum {
+            if err := syncProductLowStock(tx, p.ID); err != nil { return err }
+        }
+        // only a published product goes back to review; a review that
+        // finished meanwhile is not overwritten with a stale status
+        if p.Status == prodmodel.StatusPendingReview {
+            if err := tx.Model(&prodmodel.Product{}).Where("id = ? AND status = ?", p.ID, prodmodel.StatusPublished).
+                Update("status", prodmodel.StatusPendingReview).Error; err != nil {
+                return err
+            }
+        }
+        return recordPrice(tx, p.ID, nil, atoi(p.HargaReseller), atoi(p.HargaKonsumen), actor.UserID)
+    })
+    if err != nil { return nil, err }
+    return removed, nil
+}
+
+// ErrStatusConflict is returned when a product is not in a state the
+// requested moderation step applies to.
+var ErrStatusConflict = errors.New("status conflict")
+
+// StatusChange moves a product to Status when it is currently in one of
+// From. ReviewerID is recorded for admin decisions; Notify, when set, is
+// sent to the toko owner in the same transaction.
+type StatusChange struct {
+    From       []string
+    Status     string
+    Alasan     *string
+    ReviewerID uint
+    Notify     *notifmodel.Notification
+}
+
+// SetStatus applies a moderation step. The current status is checked in the
+// UPDATE itself so concurrent reviews cannot both succeed.
+func (r *Repository) SetStatus(productID uint, ch StatusChange) error {
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        now := time.Now()
+        upd := map[string]interface{}{"status": ch.Status, "alasan_penolakan": ch.Alasan, "updated_at": now}
+        if ch.ReviewerID > 0 {
+            upd["reviewed_by"] = ch.ReviewerID
+            upd["reviewed_at"] = now
+        }
+        res := tx.Model(&prodmodel.Product{}).Where("id = ? AND status IN ?", productID, ch.From).Updates(upd)
+        if res.Error != nil { return res.Error }
+        if res.RowsAffected == 0 { return ErrStatusConflict }
+        if ch.Notify == nil { return nil }
+        var owners []uint
+        if err := tx.Raw("SELECT t.id_user FROM produk p JOIN toko t ON t.id = p.id_toko WHERE p.id = ?", productID).
+            Scan(&owners).Error; err != nil {
+            return err
+        }
+        if len(owners) == 0 || owners[0] == 0 { return nil }
+        ch.Notify.IDUser = owners[0]
+        return notifrepo.Create(tx, ch.Notify)
+    })
+}
+
+// keepOldSlug records the product's current slug as a former one when it is
+// about to change, and drops newSlug from the former slugs when reclaimed.
+func keepOldSlug(tx *gorm.DB, productID uint, newSlug string) error {
+    var cur []string
+    if err := tx.Model(&prodmodel.Product{}).Where("id = ?", productID).Pluck("slug", &cur).Error; err != nil {
+        return err
+    }
+    if len(cur) == 0 || cur[0] == newSlug { return nil }
+    if err := tx.Where("id_produk = ? AND slug = ?", productID, newSlug).Delete(&prodmodel.SlugLama{}).Error; err != nil {
+        return err
+    }
+    if cur[0] == "" { return nil }
+    old := prodmodel.SlugLama{IDProduk: productID, Slug: cur[0], CreatedAt: time.Now()}
+    return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&old).Error
+}
+
+// replaceVariants rewrites the option list and syncs variants by SKU:
+// matching SKUs are updated in place (keeping their IDs, photos and stock),
+// new SKUs are inserted with their initial stock and SKUs no longer present
+// are removed. Stock effects go through the ledger. Returns the photos of
+// removed variants; their rows go with the variant but the files remain.
+func replaceVariants(tx *gorm.DB, productID uint, vs *VariantSet, actor StockActor) ([]prodmodel.Photo, error) {
+    if err := tx.Where("id_produk = ?", productID).Delete(&prodmodel.Option{}).Error; err != nil {
+        return nil, err
+    }
+    if len(vs.Options) > 0 {
+        for i := range vs.Options { vs.Options[i].IDProduk = productID }
+        if err := tx.Create(&vs.Options).Error; err != nil { return nil, err }
+    }
+
+    var existing []prodmodel.Variant
+    if err := tx.Where("id_produk = ?", productID).Find(&existing).Error; err != nil {
+        return nil, err
+    }
+    bySKU := make(map[string]prodmodel.Variant, len(existing))
+    for _, v := range existing {
+        bySKU[strings.ToLower(v.SKU)] = v
+    }
+
+    keep := make(map[uint]bool, len(vs.Variants))
+    for i := range vs.Variants {
+        v := &vs.Variants[i]
+        v.IDProduk = productID
+        photos := v.Photos
+        v.Photos = nil
+        if old, ok := bySKU[strings.ToLower(v.SKU)]; ok {
+            v.ID = old.ID
+            v.CreatedAt = old.CreatedAt
+            if err := tx.Model(&prodmodel.Variant{}).Where("id = ?", old.ID).Updates(map[string]interface{}{
+                "sku":            v.SKU,
+                "nama_varian":    v.NamaVarian,
+                "opsi_json":      v.OpsiJSON,
+                "harga_reseller": v.HargaReseller,
+                "harga_konsumen": v.HargaKonsumen,
+                "berat":          v.Berat,
+                "panjang":        v.Panjang,
+                "lebar":          v.Lebar,
+                "tinggi":         v.Tinggi,
+                "updated_at":     v.UpdatedAt,
+            }).Error; err != nil {
+                return nil, err
+            }
+            v.Stok = old.Stok
+        } else {
+            initial := v.Stok
+            v.Stok = 0
+            if err := tx.Omit(clause.Associations).Create(v).Error; err != nil {
+                return nil, err
+            }
+            vid := v.ID
+            if err := applyStockChange(tx, actor.movement(productID, &vid, initial)); err != nil {
+                return nil, err
+            }
+            v.Stok = initial
+        }
+        keep[v.ID] = true
+        vid := v.ID
+        if err := recordPrice(tx, productID, &vid, v.HargaReseller, v.HargaKonsumen, actor.UserID); err != nil {
+            return nil, err
+        }
+        if len(photos) > 0 {
+            next, err := nextPhotoOrder(tx, productID)
+            if err != nil { return nil, err }
+            for j := range photos {
+                photos[j].IDProduk = productID
+                photos[j].IDVarian = &v.ID
+                photos[j].Urutan = next + j
+            }
+            if err := tx.Create(&photos).Error; err != nil { return nil, err }
+        }
+        v.Photos = photos
+    }
+
+    var gone []prodmodel.Photo
+    for _, old := range existing {
+        if keep[old.ID] { continue }
+        vid := old.ID
+        removed := StockActor{UserID: actor.UserID, Tipe: prodmodel.MovementAdjustment, Alasan: "varian dihapus"}
+        if err := applyStockChange(tx, removed.movement(productID, &vid, -old.Stok)); err != nil { return nil, err }
+        var ph []prodmodel.Photo
+        if err := tx.Where("id_varian = ?", old.ID).Find(&ph).Error; err != nil { return nil, err }
+        gone = append(gone, ph...)
+        if err := tx.Delete(&prodmodel.Variant{}, old.ID).Error; err != nil { return nil, err }
+    }
+    return gone, nil
+}
+
+// DeletePhoto removes one photo of a product and returns it so the caller
+// can delete the file. Returns nil, nil when the photo does not exist.
+func (r *Repository) DeletePhoto(productID, photoID uint) (*prodmodel.Photo, error) {
+    var ph prodmodel.Photo
+    if err := r.db.Where("id = ? AND id_produk = ?", photoID, productID).First(&ph).Error; err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) { return nil, nil }
+        return nil, err
+    }
+    err := r.db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Delete(&prodmodel.Photo{}, ph.ID).Error; err != nil {
+            return err
+        }
+        if !ph.IsPrimary || ph.IDVarian != nil { return nil }
+        // promote the next product photo to cover
+        var next prodmodel.Photo
+        err := tx.Where("id_produk = ? AND id_varian IS NULL", productID).Order(photoOrder).First(&next).Error
+        if errors.Is(err, gorm.ErrRecordNotFound) { return nil }
+        if err != nil { return err }
+        return tx.Model(&prodmodel.Photo{}).Where("id = ?", next.ID).Update("is_primary", true).Error
+    })
+    if err != nil { return nil, err }
+    return &ph, nil
+}
+
+// ReorderPhotos sets urutan of the product-level photos to their position in
+// ids and, when primaryID is non-nil, marks that photo as the only primary one.
+func (r *Repository) ReorderPhotos(productID uint, ids []uint, primaryID *uint) error {
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        for i, id := range ids {
+            if err := tx.Model(&prodmodel.Photo{}).Where("id = ? AND id_produk = ?", id, productID).
+                Updates(map[string]interface{}{"urutan": i, "updated_at": time.Now()}).Error; err != nil {
+                return err
+            }
+        }
+        if primaryID == nil { return nil }
+        if err := tx.Model(&prodmodel.Photo{}).Where("id_produk = ? AND id <> ?", productID, *primaryID).
+            Update("is_primary", false).Error; err != nil {
+            return err
+        }
+        return tx.Model(&prodmodel.Photo{}).Where("id = ? AND id_produk = ?", *primaryID, productID).
+            Update("is_primary", true).Error
+    })
+}
+
+// ErrInsufficientStock is returned when a change would make stock negative.
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// AdjustStock applies a stock change in its own transaction. When target is
+// non-nil the stock is set to that value and m.Jumlah is computed from it.
+func (r *Repository) AdjustStock(m *prodmodel.StockMovement, target *int) error {
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        if target != nil {
+            cur, err := currentStock(tx, m.IDProduk, m.IDVarian)
+            if err != nil { return err }
+            m.Jumlah = *target - cur
+        }
+        return applyWatched(tx, m)
+    })
+}
+
+// ApplyStockChange applies a stock change inside the caller's transaction.
+func (r *Repository) ApplyStockChange(tx *gorm.DB, m *prodmodel.StockMovement) error {
+    return applyWatched(tx, m)
+}
+
+// applyWatched is applyStockChange followed by the low stock check. Initial
+// stock and variant removal skip the check as they are not sales or edits.
+func applyWatched(tx *gorm.DB, m *prodmodel.StockMovement) error {
+    if m.Jumlah == 0 { return nil }
+    if err := applyStockChange(tx, m); err != nil { return err }
+    return syncLowStock(tx, m.IDProduk, m.IDVarian, m.StokSesudah)
+}
+
+// syncLowStock opens a low stock alert, and notifies the toko owner, when
+// stok is at or below the product's stok_minimum and no alert is open yet.
+// The open alert is resolved once stok is above the threshold again, so
+// every crossing raises exactly one alert.
+func syncLowStock(tx *gorm.DB, productID uint, variantID *uint, stok int) error {
+    var info struct {
+        NamaProduk  string
+        StokMinimum int
+        IDToko      uint
+        IDUser      *uint
+    }
+    if err := tx.Raw("SELECT p.nama_produk, p.stok_minimum, p.id_toko, t.id_user FROM produk p JOIN toko t ON t.id = p.id_toko WHERE p.id = ?", productID).
+        Scan(&info).Error; err != nil {
+        return err
+    }
+    if info.IDToko == 0 { return nil }
+    open := func() *gorm.DB {
+        q := tx.Model(&prodmodel.StockAlert{}).Where("id_produk = ? AND resolved_at IS NULL", productID)
+        if variantID != nil { return q.Where("id_varian = ?", *variantID) }
+        return q.Where("id_varian IS NULL")
+    }
+    if stok > info.StokMinimum {
+        return open().Update("resolved_at", time.Now()).Error
+    }
+    var cnt int64
+    if err := open().Count(&cnt).Error; err != nil { return err }
+    if cnt > 0 { return nil }
+
+    a := &prodmodel.StockAlert{
+        IDToko:    info.IDToko,
+        IDProduk:  productID,
+        IDVarian:  variantID,
+        Stok:      stok,
+        Batas:     info.StokMinimum,
+        CreatedAt: time.Now(),
+    }
+    if err := tx.Create(a).Error; err != nil { return err }
+    if info.IDUser == nil { return nil }
+
+    name := info.NamaProduk
+    if variantID != nil {
+        var vn []string
+        if err := tx.Model(&prodmodel.Variant{}).Where("id = ?", *variantID).Pluck("nama_varian", &vn).Error; err != nil {
+            return err
+        }
+        if len(vn) > 0 && vn[0] != "" { name += " (" + vn[0] + ")" }
+    }
+    judul := "Stok menipis: " + name
+    if stok <= 0 { judul = "Stok habis: " + name }
+    data, _ := json.Marshal(map[string]interface{}{"alert_id": a.ID, "product_id": productID, "variant_id": variantID})
+    return notifrepo.Create(tx, &notifmodel.Notification{
+        IDUser:   *info.IDUser,
+        Tipe:     notifmodel.TypeLowStock,
+        Judul:    judul,
+        Pesan:    fmt.Sprintf("Stok %s tinggal %d (batas minimum %d).", name, stok, info.StokMinimum),
+        DataJSON: string(data),
     })
 }
 
+// syncProductLowStock re-checks all stock of a product, e.g. after its
+// threshold changed: each variant, or the product itself without variants.
+func syncProductLowStock(tx *gorm.DB, productID uint) error {
+    var variants []prodmodel.Variant
+    if err := tx.Select("id", "stok").Where("id_produk = ?", productID).Find(&variants).Error; err != nil {
+        return err
+    }
+    if len(variants) == 0 {
+        stok, err := currentStock(tx, productID, nil)
+        if err != nil { return err }
+        return syncLowStock(tx, productID, nil, stok)
+    }
+    for _, v := range variants {
+        vid := v.ID
+        if err := syncLowStock(tx, productID, &vid, v.Stok); err != nil { return err }
+    }
+    return nil
+}
+
+// ListStockAlerts returns a toko's low stock alerts, newest first.
+// status is "open", "resolved" or "" for all.
+func (r *Repository) ListStockAlerts(tokoID uint, status string, limit, page int) ([]StockAlertRow, int64, error) {
+    var rows []StockAlertRow
+    var count int64
+    q := r.db.Table("stock_alert a").
+        Joins("JOIN produk p ON p.id = a.id_produk").
+        Joins("LEFT JOIN varian_produk v ON v.id = a.id_varian").
+        Where("a.id_toko = ? AND p.deleted_at IS NULL", tokoID)
+    switch status {
+    case "open":
+        q = q.Where("a.resolved_at IS NULL")
+    case "resolved":
+        q = q.Where("a.resolved_at IS NOT NULL")
+    }
+    if err := q.Count(&count).Error; err != nil {
+        return nil, 0, err
+    }
+    offset := (page - 1) * limit
+    if offset < 0 { offset = 0 }
+    if err := q.Select("a.*, p.nama_produk, p.slug, p.stok_minimum, v.sku, v.nama_varian, COALESCE(v.stok, p.stok) AS stok_sekarang").
+        Order("a.id DESC").Limit(limit).Offset(offset).Scan(&rows).Error; err != nil {
+        return nil, 0, err
+    }
+    return rows, count, nil
+}
+
+// StockAlertRow is a stock alert with the product/variant it concerns.
+type StockAlertRow struct {
+    prodmodel.StockAlert
+    NamaProduk   string
+    Slug         string
+    StokMinimum  int
+    SKU          *string `gorm:"column:sku"`
+    NamaVarian   *string
+    StokSekarang int
+}
+
+// currentStock reads the variant (when set) or product stock under a row lock.
+func currentStock(tx *gorm.DB, productID uint, variantID *uint) (int, error) {
+    lock := clause.Locking{Strength: "UPDATE"}
+    if variantID != nil {
+        var v prodmodel.Variant
+        if err := tx.Clauses(lock).Select("id", "stok").
+            Where("id = ? AND id_produk = ?", *variantID, productID).First(&v).Error; err != nil {
+            return 0, err
+        }
+        return v.Stok, nil
+    }
+    var p prodmodel.Product
+    if err := tx.Clauses(lock).Select("id", "stok").Where("id = ?", productID).First(&p).Error; err != nil {
+        return 0, err
+    }
+    return p.Stok, nil
+}
+
+// applyStockChange adds m.Jumlah to the variant (when set) and product stock
+// under row locks, then records the movement with before/after values.
+// Variant changes are mirrored on the product so its stock stays the sum.
+func applyStockChange(tx *gorm.DB, m *prodmodel.StockMovement) error {
+    if m.Jumlah == 0 {
+        return nil
+    }
+    lock := clause.Locking{Strength: "UPDATE"}
+
+    var prod prodmodel.Product
+    if err := tx.Clauses(lock).Select("id", "stok").Where("id = ?", m.IDProduk).First(&prod).Error; err != nil {
+        return err
+    }
+    before := prod.Stok
+    if m.IDVarian != nil {
+        var v prodmodel.Variant
+        if err := tx.Clauses(lock).Select("id", "stok", "sku", "nama_varian").
+            Where("id = ? AND id_produk = ?", *m.IDVarian, m.IDProduk).First(&v).Error; err != nil {
+            return err
+        }
+        before = v.Stok
+        m.SKU, m.NamaVarian = v.SKU, v.NamaVarian
+        if before+m.Jumlah < 0 {
+            return ErrInsufficientStock
+        }
+        if err := tx.Model(&prodmodel.Variant{}).Where("id = ?", v.ID).
+            Update("stok", before+m.Jumlah).Error; err != nil {
+            return err
+        }
+        next := prod.Stok + m.Jumlah
+        if next < 0 { next = 0 }
+        if err := tx.Model(&prodmodel.Product{}).Where("id = ?", prod.ID).Update("stok", next).Error; err != nil {
+            return err
+        }
+    } else {
+        if before+m.Jumlah < 0 {
+            return ErrInsufficientStock
+        }
+        if err := tx.Model(&prodmodel.Product{}).Where("id = ?", prod.ID).
+            Update("stok", before+m.Jumlah).Error; err != nil {
+            return err
+        }
+    }
+    m.StokSebelum = before
+    m.StokSesudah = before + m.Jumlah
+    if m.CreatedAt.IsZero() {
+        m.CreatedAt = time.Now()
+    }
+    return tx.Create(m).Error
+}
+
+// ListStockMovements returns a product's stock history, newest first.
+func (r *Repository) ListStockMovements(filter StockHistoryFilter) ([]prodmodel.StockMovement, int64, error) {
+    var rows []prodmodel.StockMovement
+    var count int64
+    q := r.db.Model(&prodmodel.StockMovement{}).Where("id_produk = ?", filter.ProductID)
+    if filter.VariantID > 0 {
+        q = q.Where("id_varian = ?", filter.VariantID)
+    }
+    if t := strings.TrimSpace(filter.Tipe); t != "" {
+        q = q.Where("tipe = ?", t)
+    }
+    if err := q.Count(&count).Error; err != nil {
+        return nil, 0, err
+    }
+    offset := (filter.Page - 1) * filter.Limit
+    if offset < 0 { offset = 0 }
+    if err := q.Order("id DESC").Limit(filter.Limit).Offset(offset).Find(&rows).Error; err != nil {
+        return nil, 0, err
+    }
+    return rows, count, nil
+}
+
+// recordPrice appends a price point when the price differs from the latest
+// list price of the product (or variant). The first point is an initial one.
+// Order points are skipped as they may carry a flash sale price.
+func recordPrice(tx *gorm.DB, productID uint, variantID *uint, reseller, konsumen int, userID uint) error {
+    var last []prodmodel.PriceHistory
+    if err := priceSeries(tx, productID, variantID).Where("sumber <> ?", prodmodel.PriceOrder).Order("created_at DESC, id DESC").Limit(1).Find(&last).Error; err != nil {
+        return err
+    }
+    h := &prodmodel.PriceHistory{
+        IDProduk:      productID,
+        IDVarian:      variantID,
+        HargaReseller: reseller,
+        HargaKonsumen: konsumen,
+        Sumber:        prodmodel.PriceInitial,
+        CreatedAt:     time.Now(),
+    }
+    if len(last) > 0 {
+        if last[0].HargaReseller == reseller && last[0].HargaKonsumen == konsumen { return nil }
+        h.Sumber = prodmodel.PriceUpdate
+    }
+    if userID > 0 {
+        uid := userID
+        h.IDUser = &uid
+    }
+    return tx.Create(h).Error
+}
+
+// RecordOrderPrice appends the price charged for a product (or variant) in an
+// order, inside the caller's transaction.
+func (r *Repository) RecordOrderPrice(tx *gorm.DB, productID uint, variantID *uint, reseller, konsumen int) error {
+    return tx.Create(&prodmodel.PriceHistory{
+        IDProduk:      productID,
+        IDVarian:      variantID,
+        HargaReseller: reseller,
+        HargaKonsumen: konsumen,
+        Sumber:        prodmodel.PriceOrder,
+        CreatedAt:     time.Now(),
+    }).Error
+}
+
+// priceSeries scopes price_history to one product, or one of its variants.
+func priceSeries(db *gorm.DB, productID uint, variantID *uint) *gorm.DB {
+    q := db.Model(&prodmodel.PriceHistory{}).Where("id_produk = ?", productID)
+    if variantID != nil { return q.Where("id_varian = ?", *variantID) }
+    return q.Where("id_varian IS NULL")
+}
+
+// ListPriceHistory returns the price points of a product (or variant) since
+// the given time, oldest first, preceded by the point in effect at since.
+func (r *Repository) ListPriceHistory(productID uint, variantID *uint, since time.Time) ([]prodmodel.PriceHistory, error) {
+    var before []prodmodel.PriceHistory
+    if err := priceSeries(r.db, productID, variantID).Where("created_at < ?", since).
+        Order("created_at DESC, id DESC").Limit(1).Find(&before).Error; err != nil {
+        return nil, err
+    }
+    var rows []prodmodel.PriceHistory
+    if err := priceSeries(r.db, productID, variantID).Where("created_at >= ?", since).
+        Order("created_at ASC, id ASC").Find(&rows).Error; err != nil {
+        return nil, err
+    }
+    return append(before, rows...), nil
+}
+
+func sortedKeys(m map[string][]string) []string {
+    keys := make([]string, 0, len(m))
+    for k := range m { keys = append(keys, k) }
+    sort.Strings(keys)
+    return keys
+}
+
+func atoi(s string) int {
+    n, _ := strconv.Atoi(strings.TrimSpace(s))
+    return n
+}
+
+// Delete moves a product to the trash (soft delete).
 func (r *Repository) Delete(id uint) error {
-    return r.db.Delete(&prodmodel.Product{}, id).Error
+    res := r.db.Delete(&prodmodel.Product{}, id)
+    if res.Error != nil { return res.Error }
+    if res.RowsAffected == 0 { return gorm.ErrRecordNotFound }
+    return nil
+}
+
+// ListTrashed returns a toko's trashed products, most recently deleted first.
+func (r *Repository) ListTrashed(tokoID uint, limit, page int) ([]prodmodel.Product, int64, error) {
+    var items []prodmodel.Product
+    var count int64
+    q := r.db.Unscoped().Model(&prodmodel.Product{}).
+        Where("id_toko = ? AND deleted_at IS NOT NULL", tokoID)
+    if err := q.Count(&count).Error; err != nil {
+        return nil, 0, err
+    }
+    offset := (page - 1) * limit
+    if offset < 0 { offset = 0 }
+    if err := q.Order("deleted_at DESC, id DESC").Limit(limit).Offset(offset).
+        Scopes(withDetails).
+        Find(&items).Error; err != nil {
+        return nil, 0, err
+    }
+    return items, count, nil
+}
+
+// ListFeed returns the published products of the stores userID follows,
+// newest first.
+func (r *Repository) ListFeed(userID uint, limit, page int) ([]prodmodel.Product, int64, error) {
+    var items []prodmodel.Product
+    var count int64
+    q := r.db.Model(&prodmodel.Product{}).
+        Where("status = ? AND id_toko IN (?)", prodmodel.StatusPublished,
+            r.db.Table("toko_follower").Select("id_toko").Where("id_user = ?", userID))
+    if err := q.Count(&count).Error; err != nil {
+        return nil, 0, err
+    }
+    offset := (page - 1) * limit
+    if offset < 0 { offset = 0 }
+    if err := q.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).
+        Scopes(withDetails).
+        Find(&items).Error; err != nil {
+        return nil, 0, err
+    }
+    return items, count, nil
+}
+
+// EachByToko walks a toko's products with details in id order, batchSize at
+// a time, so a full catalog is never held in memory.
+func (r *Repository) EachByToko(tokoID uint, batchSize int, fn func([]prodmodel.Product) error) error {
+    var items []prodmodel.Product
+    return r.db.Where("id_toko = ?", tokoID).Scopes(withDetails).
+        FindInBatches(&items, batchSize, func(tx *gorm.DB, batch int) error {
+            return fn(items)
+        }).Error
 }
 
-// Ownership helpers
-func (r *Repository) GetOwnerUserIDByProductID(id uint) (uint, error) {
-    type row struct{ UserID uint }
-    var out row
-    // join produk -> toko to get toko.id_user
-    err := r.db.Raw("SELECT t.id_user AS user_id FROM produk p JOIN toko t ON p.id_toko = t.id WHERE p.id = ?", id).Scan(&out).Error
-    if err != nil { return 0, err }
-    return out.UserID, nil
+// Restore takes a product out of the trash.
+func (r *Repository) Restore(id uint) error {
+    res := r.db.Unscoped().Model(&prodmodel.Product{}).
+        Where("id = ? AND deleted_at IS NOT NULL", id).
+        Updates(map[string]interface{}{"deleted_at": nil, "updated_at": time.Now()})
+    if res.Error != nil { return res.Error }
+    if res.RowsAffected == 0 { return gorm.ErrRecordNotFound }
+    return nil
+}
+
+// PurgeTrashed permanently deletes up to limit products trashed before the
+// given time. Photos, variants and stock history go with them via FK cascade.
+// Returns the photos of the purged products so their files can be removed.
+func (r *Repository) PurgeTrashed(before time.Time, limit int) (int, []prodmodel.Photo, error) {
+    var ids []uint
+    if err := r.db.Unscoped().Model(&prodmodel.Product{}).
+        Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+        Order("deleted_at ASC").Limit(limit).
+        Pluck("id", &ids).Error; err != nil {
+        return 0, nil, err
+    }
+    if len(ids) == 0 {
+        return 0, nil, nil
+    }
+    var photos []prodmodel.Photo
+    err := r.db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Where("id_produk IN ?", ids).Find(&photos).Error; err != nil {
+            return err
+        }
+        return tx.Unscoped().Where("id IN ? AND deleted_at IS NOT NULL", ids).Delete(&prodmodel.Product{}).Error
+    })
+    if err != nil { return 0, nil, err }
+    return len(ids), photos, nil
+}
+
+// Ownership helpers: the toko of a product, 0 when there is none.
+func (r *Repository) GetTokoIDByProductID(id uint) (uint, error) {
+    var ids []uint
+    err := r.db.Model(&prodmodel.Product{}).Where("id = ?", id).Pluck("id_toko", &ids).Error
+    if err != nil || len(ids) == 0 { return 0, err }
+    return ids[0], nil
+}
+
+// GetTrashedTokoID is GetTokoIDByProductID for trashed products only.
+func (r *Repository) GetTrashedTokoID(id uint) (uint, error) {
+    var ids []uint
+    err := r.db.Unscoped().Model(&prodmodel.Product{}).Where("id = ? AND deleted_at IS NOT NULL", id).Pluck("id_toko", &ids).Error
+    if err != nil || len(ids) == 0 { return 0, err }
+    return ids[0], nil
 }
 
 func (r *Repository) CategoryExists(id uint) (bool, error) {
@@ -139,13 +948,48 @@ func (r *Repository) CategoryExists(id uint) (bool, error) {
     return cnt > 0, nil
 }
 
+// TokoHasOrigin reports whether a toko has set its origin address.
+func (r *Repository) TokoHasOrigin(tokoID uint) (bool, error) {
+    var cnt int64
+    err := r.db.Table("toko").Where("id = ? AND asal_id_kota IS NOT NULL AND asal_id_kota <> ''", tokoID).Count(&cnt).Error
+    return cnt > 0, err
+}
+
+// CategoryIDByName finds a category by case-insensitive name; 0 when absent.
+func (r *Repository) CategoryIDByName(name string) (uint, error) {
+    var ids []uint
+    if err := r.db.Table("category").Where("LOWER(nama_category) = LOWER(?)", strings.TrimSpace(name)).
+        Limit(1).Pluck("id", &ids).Error; err != nil {
+        return 0, err
+    }
+    if len(ids) == 0 { return 0, nil }
+    return ids[0], nil
+}
+
+// Filter input for stock history
+type StockHistoryFilter struct {
+    ProductID uint
+    VariantID uint
+    Tipe      string
+    Limit     int
+    Page      int
+}
+
 // Filter input for listing
 type ListFilter struct {
-    NamaProduk string
-    CategoryID uint
-    TokoID     uint
-    MinHarga   *int
-    MaxHarga   *int
-    Limit      int
-    Page       int
-}
\ No newline at end of file
+    NamaProduk  string
+    CategoryID  uint
+    TokoID      uint
+    // Status limits the list to one moderation state ("" = any). Products
+    // of OwnerTokoID are listed regardless, so sellers see their drafts.
+    Status      string
+    OwnerTokoID uint
+    // Attributes filters by attribute kode; any of the values matches
+    Attributes  map[string][]string
+    MinHarga    *int
+    MaxHarga    *int
+    // Verified limits the list to products of (un)verified stores when set
+    Verified    *bool
+    Limit       int
+    Page        int
+}
@@ -0,0 +1,220 @@
+package toko
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	notifmodel "project-evermos/internal/todo/model/notification"
+	model "project-evermos/internal/todo/model/toko"
+	notifrepo "project-evermos/internal/todo/repository/notification"
+)
+
+// ErrInvitationClosed is returned when answering an invitation that is no
+// longer pending.
+var ErrInvitationClosed = errors.New("invitation closed")
+
+// FindMember returns the membership of a user in a toko, or nil. The owner
+// has no membership row.
+func (r *Repository) FindMember(tokoID, userID uint) (*model.Member, error) {
+	var m model.Member
+	if err := r.db.Where("id_toko = ? AND id_user = ?", tokoID, userID).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ListMembers returns the owner of a toko followed by its members, oldest
+// first.
+func (r *Repository) ListMembers(tokoID uint) ([]model.MemberRow, error) {
+	var owner []model.MemberRow
+	if err := r.db.Table("toko t").
+		Select("u.id AS id_user, u.nama, u.email, u.notelp AS no_telp, ? AS role, t.created_at", model.RoleOwner).
+		Joins("JOIN users u ON u.id = t.id_user").
+		Where("t.id = ?", tokoID).Scan(&owner).Error; err != nil {
+		return nil, err
+	}
+	var rows []model.MemberRow
+	if err := r.db.Table("toko_member m").
+		Select("u.id AS id_user, u.nama, u.email, u.notelp AS no_telp, m.role, m.created_at").
+		Joins("JOIN users u ON u.id = m.id_user").
+		Where("m.id_toko = ?", tokoID).Order("m.id ASC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return append(owner, rows...), nil
+}
+
+// ListMemberships returns the stores a user owns or is a member of; owned
+// stores first.
+func (r *Repository) ListMemberships(userID uint) ([]model.Membership, error) {
+	var owned []model.Toko
+	if err := r.db.Where("id_user = ?", userID).Order("id ASC").Find(&owned).Error; err != nil {
+		return nil, err
+	}
+	var members []model.Member
+	if err := r.db.Where("id_user = ?", userID).Order("id ASC").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	out := make([]model.Membership, 0, len(owned)+len(members))
+	for _, t := range owned {
+		out = append(out, model.Membership{Toko: t, Role: model.RoleOwner})
+	}
+	for _, m := range members {
+		t, err := r.FindByID(m.IDToko)
+		if err != nil {
+			return nil, err
+		}
+		if t != nil {
+			out = append(out, model.Membership{Toko: *t, Role: m.Role})
+		}
+	}
+	return out, nil
+}
+
+// UpdateMemberRole changes the role of a member. Returns false when the user
+// is not a member.
+func (r *Repository) UpdateMemberRole(tokoID, userID uint, role string) (bool, error) {
+	res := r.db.Model(&model.Member{}).Where("id_toko = ? AND id_user = ?", tokoID, userID).
+		Updates(map[string]interface{}{"role": role, "updated_at": time.Now()})
+	return res.RowsAffected == 1, res.Error
+}
+
+// DeleteMember removes a member. Returns false when the user is not a member.
+func (r *Repository) DeleteMember(tokoID, userID uint) (bool, error) {
+	res := r.db.Where("id_toko = ? AND id_user = ?", tokoID, userID).Delete(&model.Member{})
+	return res.RowsAffected == 1, res.Error
+}
+
+// FindUserIDByKontak returns the user with email or phone kontak, or 0.
+func (r *Repository) FindUserIDByKontak(kontak string) (uint, error) {
+	var ids []uint
+	if err := r.db.Table("users").Where("email = ? OR notelp = ?", kontak, kontak).Limit(1).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return ids[0], nil
+}
+
+// UserKontak returns the email and phone of a user.
+func (r *Repository) UserKontak(userID uint) (email, noTelp string, err error) {
+	var row struct {
+		Email  string
+		NoTelp string
+	}
+	err = r.db.Table("users").Select("email, notelp AS no_telp").Where("id = ?", userID).Scan(&row).Error
+	return row.Email, row.NoTelp, err
+}
+
+// FindPendingInvitation returns the pending invitation of kontak to a toko,
+// or nil.
+func (r *Repository) FindPendingInvitation(tokoID uint, kontak string, now time.Time) (*model.Invitation, error) {
+	var inv model.Invitation
+	err := r.db.Where("id_toko = ? AND kontak = ? AND status = ? AND expires_at > ?", tokoID, kontak, model.InvitePending, now).
+		First(&inv).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// CreateInvitation saves an invitation and, when kontak already belongs to a
+// user, notifies them.
+func (r *Repository) CreateInvitation(inv *model.Invitation, inviteeID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(inv).Error; err != nil {
+			return err
+		}
+		if inviteeID == 0 {
+			return nil
+		}
+		var nama []string
+		if err := tx.Model(&model.Toko{}).Where("id = ?", inv.IDToko).Pluck("nama_toko", &nama).Error; err != nil {
+			return err
+		}
+		toko := ""
+		if len(nama) > 0 {
+			toko = nama[0]
+		}
+		return notifrepo.Create(tx, &notifmodel.Notification{
+			IDUser:   inviteeID,
+			Tipe:     notifmodel.TypeStoreInvitation,
+			Judul:    "Undangan bergabung ke " + toko,
+			Pesan:    fmt.Sprintf("Anda diundang bergabung ke toko %s sebagai %s.", toko, inv.Role),
+			DataJSON: fmt.Sprintf(`{"invitation_id":%d,"toko_id":%d}`, inv.ID, inv.IDToko),
+		})
+	})
+}
+
+// FindInvitation returns an invitation with its toko, or nil.
+func (r *Repository) FindInvitation(id uint) (*model.Invitation, error) {
+	var inv model.Invitation
+	if err := r.db.Preload("Toko").Where("id = ?", id).First(&inv).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// ListInvitations returns the pending invitations of a toko, newest first.
+func (r *Repository) ListInvitations(tokoID uint, now time.Time) ([]model.Invitation, error) {
+	var items []model.Invitation
+	err := r.db.Where("id_toko = ? AND status = ? AND expires_at > ?", tokoID, model.InvitePending, now).
+		Order("id DESC").Find(&items).Error
+	return items, err
+}
+
+// ListInvitationsByKontak returns the pending invitations sent to any of the
+// contacts, with their toko, newest first.
+func (r *Repository) ListInvitationsByKontak(kontak []string, now time.Time) ([]model.Invitation, error) {
+	var items []model.Invitation
+	err := r.db.Preload("Toko").
+		Where("kontak IN ? AND status = ? AND expires_at > ?", kontak, model.InvitePending, now).
+		Order("id DESC").Find(&items).Error
+	return items, err
+}
+
+// CancelInvitation closes a pending invitation of a toko. Returns false when
+// there is none.
+func (r *Repository) CancelInvitation(tokoID, id uint) (bool, error) {
+	res := r.db.Model(&model.Invitation{}).Where("id = ? AND id_toko = ? AND status = ?", id, tokoID, model.InvitePending).
+		Updates(map[string]interface{}{"status": model.InviteCancelled, "updated_at": time.Now()})
+	return res.RowsAffected == 1, res.Error
+}
+
+// AnswerInvitation closes a pending invitation for userID; accepting also
+// adds them as member. Returns ErrInvitationClosed when it was answered or
+// cancelled meanwhile.
+func (r *Repository) AnswerInvitation(inv *model.Invitation, userID uint, accept bool) error {
+	now := time.Now()
+	status := model.InviteDeclined
+	if accept {
+		status = model.InviteAccepted
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Invitation{}).Where("id = ? AND status = ? AND expires_at > ?", inv.ID, model.InvitePending, now).
+			Updates(map[string]interface{}{"status": status, "id_user": userID, "updated_at": now})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrInvitationClosed
+		}
+		if !accept {
+			return nil
+		}
+		m := model.Member{IDToko: inv.IDToko, IDUser: userID, Role: inv.Role, CreatedAt: now, UpdatedAt: now}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&m).Error
+	})
+}
@@ -2,9 +2,14 @@ package toko
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"project-evermos/internal/slug"
+	prodmodel "project-evermos/internal/todo/model/product"
 	model "project-evermos/internal/todo/model/toko"
 )
 
@@ -15,10 +20,64 @@ type Repository struct {
 
 func NewRepository(db *gorm.DB) *Repository { return &Repository{db: db} }
 
+// Create saves a toko, deriving a unique slug from its name when unset.
 func (r *Repository) Create(t *model.Toko) error {
+	if t.Slug == "" {
+		sl, err := r.UniqueSlug(t.NamaToko, 0)
+		if err != nil {
+			return err
+		}
+		t.Slug = sl
+	}
 	return r.db.Create(t).Error
 }
 
+// UniqueSlug returns a slug for name that no other toko uses, now or
+// formerly. selfID is the toko being renamed, 0 when creating.
+func (r *Repository) UniqueSlug(name string, selfID uint) (string, error) {
+	base := slug.Make(name, fmt.Sprintf("toko-%d", time.Now().Unix()))
+	return slug.Unique(base, func(c string) (bool, error) {
+		var cnt int64
+		if err := r.db.Model(&model.Toko{}).Where("slug = ? AND id <> ?", c, selfID).Count(&cnt).Error; err != nil {
+			return false, err
+		}
+		if cnt > 0 {
+			return true, nil
+		}
+		if err := r.db.Model(&model.SlugLama{}).Where("slug = ? AND id_toko <> ?", c, selfID).Count(&cnt).Error; err != nil {
+			return false, err
+		}
+		return cnt > 0, nil
+	})
+}
+
+func (r *Repository) FindBySlug(sl string) (*model.Toko, error) {
+	var t model.Toko
+	if err := r.db.Where("slug = ?", sl).First(&t).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// CurrentSlugByOld returns the current slug of the toko that used to have
+// slug, or "" when none.
+func (r *Repository) CurrentSlugByOld(sl string) (string, error) {
+	var slugs []string
+	if err := r.db.Model(&model.Toko{}).
+		Joins("JOIN toko_slug_lama l ON l.id_toko = toko.id").
+		Where("l.slug = ?", sl).Limit(1).
+		Pluck("toko.slug", &slugs).Error; err != nil {
+		return "", err
+	}
+	if len(slugs) == 0 {
+		return "", nil
+	}
+	return slugs[0], nil
+}
+
 func (r *Repository) FindByUserID(userID uint) (*model.Toko, error) {
 	var t model.Toko
 	if err := r.db.Where("id_user = ?", userID).First(&t).Error; err != nil {
@@ -41,17 +100,42 @@ func (r *Repository) FindByID(id uint) (*model.Toko, error) {
 	return &t, nil
 }
 
+// Update saves a toko. When its slug changes, the old slug is kept so old
+// links redirect; a reclaimed former slug is dropped from that list.
 func (r *Repository) Update(t *model.Toko) error {
-	return r.db.Save(t).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var cur []string
+		if err := tx.Model(&model.Toko{}).Where("id = ?", t.ID).Pluck("slug", &cur).Error; err != nil {
+			return err
+		}
+		if len(cur) > 0 && cur[0] != t.Slug {
+			if err := tx.Where("id_toko = ? AND slug = ?", t.ID, t.Slug).Delete(&model.SlugLama{}).Error; err != nil {
+				return err
+			}
+			if cur[0] != "" {
+				old := model.SlugLama{IDToko: t.ID, Slug: cur[0], CreatedAt: time.Now()}
+				if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&old).Error; err != nil {
+					return err
+				}
+			}
+		}
+		// the badge is only granted through ReviewVerification
+		return tx.Omit("verified", "verified_at").Save(t).Error
+	})
 }
 
-func (r *Repository) List(limit, page int, name string) ([]model.Toko, int64, error) {
+// List returns stores by name; verified limits them to (un)verified stores
+// when set.
+func (r *Repository) List(limit, page int, name string, verified *bool) ([]model.Toko, int64, error) {
 	var items []model.Toko
 	var count int64
 	q := r.db.Model(&model.Toko{})
 	if strings.TrimSpace(name) != "" {
 		q = q.Where("nama_toko LIKE ?", "%"+strings.TrimSpace(name)+"%")
 	}
+	if verified != nil {
+		q = q.Where("verified = ?", *verified)
+	}
 	if err := q.Count(&count).Error; err != nil {
 		return nil, 0, err
 	}
@@ -60,4 +144,58 @@ func (r *Repository) List(limit, page int, name string) ([]model.Toko, int64, er
 		return nil, 0, err
 	}
 	return items, count, nil
-}
\ No newline at end of file
+}
+
+// Stats computes the store page figures of a toko.
+func (r *Repository) Stats(id uint) (*model.Stats, error) {
+	var st model.Stats
+	if err := r.db.Table("produk").
+		Where("id_toko = ? AND status = ? AND deleted_at IS NULL", id, prodmodel.StatusPublished).
+		Count(&st.JumlahProduk).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Table("detail_trx").Where("id_toko = ?", id).
+		Select("COALESCE(SUM(kuantitas), 0)").Scan(&st.TotalTerjual).Error; err != nil {
+		return nil, err
+	}
+	var rating struct {
+		Avg *float64
+		Cnt int64
+	}
+	if err := r.db.Table("ulasan").Where("id_toko = ?", id).
+		Select("AVG(rating) AS avg, COUNT(*) AS cnt").Scan(&rating).Error; err != nil {
+		return nil, err
+	}
+	st.Rating, st.JumlahUlasan = rating.Avg, rating.Cnt
+	n, err := r.Followers(id)
+	if err != nil {
+		return nil, err
+	}
+	st.Pengikut = n
+	return &st, nil
+}
+
+// Follow makes userID follow a toko; following twice is a no-op.
+func (r *Repository) Follow(tokoID, userID uint) error {
+	f := model.Follower{IDToko: tokoID, IDUser: userID, CreatedAt: time.Now()}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&f).Error
+}
+
+// Unfollow stops userID following a toko; not following is a no-op.
+func (r *Repository) Unfollow(tokoID, userID uint) error {
+	return r.db.Where("id_toko = ? AND id_user = ?", tokoID, userID).Delete(&model.Follower{}).Error
+}
+
+// Followers counts the users following a toko.
+func (r *Repository) Followers(tokoID uint) (int64, error) {
+	var cnt int64
+	err := r.db.Model(&model.Follower{}).Where("id_toko = ?", tokoID).Count(&cnt).Error
+	return cnt, err
+}
+
+// IsFollowing reports whether userID follows a toko.
+func (r *Repository) IsFollowing(tokoID, userID uint) (bool, error) {
+	var cnt int64
+	err := r.db.Model(&model.Follower{}).Where("id_toko = ? AND id_user = ?", tokoID, userID).Count(&cnt).Error
+	return cnt > 0, err
+}
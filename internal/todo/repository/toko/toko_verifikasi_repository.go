@@ -0,0 +1,135 @@
+package toko
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	notifmodel "project-evermos/internal/todo/model/notification"
+	model "project-evermos/internal/todo/model/toko"
+	notifrepo "project-evermos/internal/todo/repository/notification"
+)
+
+var (
+	// ErrVerificationPending is returned when a toko already has a request
+	// waiting for review.
+	ErrVerificationPending = errors.New("verification pending")
+	// ErrVerificationClosed is returned when reviewing a request that is no
+	// longer pending.
+	ErrVerificationClosed = errors.New("verification closed")
+)
+
+// CreateVerification saves a verification request unless the toko already
+// has one pending.
+func (r *Repository) CreateVerification(v *model.Verification) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// lock the toko so two requests cannot both pass the check
+		var ids []uint
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Model(&model.Toko{}).
+			Where("id = ?", v.IDToko).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		var cnt int64
+		if err := tx.Model(&model.Verification{}).
+			Where("id_toko = ? AND status = ?", v.IDToko, model.VerifyPending).Count(&cnt).Error; err != nil {
+			return err
+		}
+		if cnt > 0 {
+			return ErrVerificationPending
+		}
+		return tx.Create(v).Error
+	})
+}
+
+// LatestVerification returns the newest verification request of a toko, or
+// nil.
+func (r *Repository) LatestVerification(tokoID uint) (*model.Verification, error) {
+	var v model.Verification
+	if err := r.db.Where("id_toko = ?", tokoID).Order("id DESC").First(&v).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+// FindVerification returns a verification request with its toko, or nil.
+func (r *Repository) FindVerification(id uint) (*model.Verification, error) {
+	var v model.Verification
+	if err := r.db.Preload("Toko").Where("id = ?", id).First(&v).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+// ListVerifications returns verification requests with their toko by status
+// ("" for all). Pending requests come oldest first, as a queue; others
+// newest first.
+func (r *Repository) ListVerifications(status string, limit, page int) ([]model.Verification, int64, error) {
+	var items []model.Verification
+	var count int64
+	q := r.db.Model(&model.Verification{})
+	order := "id DESC"
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	if status == model.VerifyPending {
+		order = "id ASC"
+	}
+	if err := q.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	offset := (page - 1) * limit
+	if err := q.Preload("Toko").Order(order).Limit(limit).Offset(offset).Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, count, nil
+}
+
+// ReviewVerification approves or rejects a pending request and notifies the
+// store owner; approving grants the verified badge. Returns
+// ErrVerificationClosed when it was reviewed meanwhile.
+func (r *Repository) ReviewVerification(v *model.Verification, adminID uint, approve bool, alasan string) error {
+	now := time.Now()
+	status := model.VerifyRejected
+	if approve {
+		status = model.VerifyApproved
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Verification{}).Where("id = ? AND status = ?", v.ID, model.VerifyPending).
+			Updates(map[string]interface{}{"status": status, "alasan": alasan, "reviewed_by": adminID, "reviewed_at": now, "updated_at": now})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrVerificationClosed
+		}
+		var t model.Toko
+		if err := tx.Where("id = ?", v.IDToko).First(&t).Error; err != nil {
+			return err
+		}
+		n := &notifmodel.Notification{
+			IDUser:   t.IDUser,
+			Tipe:     notifmodel.TypeStoreVerifyRejected,
+			Judul:    "Verifikasi toko ditolak: " + t.NamaToko,
+			Pesan:    fmt.Sprintf("Pengajuan verifikasi %s ditolak: %s. Lengkapi dokumen lalu ajukan kembali.", t.NamaToko, alasan),
+			DataJSON: fmt.Sprintf(`{"verification_id":%d,"toko_id":%d}`, v.ID, t.ID),
+		}
+		if approve {
+			if err := tx.Model(&model.Toko{}).Where("id = ?", t.ID).
+				Updates(map[string]interface{}{"verified": true, "verified_at": now}).Error; err != nil {
+				return err
+			}
+			n.Tipe = notifmodel.TypeStoreVerified
+			n.Judul = "Toko terverifikasi: " + t.NamaToko
+			n.Pesan = fmt.Sprintf("%s sekarang memiliki badge toko terverifikasi.", t.NamaToko)
+		}
+		return notifrepo.Create(tx, n)
+	})
+}
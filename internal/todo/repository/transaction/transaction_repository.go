@@ -3,13 +3,17 @@ package transaction
 import (
     "errors"
     "encoding/json"
+    "strconv"
+    "time"
 
     prodmodel "project-evermos/internal/todo/model/product"
+    prodrepo "project-evermos/internal/todo/repository/product"
     tokomodel "project-evermos/internal/todo/model/toko"
     usermodel "project-evermos/internal/todo/model/users"
     trxmodel "project-evermos/internal/todo/model/transaction"
 
     "gorm.io/gorm"
+    "gorm.io/gorm/clause"
 )
 
 // Repository provides data access for transaction domain.
@@ -31,19 +35,54 @@ func (r *Repository) CreateLogProduk(tx *gorm.DB, lp *trxmodel.LogProduk) error
     return tx.Create(lp).Error
 }
 
-// UpdateProductStock decrements stock safely
-func (r *Repository) UpdateProductStock(tx *gorm.DB, productID uint, dec int) error {
+// UpdateProductStock decrements product (or variant) stock safely and records
+// a sale movement in the stock ledger.
+func (r *Repository) UpdateProductStock(tx *gorm.DB, productID uint, variantID *uint, dec int, trxID, userID uint) error {
     if dec <= 0 { return nil }
-    res := tx.Exec("UPDATE produk SET stok = stok - ? WHERE id = ? AND stok >= ?", dec, productID, dec)
-    if res.Error != nil { return res.Error }
-    if res.RowsAffected != 1 { return errors.New("insufficient stock") }
-    return nil
+    tid, uid := trxID, userID
+    return prodrepo.NewRepository(tx).ApplyStockChange(tx, &prodmodel.StockMovement{
+        IDProduk: productID,
+        IDVarian: variantID,
+        Tipe:     prodmodel.MovementSale,
+        Jumlah:   -dec,
+        IDTrx:    &tid,
+        IDUser:   &uid,
+    })
+}
+
+// RecordOrderPrice adds the price charged for a logged item to the product's
+// price history.
+func (r *Repository) RecordOrderPrice(tx *gorm.DB, lp *trxmodel.LogProduk) error {
+    reseller, _ := strconv.Atoi(lp.HargaReseller)
+    konsumen, _ := strconv.Atoi(lp.HargaKonsumen)
+    return prodrepo.NewRepository(tx).RecordOrderPrice(tx, lp.IDProduk, lp.IDVarian, reseller, konsumen)
+}
+
+// ReserveSaleQuota counts qty units of a flash sale item as sold, but only
+// while its campaign runs and the quota allows it. The check and increment
+// are one UPDATE, so concurrent checkouts cannot oversell the quota.
+func (r *Repository) ReserveSaleQuota(tx *gorm.DB, itemID uint, qty int) (bool, error) {
+    now := time.Now()
+    res := tx.Exec("UPDATE flash_sale_item SET terjual = terjual + ?, updated_at = ? "+
+        "WHERE id = ? AND terjual + ? <= kuota "+
+        "AND id_flash_sale IN (SELECT id FROM flash_sale WHERE mulai <= ? AND selesai > ?)",
+        qty, now, itemID, qty, now, now)
+    if res.Error != nil { return false, res.Error }
+    return res.RowsAffected == 1, nil
 }
 
 // --- fetch helpers ---
 func (r *Repository) GetProductByID(id uint) (*prodmodel.Product, error) {
     var p prodmodel.Product
-    if err := r.DB.Where("id = ?", id).Preload("Photos").First(&p).Error; err != nil {
+    if err := r.DB.Where("id = ?", id).
+        // cover photo first so the order snapshot starts with it
+        Preload("Photos", func(db *gorm.DB) *gorm.DB {
+            return db.Where("id_varian IS NULL").Order("is_primary DESC, urutan ASC, id ASC")
+        }).
+        Preload("Variants.Photos", func(db *gorm.DB) *gorm.DB { return db.Order("urutan ASC, id ASC") }).
+        Preload("Sales", prodrepo.ActiveSales).
+        Preload("Toko").
+        First(&p).Error; err != nil {
         if errors.Is(err, gorm.ErrRecordNotFound) { return nil, nil }
         return nil, err
     }
@@ -91,6 +130,23 @@ func (r *Repository) ListTrxByUser(userID uint, limit, page int) ([]trxmodel.Trx
     return rows, cnt, nil
 }
 
+// ListTrxByToko returns the transactions containing items sold by a toko.
+func (r *Repository) ListTrxByToko(tokoID uint, limit, page int) ([]trxmodel.Trx, int64, error) {
+    var rows []trxmodel.Trx
+    var cnt int64
+    sub := r.DB.Model(&trxmodel.DetailTrx{}).Select("id_trx").Where("id_toko = ?", tokoID)
+    q := r.DB.Model(&trxmodel.Trx{}).Where("id IN (?)", sub)
+    if err := q.Count(&cnt).Error; err != nil { return nil, 0, err }
+    if limit <= 0 { limit = 10 }
+    if limit > 100 { limit = 100 }
+    if page <= 0 { page = 1 }
+    off := (page - 1) * limit
+    if err := q.Order("id DESC").Limit(limit).Offset(off).Find(&rows).Error; err != nil {
+        return nil, 0, err
+    }
+    return rows, cnt, nil
+}
+
 func (r *Repository) GetDetailItems(trxID uint) ([]trxmodel.DetailTrx, error) {
     var items []trxmodel.DetailTrx
     if err := r.DB.Where("id_trx = ?", trxID).Find(&items).Error; err != nil { return nil, err }
@@ -124,6 +180,35 @@ func (r *Repository) GetCategoryByID(id uint) (*prodmodel.CategoryRef, error) {
     return &c, nil
 }
 
+// GetDetailItem returns one item of a transaction, or nil.
+func (r *Repository) GetDetailItem(trxID, detailID uint) (*trxmodel.DetailTrx, error) {
+    var d trxmodel.DetailTrx
+    if err := r.DB.Where("id = ? AND id_trx = ?", detailID, trxID).First(&d).Error; err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) { return nil, nil }
+        return nil, err
+    }
+    return &d, nil
+}
+
+// CreateUlasan saves a rating. Returns false when the item is already rated.
+func (r *Repository) CreateUlasan(u *trxmodel.Ulasan) (bool, error) {
+    res := r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(u)
+    if res.Error != nil { return false, res.Error }
+    return res.RowsAffected == 1, nil
+}
+
+// GetUlasanByDetails returns the ratings of order items keyed by item id.
+func (r *Repository) GetUlasanByDetails(detailIDs []uint) (map[uint]trxmodel.Ulasan, error) {
+    out := map[uint]trxmodel.Ulasan{}
+    if len(detailIDs) == 0 { return out, nil }
+    var rows []trxmodel.Ulasan
+    if err := r.DB.Where("id_detail_trx IN ?", detailIDs).Find(&rows).Error; err != nil { return nil, err }
+    for _, u := range rows {
+        out[u.IDDetailTrx] = u
+    }
+    return out, nil
+}
+
 // Helpers
 func MarshalPhotos(urls []string) string {
     b, _ := json.Marshal(urls)
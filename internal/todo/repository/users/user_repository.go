@@ -3,7 +3,9 @@ package users
 import (
     "strings"
 
+    authmodel "project-evermos/internal/todo/model/auth"
     usermodel "project-evermos/internal/todo/model/users"
+    authrepo "project-evermos/internal/todo/repository/auth"
 
     "gorm.io/gorm"
 )
@@ -37,18 +39,27 @@ func (r *Repository) FindByPhone(notelp string) (*usermodel.User, error) {
     return &u, nil
 }
 
-func (r *Repository) UpdateSelf(u *usermodel.User) error {
-    return r.db.Model(&usermodel.User{}).Where("id = ?", u.ID).
-        Updates(map[string]interface{}{
-            "nama":           u.Nama,
-            "kata_sandi":     u.KataSandi,
-            "notelp":         u.NoTelp,
-            "tanggal lahir":  u.TanggalLahir,
-            "pekerjaan":      u.Pekerjaan,
-            "email":          u.Email,
-            "id_provinsi":    u.IDProvinsi,
-            "id_kota":        u.IDKota,
-        }).Error
+// UpdateSelf saves a user's own profile. A new password (u.KataSandi set)
+// also ends every session of the user except keepSession.
+func (r *Repository) UpdateSelf(u *usermodel.User, keepSession uint) error {
+    fields := map[string]interface{}{
+        "nama":           u.Nama,
+        "notelp":         u.NoTelp,
+        "tanggal lahir":  u.TanggalLahir,
+        "pekerjaan":      u.Pekerjaan,
+        "email":          u.Email,
+        "id_provinsi":    u.IDProvinsi,
+        "id_kota":        u.IDKota,
+    }
+    if u.KataSandi == "" {
+        return r.db.Model(&usermodel.User{}).Where("id = ?", u.ID).Updates(fields).Error
+    }
+    fields["kata_sandi"] = u.KataSandi
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Model(&usermodel.User{}).Where("id = ?", u.ID).Updates(fields).Error; err != nil { return err }
+        _, err := authrepo.RevokeSessions(tx, u.ID, keepSession, authmodel.RevokePassword)
+        return err
+    })
 }
 
 // ----- Alamat queries (ownership enforced via where id_user = ?) -----
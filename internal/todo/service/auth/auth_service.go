@@ -2,15 +2,14 @@ package auth
 
 import (
 	"errors"
-	"time"
 
 	"project-evermos/internal/config"
+	"project-evermos/internal/notify"
 	model "project-evermos/internal/todo/model/auth"
 	storemodel "project-evermos/internal/todo/model/toko"
 	repo "project-evermos/internal/todo/repository/auth"
 	storerepo "project-evermos/internal/todo/repository/toko"
 
-	"github.com/golang-jwt/jwt"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -26,36 +25,30 @@ type Service struct {
 	repo      *repo.Repository
 	storeRepo *storerepo.Repository
 	cfg       *config.Config
+	sender    notify.Sender
 }
 
-// NewService constructs a new Service.
-func NewService(r *repo.Repository, storeR *storerepo.Repository, cfg *config.Config) *Service { return &Service{repo: r, storeRepo: storeR, cfg: cfg} }
+// NewService constructs a new Service; sender delivers password reset codes.
+func NewService(r *repo.Repository, storeR *storerepo.Repository, cfg *config.Config, sender notify.Sender) *Service { return &Service{repo: r, storeRepo: storeR, cfg: cfg, sender: sender} }
 
-// Login authenticates a user by phone and password and returns the user and JWT token.
-func (s *Service) Login(phone, password string) (*model.User, string, error) {
+// Login authenticates a user by phone and password, starts a login session
+// and returns the user with its access and refresh tokens.
+func (s *Service) Login(phone, password string, meta SessionMeta) (*model.User, *Tokens, error) {
 	u, err := s.repo.FindByPhone(phone)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 	if u == nil {
-		return nil, "", ErrInvalidCredentials
+		return nil, nil, ErrInvalidCredentials
 	}
 	if err1 := bcrypt.CompareHashAndPassword([]byte(u.KataSandi), []byte(password)); err1 != nil {
-		return nil, "", ErrInvalidCredentials
+		return nil, nil, ErrInvalidCredentials
 	}
-	// Generate JWT
-	exp := time.Now().Add(time.Duration(s.cfg.JWTExpiryDays) * 24 * time.Hour).Unix()
-	claims := jwt.MapClaims{
-		"email": u.Email,
-		"id":    u.ID,
-		"exp":   exp,
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString([]byte(s.cfg.JWTSecret))
+	tokens, err := s.startSession(u, meta)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
-	return u, signed, nil
+	return u, tokens, nil
 }
 
 // Register creates a new user after validating uniqueness and hashing the password.
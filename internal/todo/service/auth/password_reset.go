@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"project-evermos/internal/notify"
+	model "project-evermos/internal/todo/model/auth"
+	repo "project-evermos/internal/todo/repository/auth"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// ResetMaxAttempts is how many times a reset code may be tried.
+	ResetMaxAttempts = 5
+	// resetCooldown is the minimum time between two codes for a user.
+	resetCooldown = time.Minute
+	resetCodeLen  = 6
+)
+
+var (
+	// ErrResetTarget is returned when neither phone nor email is given
+	ErrResetTarget = errors.New("no_telp atau email wajib diisi")
+	// ErrResetCode is returned for a wrong, expired or used reset code
+	ErrResetCode = errors.New("kode OTP salah atau sudah kedaluwarsa")
+	// ErrResetAttempts is returned once a code was tried too often
+	ErrResetAttempts = errors.New("terlalu banyak percobaan, minta kode OTP baru")
+)
+
+// ResetTarget names the account of a forgotten password by phone or email;
+// the code is sent to the one given.
+type ResetTarget struct {
+	NoTelp string
+	Email  string
+}
+
+// findTarget returns the user of t, or nil.
+func (s *Service) findTarget(t ResetTarget) (*model.User, string, error) {
+	if phone := strings.TrimSpace(t.NoTelp); phone != "" {
+		u, err := s.repo.FindByPhone(phone)
+		return u, notify.ChannelSMS, err
+	}
+	if email := strings.TrimSpace(t.Email); email != "" {
+		u, err := s.repo.FindByEmail(email)
+		return u, notify.ChannelEmail, err
+	}
+	return nil, "", ErrResetTarget
+}
+
+// ForgotPassword sends a one-time reset code to the phone or email of an
+// account. It succeeds silently for unknown accounts and within the
+// cooldown of a previous code, so it does not tell which accounts exist.
+func (s *Service) ForgotPassword(ctx context.Context, t ResetTarget) error {
+	u, kanal, err := s.findTarget(t)
+	if err != nil || u == nil {
+		return err
+	}
+	now := time.Now()
+	last, err := s.repo.LatestReset(u.ID)
+	if err != nil {
+		return err
+	}
+	if last != nil && now.Sub(last.CreatedAt) < resetCooldown {
+		return nil
+	}
+	code, err := randomCode(resetCodeLen)
+	if err != nil {
+		return err
+	}
+	h, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	ttl := time.Duration(s.cfg.PasswordResetTTLMin) * time.Minute
+	p := &model.PasswordReset{IDUser: u.ID, KodeHash: string(h), Kanal: kanal, ExpiresAt: now.Add(ttl), CreatedAt: now}
+	if err := s.repo.CreateReset(p); err != nil {
+		return err
+	}
+	m := notify.Message{Channel: kanal, To: u.NoTelp, Subject: "Reset kata sandi Evermos",
+		Body: fmt.Sprintf("Kode reset kata sandi Evermos: %s. Berlaku %d menit. Jangan berikan kode ini kepada siapa pun.", code, s.cfg.PasswordResetTTLMin)}
+	if kanal == notify.ChannelEmail {
+		m.To = u.Email
+	}
+	return s.sender.Send(ctx, m)
+}
+
+// ResetPassword sets a new password with a reset code. The code is used up
+// and every session of the user is signed out.
+func (s *Service) ResetPassword(t ResetTarget, code, password string) error {
+	u, _, err := s.findTarget(t)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		return ErrResetCode
+	}
+	p, err := s.repo.LatestReset(u.ID)
+	if err != nil {
+		return err
+	}
+	if p == nil || p.UsedAt != nil || !time.Now().Before(p.ExpiresAt) {
+		return ErrResetCode
+	}
+	ok, err := s.repo.CountResetAttempt(p.ID, ResetMaxAttempts)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrResetAttempts
+	}
+	if bcrypt.CompareHashAndPassword([]byte(p.KodeHash), []byte(strings.TrimSpace(code))) != nil {
+		if left := ResetMaxAttempts - p.Percobaan - 1; left > 0 {
+			return fmt.Errorf("%w, sisa %d percobaan", ErrResetCode, left)
+		}
+		return ErrResetAttempts
+	}
+	h, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.ResetPassword(p, string(h)); err != nil {
+		if errors.Is(err, repo.ErrResetUsed) {
+			return ErrResetCode
+		}
+		return err
+	}
+	return nil
+}
+
+// randomCode returns n random digits.
+func randomCode(n int) (string, error) {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		d, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(byte('0' + d.Int64()))
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,266 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	model "project-evermos/internal/todo/model/auth"
+	repo "project-evermos/internal/todo/repository/auth"
+
+	"github.com/golang-jwt/jwt"
+)
+
+var (
+	// ErrInvalidRefresh is returned for an unknown, expired or revoked refresh token
+	ErrInvalidRefresh = errors.New("refresh token tidak valid atau sudah kedaluwarsa")
+	// ErrRefreshReused is returned when a rotated refresh token is used again;
+	// its session is revoked
+	ErrRefreshReused = errors.New("refresh token sudah pernah dipakai, sesi dicabut; silakan login ulang")
+	// ErrSessionNotFound is returned when revoking a session the user does not have
+	ErrSessionNotFound = errors.New("sesi tidak ditemukan")
+)
+
+// activityInterval is how often the last activity of a session is saved.
+const activityInterval = time.Minute
+
+// SessionChecker tells the JWT middlewares whether the session of an access
+// token is still active; implemented by Service.
+type SessionChecker interface {
+	SessionActive(sessionID, userID uint) (bool, error)
+}
+
+// SessionMeta describes the client starting or refreshing a session.
+type SessionMeta struct {
+	UserAgent string
+	IP        string
+}
+
+// Tokens is an access token with the refresh token that renews it.
+type Tokens struct {
+	AccessToken      string    `json:"token"`
+	AccessExpiresAt  time.Time `json:"token_expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+// SessionID reads the session id ("sid") of access token claims, 0 when
+// the token has none.
+func SessionID(claims jwt.MapClaims) uint {
+	switch v := claims["sid"].(type) {
+	case float64:
+		if v > 0 {
+			return uint(v)
+		}
+	case string:
+		if n, err := strconv.ParseUint(v, 10, 64); err == nil {
+			return uint(n)
+		}
+	}
+	return 0
+}
+
+// SessionInfo is an active session as listed to its user.
+type SessionInfo struct {
+	ID           uint      `json:"id"`
+	Perangkat    string    `json:"perangkat"`
+	IP           string    `json:"ip"`
+	UserAgent    string    `json:"user_agent"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastActiveAt time.Time `json:"last_active_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	SesiIni      bool      `json:"sesi_ini"`
+}
+
+// SessionActive reports whether a session of the user is neither revoked
+// nor expired, and records its activity.
+func (s *Service) SessionActive(sessionID, userID uint) (bool, error) {
+	if sessionID == 0 || userID == 0 {
+		return false, nil
+	}
+	ok, err := s.repo.SessionActive(sessionID, userID)
+	if err != nil || !ok {
+		return ok, err
+	}
+	return true, s.repo.TouchSession(sessionID, time.Now(), activityInterval)
+}
+
+// ListSessions lists the active sessions of a user; currentID marks the
+// session of the request.
+func (s *Service) ListSessions(userID, currentID uint) ([]SessionInfo, error) {
+	rows, err := s.repo.ListSessions(userID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SessionInfo, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, SessionInfo{ID: r.ID, Perangkat: deviceName(r.UserAgent), IP: r.IP, UserAgent: r.UserAgent,
+			CreatedAt: r.CreatedAt, LastActiveAt: r.LastActiveAt, ExpiresAt: r.ExpiresAt, SesiIni: r.ID == currentID})
+	}
+	return out, nil
+}
+
+// RevokeSession signs a user out of one of their sessions.
+func (s *Service) RevokeSession(userID, sessionID uint) error {
+	ok, err := s.repo.RevokeUserSession(userID, sessionID, model.RevokeDevice)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// deviceName makes a readable device label such as "Chrome di Windows"
+// from a user agent.
+func deviceName(ua string) string {
+	var browser, os string
+	for _, b := range []struct{ key, name string }{
+		{"Edg/", "Edge"}, {"OPR/", "Opera"}, {"SamsungBrowser/", "Samsung Internet"}, {"Chrome/", "Chrome"},
+		{"CriOS/", "Chrome"}, {"Firefox/", "Firefox"}, {"FxiOS/", "Firefox"}, {"Safari/", "Safari"},
+		{"okhttp/", "Aplikasi Android"}, {"CFNetwork/", "Aplikasi iOS"}, {"PostmanRuntime/", "Postman"}, {"curl/", "curl"},
+	} {
+		if strings.Contains(ua, b.key) {
+			browser = b.name
+			break
+		}
+	}
+	for _, o := range []struct{ key, name string }{
+		{"iPhone", "iPhone"}, {"iPad", "iPad"}, {"Android", "Android"}, {"Windows", "Windows"},
+		{"Macintosh", "macOS"}, {"CrOS", "ChromeOS"}, {"Linux", "Linux"},
+	} {
+		if strings.Contains(ua, o.key) {
+			os = o.name
+			break
+		}
+	}
+	switch {
+	case browser != "" && os != "":
+		return browser + " di " + os
+	case browser != "":
+		return browser
+	case os != "":
+		return os
+	}
+	return "Perangkat tidak dikenal"
+}
+
+// startSession opens a session for u and issues its first tokens.
+func (s *Service) startSession(u *model.User, meta SessionMeta) (*Tokens, error) {
+	now := time.Now()
+	raw, rt := s.newRefreshToken(now)
+	sess := &model.Session{IDUser: u.ID, UserAgent: truncate(meta.UserAgent, 255), IP: truncate(meta.IP, 45),
+		CreatedAt: now, LastActiveAt: now, ExpiresAt: rt.ExpiresAt}
+	if err := s.repo.CreateSession(sess, rt); err != nil {
+		return nil, err
+	}
+	return s.issue(u, sess.ID, raw, rt, now)
+}
+
+// Refresh trades a refresh token for new tokens. The old refresh token is
+// spent: using it again revokes the whole session, since only a copy held
+// by someone else would still present it.
+func (s *Service) Refresh(refreshToken string, meta SessionMeta) (*Tokens, error) {
+	old, err := s.repo.FindRefreshToken(hashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if old == nil || old.Session == nil || !old.Session.Active(now) {
+		return nil, ErrInvalidRefresh
+	}
+	if old.UsedAt != nil {
+		return nil, s.revokeReused(old.IDSesi)
+	}
+	if !now.Before(old.ExpiresAt) {
+		return nil, ErrInvalidRefresh
+	}
+	u, err := s.repo.FindByID(old.Session.IDUser)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrInvalidRefresh
+	}
+	raw, next := s.newRefreshToken(now)
+	err = s.repo.RotateRefreshToken(old, next, truncate(meta.UserAgent, 255), truncate(meta.IP, 45))
+	switch {
+	case errors.Is(err, repo.ErrTokenUsed):
+		return nil, s.revokeReused(old.IDSesi)
+	case errors.Is(err, repo.ErrSessionRevoked):
+		return nil, ErrInvalidRefresh
+	case err != nil:
+		return nil, err
+	}
+	return s.issue(u, old.IDSesi, raw, next, now)
+}
+
+// revokeReused ends a session whose refresh token was reused.
+func (s *Service) revokeReused(sessionID uint) error {
+	if err := s.repo.RevokeSession(sessionID, model.RevokeReuse); err != nil {
+		return err
+	}
+	return ErrRefreshReused
+}
+
+// Logout ends the session of a refresh token.
+func (s *Service) Logout(refreshToken string) error {
+	t, err := s.repo.FindRefreshToken(hashToken(refreshToken))
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return ErrInvalidRefresh
+	}
+	return s.repo.RevokeSession(t.IDSesi, model.RevokeLogout)
+}
+
+// LogoutAll ends every session of a user, on every device, and returns how
+// many were ended.
+func (s *Service) LogoutAll(userID uint) (int64, error) {
+	return s.repo.RevokeUserSessions(userID, model.RevokeLogoutAll)
+}
+
+// newRefreshToken makes a random refresh token; the model keeps its hash.
+func (s *Service) newRefreshToken(now time.Time) (string, *model.RefreshToken) {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	raw := base64.RawURLEncoding.EncodeToString(b)
+	exp := now.Add(time.Duration(s.cfg.JWTExpiryDays) * 24 * time.Hour)
+	return raw, &model.RefreshToken{TokenHash: hashToken(raw), ExpiresAt: exp, CreatedAt: now}
+}
+
+// issue signs an access token of session sid and pairs it with refresh.
+func (s *Service) issue(u *model.User, sid uint, refresh string, rt *model.RefreshToken, now time.Time) (*Tokens, error) {
+	exp := now.Add(time.Duration(s.cfg.AccessTokenTTLMin) * time.Minute)
+	claims := jwt.MapClaims{
+		"email": u.Email,
+		"id":    u.ID,
+		"sid":   sid,
+		"iat":   now.Unix(),
+		"exp":   exp.Unix(),
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.cfg.JWTSecret))
+	if err != nil {
+		return nil, err
+	}
+	return &Tokens{AccessToken: signed, AccessExpiresAt: exp, RefreshToken: refresh, RefreshExpiresAt: rt.ExpiresAt}, nil
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
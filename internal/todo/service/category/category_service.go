@@ -1,9 +1,13 @@
 package category
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
 	"strings"
 
+	model "project-evermos/internal/todo/model/category"
 	repo "project-evermos/internal/todo/repository/category"
 )
 
@@ -29,7 +33,92 @@ func (s *Service) List() ([]CategoryDTO, error) {
 func (s *Service) GetByID(id uint) (*CategoryDTO, error) {
 	c, err := s.r.GetByID(id)
 	if err != nil { return nil, err }
-	return &CategoryDTO{ID: c.ID, NamaCategory: c.NamaCategory}, nil
+	attrs, err := s.r.ListAttributes(c.ID)
+	if err != nil { return nil, err }
+	return &CategoryDTO{ID: c.ID, NamaCategory: c.NamaCategory, Attributes: attributeDTOs(attrs)}, nil
+}
+
+// Attributes returns the product attribute schema of a category.
+func (s *Service) Attributes(id uint) ([]AttributeDTO, error) {
+	if _, err := s.r.GetByID(id); err != nil { return nil, err }
+	attrs, err := s.r.ListAttributes(id)
+	if err != nil { return nil, err }
+	return attributeDTOs(attrs), nil
+}
+
+// maxAttributes caps the attribute schema of one category.
+const maxAttributes = 30
+
+var reAttrKode = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// ReplaceAttributes sets the attribute schema of a category. Attributes are
+// matched by kode, so renaming or reordering keeps product values.
+func (s *Service) ReplaceAttributes(isAdmin bool, id uint, in []AttributeInput) ([]AttributeDTO, error) {
+	if !isAdmin { return nil, ErrForbidden }
+	if _, err := s.r.GetByID(id); err != nil { return nil, err }
+	var errs []string
+	if len(in) > maxAttributes {
+		errs = append(errs, fmt.Sprintf("maksimal %d atribut per kategori", maxAttributes))
+	}
+	seen := map[string]bool{}
+	attrs := make([]model.Attribute, 0, len(in))
+	for i, a := range in {
+		kode := strings.ToLower(strings.TrimSpace(a.Kode))
+		nama := strings.TrimSpace(a.Nama)
+		tipe := strings.ToLower(strings.TrimSpace(a.Tipe))
+		if !reAttrKode.MatchString(kode) {
+			errs = append(errs, fmt.Sprintf("attributes[%d].kode harus huruf kecil, angka atau _ (maks 50)", i))
+		} else if seen[kode] {
+			errs = append(errs, fmt.Sprintf("kode %q duplicated", kode))
+		}
+		seen[kode] = true
+		if nama == "" || len(nama) > 100 {
+			errs = append(errs, fmt.Sprintf("attributes[%d].nama wajib diisi (maks 100)", i))
+		}
+		var nilai []string
+		switch tipe {
+		case model.AttrText, model.AttrNumber, model.AttrBoolean:
+			if len(a.Nilai) > 0 {
+				errs = append(errs, fmt.Sprintf("attributes[%d].nilai hanya untuk tipe enum", i))
+			}
+		case model.AttrEnum:
+			dup := map[string]bool{}
+			for _, v := range a.Nilai {
+				v = strings.TrimSpace(v)
+				if v == "" || dup[strings.ToLower(v)] { continue }
+				if len(v) > 255 {
+					errs = append(errs, fmt.Sprintf("attributes[%d].nilai maks 255 char", i))
+					continue
+				}
+				dup[strings.ToLower(v)] = true
+				nilai = append(nilai, v)
+			}
+			if len(nilai) == 0 {
+				errs = append(errs, fmt.Sprintf("attributes[%d].nilai wajib diisi untuk tipe enum", i))
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("attributes[%d].tipe harus text, number, enum atau boolean", i))
+		}
+		row := model.Attribute{Kode: kode, Nama: nama, Tipe: tipe, Wajib: a.Wajib}
+		if nilai != nil {
+			b, _ := json.Marshal(nilai)
+			row.NilaiJSON = string(b)
+		}
+		attrs = append(attrs, row)
+	}
+	if len(errs) > 0 { return nil, errors.New(strings.Join(errs, "; ")) }
+	if err := s.r.ReplaceAttributes(id, attrs); err != nil { return nil, err }
+	return attributeDTOs(attrs), nil
+}
+
+func attributeDTOs(rows []model.Attribute) []AttributeDTO {
+	out := make([]AttributeDTO, 0, len(rows))
+	for _, a := range rows {
+		d := AttributeDTO{ID: a.ID, Kode: a.Kode, Nama: a.Nama, Tipe: a.Tipe, Nilai: []string{}, Wajib: a.Wajib}
+		if a.NilaiJSON != "" { _ = json.Unmarshal([]byte(a.NilaiJSON), &d.Nilai) }
+		out = append(out, d)
+	}
+	return out
 }
 
 func (s *Service) Create(isAdmin bool, name string) (uint, error) {
@@ -55,6 +144,26 @@ func (s *Service) Delete(isAdmin bool, id uint) error {
 
 // DTO used by handlers
 type CategoryDTO struct {
-	ID           uint   `json:"id"`
-	NamaCategory string `json:"nama_category"`
+	ID           uint           `json:"id"`
+	NamaCategory string         `json:"nama_category"`
+	Attributes   []AttributeDTO `json:"attributes,omitempty"`
+}
+
+// AttributeInput is one attribute of a category schema sent by an admin.
+// Nilai lists the allowed values and is only used by enum attributes.
+type AttributeInput struct {
+	Kode  string   `json:"kode"`
+	Nama  string   `json:"nama"`
+	Tipe  string   `json:"tipe"`
+	Nilai []string `json:"nilai"`
+	Wajib bool     `json:"wajib"`
+}
+
+type AttributeDTO struct {
+	ID    uint     `json:"id"`
+	Kode  string   `json:"kode"`
+	Nama  string   `json:"nama"`
+	Tipe  string   `json:"tipe"`
+	Nilai []string `json:"nilai"`
+	Wajib bool     `json:"wajib"`
 }
\ No newline at end of file
@@ -0,0 +1,263 @@
+package flashsale
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	model "project-evermos/internal/todo/model/flashsale"
+	repo "project-evermos/internal/todo/repository/flashsale"
+)
+
+const (
+	// MaxDuration caps the window of one campaign.
+	MaxDuration = 7 * 24 * time.Hour
+	// MaxItems caps the products/variants of one campaign.
+	MaxItems = 200
+)
+
+var (
+	ErrNotFound = errors.New("not_found")
+	// ErrStarted is returned when editing or deleting a running or ended campaign
+	ErrStarted = errors.New("flash sale sudah dimulai, tidak dapat diubah")
+	// ErrEnded is returned when stopping a campaign that is not running
+	ErrEnded = errors.New("flash sale sudah berakhir")
+	// ErrStatus is returned for an unknown status filter
+	ErrStatus = errors.New("status harus upcoming, active, ended atau all")
+)
+
+// Service manages flash sale campaigns. Checkout applies their prices and
+// reserves their quota (see the transaction service).
+type Service struct {
+	repo *repo.Repository
+}
+
+func NewService(r *repo.Repository) *Service { return &Service{repo: r} }
+
+// Input is a campaign definition sent by an admin.
+type Input struct {
+	Nama    string      `json:"nama"`
+	Mulai   time.Time   `json:"mulai"`
+	Selesai time.Time   `json:"selesai"`
+	Items   []ItemInput `json:"items"`
+}
+
+// ItemInput is the sale price and quota of a product, or of one variant of
+// a product with variants.
+type ItemInput struct {
+	ProductID uint `json:"product_id"`
+	VariantID uint `json:"variant_id"`
+	HargaSale int  `json:"harga_sale"`
+	Kuota     int  `json:"kuota"`
+}
+
+type Page struct {
+	Items []model.FlashSale
+	Total int64
+	Limit int
+	Page  int
+}
+
+// Create validates and saves a new campaign.
+func (s *Service) Create(adminID uint, in Input) (*model.FlashSale, error) {
+	f, err := s.build(0, in)
+	if err != nil {
+		return nil, err
+	}
+	f.CreatedBy = &adminID
+	if err := s.repo.Create(f); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(f.ID)
+}
+
+// Update replaces the definition of a campaign that has not started yet.
+func (s *Service) Update(id uint, in Input) (*model.FlashSale, error) {
+	cur, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if cur == nil {
+		return nil, ErrNotFound
+	}
+	if cur.Status(time.Now()) != model.StatusUpcoming {
+		return nil, ErrStarted
+	}
+	f, err := s.build(id, in)
+	if err != nil {
+		return nil, err
+	}
+	f.ID, f.CreatedBy, f.CreatedAt = id, cur.CreatedBy, cur.CreatedAt
+	if err := s.repo.Update(f); err != nil {
+		if errors.Is(err, repo.ErrStarted) {
+			return nil, ErrStarted
+		}
+		return nil, err
+	}
+	return s.repo.GetByID(id)
+}
+
+// Delete removes an upcoming campaign, or ends a running one now so sold
+// units stay recorded. Ended campaigns cannot be deleted.
+func (s *Service) Delete(id uint) error {
+	cur, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if cur == nil {
+		return ErrNotFound
+	}
+	switch cur.Status(time.Now()) {
+	case model.StatusUpcoming:
+		err := s.repo.Delete(id)
+		if !errors.Is(err, repo.ErrStarted) {
+			return err
+		}
+		// started meanwhile; end it instead
+	case model.StatusEnded:
+		return ErrEnded
+	}
+	ok, err := s.repo.End(id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrEnded
+	}
+	return nil
+}
+
+// GetByID returns a campaign with its items.
+func (s *Service) GetByID(id uint) (*model.FlashSale, error) {
+	f, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return nil, ErrNotFound
+	}
+	return f, nil
+}
+
+// List returns campaigns by state; "all" lists every campaign.
+func (s *Service) List(status string, limit, page int) (*Page, error) {
+	switch status {
+	case "all":
+		status = ""
+	case model.StatusUpcoming, model.StatusActive, model.StatusEnded:
+	default:
+		return nil, ErrStatus
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if page <= 0 {
+		page = 1
+	}
+	items, total, err := s.repo.List(status, time.Now(), limit, page)
+	if err != nil {
+		return nil, err
+	}
+	return &Page{Items: items, Total: total, Limit: limit, Page: page}, nil
+}
+
+// build validates a campaign definition and converts it to a model. A
+// product or variant may only be in one campaign at a time, and the sale
+// price must be below its current consumer price.
+func (s *Service) build(selfID uint, in Input) (*model.FlashSale, error) {
+	var errs []string
+	nama := strings.TrimSpace(in.Nama)
+	if len(nama) < 3 || len(nama) > 100 {
+		errs = append(errs, "nama harus 3-100 char")
+	}
+	now := time.Now()
+	switch {
+	case in.Mulai.IsZero() || in.Selesai.IsZero():
+		errs = append(errs, "mulai dan selesai wajib diisi (RFC3339)")
+	case !in.Selesai.After(in.Mulai):
+		errs = append(errs, "selesai harus setelah mulai")
+	case !in.Selesai.After(now):
+		errs = append(errs, "selesai harus di masa depan")
+	case in.Selesai.Sub(in.Mulai) > MaxDuration:
+		errs = append(errs, fmt.Sprintf("durasi maksimal %d hari", int(MaxDuration.Hours()/24)))
+	}
+	if len(in.Items) == 0 || len(in.Items) > MaxItems {
+		errs = append(errs, fmt.Sprintf("items harus 1-%d", MaxItems))
+	}
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "; "))
+	}
+
+	f := &model.FlashSale{Nama: nama, Mulai: in.Mulai, Selesai: in.Selesai, CreatedAt: now, UpdatedAt: now}
+	seen := map[string]bool{}
+	for i, it := range in.Items {
+		p, err := s.repo.GetProduct(it.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if p == nil {
+			errs = append(errs, fmt.Sprintf("items[%d].product_id tidak ditemukan", i))
+			continue
+		}
+		normal, _ := strconv.Atoi(p.HargaKonsumen)
+		var variantID *uint
+		switch {
+		case len(p.Variants) > 0 && it.VariantID == 0:
+			errs = append(errs, fmt.Sprintf("items[%d].variant_id wajib untuk produk bervarian", i))
+			continue
+		case len(p.Variants) == 0 && it.VariantID != 0:
+			errs = append(errs, fmt.Sprintf("items[%d].variant_id tidak valid", i))
+			continue
+		case it.VariantID != 0:
+			found := false
+			for _, v := range p.Variants {
+				if v.ID == it.VariantID {
+					found, normal = true, v.HargaKonsumen
+					break
+				}
+			}
+			if !found {
+				errs = append(errs, fmt.Sprintf("items[%d].variant_id tidak valid", i))
+				continue
+			}
+			vid := it.VariantID
+			variantID = &vid
+		}
+		key := fmt.Sprintf("%d/%d", it.ProductID, it.VariantID)
+		if seen[key] {
+			errs = append(errs, fmt.Sprintf("items[%d] duplikat produk/varian", i))
+			continue
+		}
+		seen[key] = true
+		if it.HargaSale < 1 || it.HargaSale >= normal {
+			errs = append(errs, fmt.Sprintf("items[%d].harga_sale harus 1-%d (di bawah harga konsumen)", i, normal-1))
+		}
+		if it.Kuota < 1 {
+			errs = append(errs, fmt.Sprintf("items[%d].kuota minimal 1", i))
+		}
+		overlap, err := s.repo.Overlaps(it.ProductID, variantID, in.Mulai, in.Selesai, selfID)
+		if err != nil {
+			return nil, err
+		}
+		if overlap {
+			errs = append(errs, fmt.Sprintf("items[%d] sudah ada di flash sale lain pada waktu yang sama", i))
+		}
+		f.Items = append(f.Items, model.Item{
+			IDProduk:  it.ProductID,
+			IDVarian:  variantID,
+			HargaSale: it.HargaSale,
+			Kuota:     it.Kuota,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "; "))
+	}
+	return f, nil
+}
@@ -0,0 +1,139 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"project-evermos/internal/storage"
+	model "project-evermos/internal/todo/model/job"
+	repo "project-evermos/internal/todo/repository/job"
+)
+
+// reportTTL is how long a signed error report URL stays valid.
+const reportTTL = time.Hour
+
+// Service runs background jobs in this process and records their progress
+// in the job table, so any replica can report on them.
+type Service struct {
+	repo  *repo.Repository
+	store storage.Storage
+}
+
+func NewService(r *repo.Repository, store storage.Storage) *Service {
+	return &Service{repo: r, store: store}
+}
+
+// Result is what a job run returns. Report, when non-empty, is stored as
+// the job's downloadable error report.
+type Result struct {
+	Report     []byte
+	ReportName string // e.g. errors.csv
+}
+
+// RunFunc does the work of a job, reporting each processed row to t.
+type RunFunc func(ctx context.Context, t *Tracker) (Result, error)
+
+// Tracker counts processed rows and persists them at most once per second.
+type Tracker struct {
+	svc       *Service
+	id        uint
+	mu        sync.Mutex
+	processed int
+	succeeded int
+	failed    int
+	flushed   time.Time
+}
+
+// Row records one processed row.
+func (t *Tracker) Row(ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.processed++
+	if ok {
+		t.succeeded++
+	} else {
+		t.failed++
+	}
+	if time.Since(t.flushed) >= time.Second {
+		t.flushed = time.Now()
+		if err := t.svc.repo.UpdateProgress(t.id, t.processed, t.succeeded, t.failed); err != nil {
+			log.Printf("job %d: progress: %v", t.id, err)
+		}
+	}
+}
+
+// Start saves j as queued and runs fn in the background.
+func (s *Service) Start(j *model.Job, fn RunFunc) error {
+	now := time.Now()
+	j.Status = model.StatusQueued
+	j.CreatedAt, j.UpdatedAt = now, now
+	if err := s.repo.Create(j); err != nil {
+		return err
+	}
+	go s.run(*j, fn)
+	return nil
+}
+
+func (s *Service) run(j model.Job, fn RunFunc) {
+	ctx := context.Background()
+	t := &Tracker{svc: s, id: j.ID, flushed: time.Now()}
+	if err := s.repo.SetStatus(j.ID, model.StatusRunning); err != nil {
+		log.Printf("job %d: %v", j.ID, err)
+	}
+
+	res, err := func() (res Result, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return fn(ctx, t)
+	}()
+
+	t.mu.Lock()
+	j.Processed, j.Succeeded, j.Failed = t.processed, t.succeeded, t.failed
+	t.mu.Unlock()
+	j.Status = model.StatusDone
+	if err != nil {
+		j.Status = model.StatusFailed
+		j.ErrorMessage = truncate(err.Error(), 500)
+	}
+	if len(res.Report) > 0 {
+		key := fmt.Sprintf("%sjobs/%d/%s", storage.PrivatePrefix, j.ID, res.ReportName)
+		if perr := s.store.Put(ctx, key, bytes.NewReader(res.Report), int64(len(res.Report)), "text/csv"); perr != nil {
+			log.Printf("job %d: store report: %v", j.ID, perr)
+		} else {
+			j.ReportKey = key
+		}
+	}
+	now := time.Now()
+	j.UpdatedAt, j.FinishedAt = now, &now
+	if err := s.repo.Finish(&j); err != nil {
+		log.Printf("job %d: finish: %v", j.ID, err)
+	}
+}
+
+// Get returns a job, or nil when it does not exist.
+func (s *Service) Get(id uint) (*model.Job, error) {
+	return s.repo.GetByID(id)
+}
+
+// ReportURL returns a short-lived download URL of the job's error report,
+// or "" when there is none.
+func (s *Service) ReportURL(j *model.Job) (string, error) {
+	if j.ReportKey == "" {
+		return "", nil
+	}
+	return s.store.SignedURL(j.ReportKey, reportTTL)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,59 @@
+package notification
+
+import (
+	"errors"
+
+	model "project-evermos/internal/todo/model/notification"
+	repo "project-evermos/internal/todo/repository/notification"
+)
+
+var ErrNotFound = errors.New("not_found")
+
+// Service exposes a user's notifications. Notifications are created by the
+// repositories of the features that raise them.
+type Service struct {
+	repo *repo.Repository
+}
+
+func NewService(r *repo.Repository) *Service { return &Service{repo: r} }
+
+type Page struct {
+	Items  []model.Notification
+	Total  int64
+	Unread int64
+	Limit  int
+	Page   int
+}
+
+// List returns a user's notifications, newest first.
+func (s *Service) List(userID uint, unreadOnly bool, limit, page int) (*Page, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if page <= 0 {
+		page = 1
+	}
+	items, total, unread, err := s.repo.List(userID, unreadOnly, limit, page)
+	if err != nil {
+		return nil, err
+	}
+	return &Page{Items: items, Total: total, Unread: unread, Limit: limit, Page: page}, nil
+}
+
+// MarkRead marks one notification, or all when id is 0, as read.
+func (s *Service) MarkRead(userID, id uint) error {
+	if id > 0 {
+		ok, err := s.repo.Exists(userID, id)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrNotFound
+		}
+	}
+	_, err := s.repo.MarkRead(userID, id)
+	return err
+}
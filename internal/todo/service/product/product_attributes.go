@@ -0,0 +1,134 @@
+package product
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "math"
+    "sort"
+    "strconv"
+    "strings"
+
+    catmodel "project-evermos/internal/todo/model/category"
+    prodmodel "project-evermos/internal/todo/model/product"
+    prodrepo "project-evermos/internal/todo/repository/product"
+)
+
+// buildAttributes validates attribute values (kode -> nilai) against the
+// schema of a category and converts them to models. Empty values count as
+// not set; every required attribute must be set. With dropUnknown, values
+// of attributes the category does not define are dropped instead of
+// rejected, e.g. when a product moves to another category.
+func (s *Service) buildAttributes(categoryID uint, in map[string]string, dropUnknown bool) (*prodrepo.AttributeSet, error) {
+    defs, err := s.repo.CategoryAttributes(categoryID)
+    if err != nil { return nil, err }
+    byKode := make(map[string]prodmodel.AttributeRef, len(defs))
+    for _, d := range defs { byKode[d.Kode] = d }
+
+    var errs []string
+    set := &prodrepo.AttributeSet{}
+    got := map[string]bool{}
+    keys := make([]string, 0, len(in))
+    for k := range in { keys = append(keys, k) }
+    sort.Strings(keys)
+    for _, k := range keys {
+        kode := strings.ToLower(strings.TrimSpace(k))
+        def, ok := byKode[kode]
+        if !ok {
+            if !dropUnknown { errs = append(errs, fmt.Sprintf("atribut %q tidak ada di kategori ini", k)) }
+            continue
+        }
+        v := strings.TrimSpace(in[k])
+        if v == "" || got[kode] { continue }
+        nilai, err := normalizeAttribute(def, v)
+        if err != nil {
+            errs = append(errs, fmt.Sprintf("atribut %s: %v", kode, err))
+            continue
+        }
+        got[kode] = true
+        set.Values = append(set.Values, prodmodel.AttributeValue{IDAtribut: def.ID, Nilai: nilai})
+    }
+    for _, d := range defs {
+        if d.Wajib && !got[d.Kode] {
+            errs = append(errs, fmt.Sprintf("atribut %s (%s) wajib diisi", d.Kode, d.Nama))
+        }
+    }
+    if len(errs) > 0 { return nil, errors.New(strings.Join(errs, "; ")) }
+    return set, nil
+}
+
+// normalizeAttribute checks v against the attribute type and returns the
+// stored form: numbers without trailing zeros, true/false, or the enum
+// value as spelled in the schema.
+func normalizeAttribute(def prodmodel.AttributeRef, v string) (string, error) {
+    switch def.Tipe {
+    case catmodel.AttrNumber:
+        f, err := strconv.ParseFloat(v, 64)
+        if err != nil || math.IsNaN(f) || math.IsInf(f, 0) { return "", errors.New("harus angka") }
+        return strconv.FormatFloat(f, 'f', -1, 64), nil
+    case catmodel.AttrBoolean:
+        b, err := strconv.ParseBool(v)
+        if err != nil { return "", errors.New("harus true atau false") }
+        return strconv.FormatBool(b), nil
+    case catmodel.AttrEnum:
+        var allowed []string
+        _ = json.Unmarshal([]byte(def.NilaiJSON), &allowed)
+        for _, a := range allowed {
+            if strings.EqualFold(a, v) { return a, nil }
+        }
+        return "", fmt.Errorf("nilai %q tidak diizinkan (pilihan: %s)", v, strings.Join(allowed, ", "))
+    }
+    if len(v) > 255 { return "", errors.New("maks 255 char") }
+    return v, nil
+}
+
+// AttributeMap returns the attribute values of a product by kode.
+func AttributeMap(p *prodmodel.Product) map[string]string {
+    out := make(map[string]string, len(p.Attributes))
+    for _, a := range p.Attributes {
+        if a.Atribut != nil { out[a.Atribut.Kode] = a.Nilai }
+    }
+    return out
+}
+
+// SortedAttributes returns the attribute values of a product in schema order.
+func SortedAttributes(p *prodmodel.Product) []prodmodel.AttributeValue {
+    out := make([]prodmodel.AttributeValue, 0, len(p.Attributes))
+    for _, a := range p.Attributes {
+        if a.Atribut != nil { out = append(out, a) }
+    }
+    sort.SliceStable(out, func(i, j int) bool { return out[i].Atribut.Urutan < out[j].Atribut.Urutan })
+    return out
+}
+
+// sameAttributes reports whether set holds exactly the values of p.
+func sameAttributes(p *prodmodel.Product, set *prodrepo.AttributeSet) bool {
+    if len(set.Values) != len(p.Attributes) { return false }
+    cur := make(map[uint]string, len(p.Attributes))
+    for _, a := range p.Attributes { cur[a.IDAtribut] = a.Nilai }
+    for _, v := range set.Values {
+        if n, ok := cur[v.IDAtribut]; !ok || n != v.Nilai { return false }
+    }
+    return true
+}
+
+// formatAttributes renders attribute values as "kode=nilai|kode=nilai" for CSV.
+func formatAttributes(p *prodmodel.Product) string {
+    attrs := SortedAttributes(p)
+    parts := make([]string, 0, len(attrs))
+    for _, a := range attrs { parts = append(parts, a.Atribut.Kode+"="+a.Nilai) }
+    return strings.Join(parts, "|")
+}
+
+// parseAttributes reads the "kode=nilai|kode=nilai" form of the CSV column.
+func parseAttributes(v string) (map[string]string, error) {
+    out := map[string]string{}
+    for _, part := range strings.Split(v, "|") {
+        part = strings.TrimSpace(part)
+        if part == "" { continue }
+        i := strings.Index(part, "=")
+        if i <= 0 { return nil, fmt.Errorf("attributes %q harus berformat kode=nilai", part) }
+        out[strings.TrimSpace(part[:i])] = strings.TrimSpace(part[i+1:])
+    }
+    return out, nil
+}
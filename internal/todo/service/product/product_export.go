@@ -0,0 +1,201 @@
+package product
+
+import (
+    "bufio"
+    "context"
+    "encoding/csv"
+    "encoding/json"
+    "errors"
+    "io"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+
+    prodmodel "project-evermos/internal/todo/model/product"
+)
+
+// Export formats
+const (
+    ExportCSV  = "csv"
+    ExportJSON = "json"
+)
+
+// exportBatch is how many products are loaded per query while exporting.
+const exportBatch = 200
+
+// exportColumns are the CSV export headers: the import columns plus id,
+// so an edited export can be imported back. Extra columns are ignored by
+// the import.
+var exportColumns = []string{"id", "nama_produk", "category", "harga_reseller", "harga_konsumen", "stok", "stok_minimum", "berat", "panjang", "lebar", "tinggi", "deskripsi", "photo_urls", "attributes", "slug", "status", "created_at", "updated_at"}
+
+// ErrExportFormat is returned for an unknown export format.
+var ErrExportFormat = errors.New("format harus csv atau json")
+
+// ExportProduct is one product of a JSON export.
+type ExportProduct struct {
+    ID            uint            `json:"id"`
+    NamaProduk    string          `json:"nama_produk"`
+    Slug          string          `json:"slug"`
+    Status        string          `json:"status"`
+    CategoryID    uint            `json:"category_id"`
+    Category      string          `json:"category"`
+    HargaReseller int             `json:"harga_reseller"`
+    HargaKonsumen int             `json:"harga_konsumen"`
+    Stok          int             `json:"stok"`
+    StokMinimum   int             `json:"stok_minimum"`
+    Berat         int             `json:"berat"`
+    Panjang       int             `json:"panjang"`
+    Lebar         int             `json:"lebar"`
+    Tinggi        int             `json:"tinggi"`
+    Deskripsi     string          `json:"deskripsi"`
+    PhotoURLs     []string        `json:"photo_urls"`
+    Attributes    map[string]string `json:"attributes"`
+    Options       []OptionInput   `json:"options"`
+    Variants      []ExportVariant `json:"variants"`
+    CreatedAt     time.Time       `json:"created_at"`
+    UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// ExportVariant is one variant of an exported product.
+type ExportVariant struct {
+    ID            uint              `json:"id"`
+    SKU           string            `json:"sku"`
+    Nama          string            `json:"nama"`
+    Opsi          map[string]string `json:"opsi"`
+    HargaReseller int               `json:"harga_reseller"`
+    HargaKonsumen int               `json:"harga_konsumen"`
+    Stok          int               `json:"stok"`
+    Berat         *int              `json:"berat"`
+    Panjang       *int              `json:"panjang"`
+    Lebar         *int              `json:"lebar"`
+    Tinggi        *int              `json:"tinggi"`
+    PhotoURLs     []string          `json:"photo_urls"`
+}
+
+// Export writes the whole catalog of a toko to w as CSV or JSON, flushing
+// after every batch. The CSV holds one row per product (stok of a product
+// with variants is the variant total); variants are only in the JSON.
+func (s *Service) Export(ctx context.Context, tokoID uint, format string, w io.Writer) error {
+    bw := bufio.NewWriter(w)
+    switch format {
+    case ExportCSV:
+        cw := csv.NewWriter(bw)
+        if err := cw.Write(exportColumns); err != nil { return err }
+        err := s.repo.EachByToko(tokoID, exportBatch, func(items []prodmodel.Product) error {
+            if err := ctx.Err(); err != nil { return err }
+            for _, p := range items {
+                if err := cw.Write(exportRow(p)); err != nil { return err }
+            }
+            cw.Flush()
+            if err := cw.Error(); err != nil { return err }
+            return bw.Flush()
+        })
+        if err != nil { return err }
+        cw.Flush()
+        if err := cw.Error(); err != nil { return err }
+    case ExportJSON:
+        if _, err := bw.WriteString("["); err != nil { return err }
+        first := true
+        err := s.repo.EachByToko(tokoID, exportBatch, func(items []prodmodel.Product) error {
+            if err := ctx.Err(); err != nil { return err }
+            for _, p := range items {
+                b, err := json.Marshal(exportProduct(p))
+                if err != nil { return err }
+                if !first { bw.WriteString(",") }
+                first = false
+                if _, err := bw.Write(b); err != nil { return err }
+            }
+            return bw.Flush()
+        })
+        if err != nil { return err }
+        if _, err := bw.WriteString("]\n"); err != nil { return err }
+    default:
+        return ErrExportFormat
+    }
+    return bw.Flush()
+}
+
+func exportRow(p prodmodel.Product) []string {
+    category := ""
+    if p.Category != nil { category = p.Category.NamaCategory }
+    return []string{
+        strconv.FormatUint(uint64(p.ID), 10),
+        p.NamaProduk,
+        category,
+        p.HargaReseller,
+        p.HargaKonsumen,
+        strconv.Itoa(p.Stok),
+        strconv.Itoa(p.StokMinimum),
+        strconv.Itoa(p.Berat),
+        strconv.Itoa(p.Panjang),
+        strconv.Itoa(p.Lebar),
+        strconv.Itoa(p.Tinggi),
+        p.Deskripsi,
+        strings.Join(photoURLs(p.Photos), "|"),
+        formatAttributes(&p),
+        p.Slug,
+        p.Status,
+        p.CreatedAt.Format(time.RFC3339),
+        p.UpdatedAt.Format(time.RFC3339),
+    }
+}
+
+func exportProduct(p prodmodel.Product) ExportProduct {
+    out := ExportProduct{
+        ID:          p.ID,
+        NamaProduk:  p.NamaProduk,
+        Slug:        p.Slug,
+        Status:      p.Status,
+        CategoryID:  p.IDCategory,
+        Stok:        p.Stok,
+        StokMinimum: p.StokMinimum,
+        Berat:       p.Berat,
+        Panjang:     p.Panjang,
+        Lebar:       p.Lebar,
+        Tinggi:      p.Tinggi,
+        Deskripsi:   p.Deskripsi,
+        PhotoURLs:   photoURLs(p.Photos),
+        Attributes:  AttributeMap(&p),
+        Options:     []OptionInput{},
+        Variants:    []ExportVariant{},
+        CreatedAt:   p.CreatedAt,
+        UpdatedAt:   p.UpdatedAt,
+    }
+    if p.Category != nil { out.Category = p.Category.NamaCategory }
+    out.HargaReseller, _ = strconv.Atoi(p.HargaReseller)
+    out.HargaKonsumen, _ = strconv.Atoi(p.HargaKonsumen)
+    for _, o := range p.Options {
+        oi := OptionInput{Nama: o.NamaOpsi}
+        _ = json.Unmarshal([]byte(o.NilaiJSON), &oi.Nilai)
+        out.Options = append(out.Options, oi)
+    }
+    for _, v := range p.Variants {
+        ev := ExportVariant{
+            ID:            v.ID,
+            SKU:           v.SKU,
+            Nama:          v.NamaVarian,
+            HargaReseller: v.HargaReseller,
+            HargaKonsumen: v.HargaKonsumen,
+            Stok:          v.Stok,
+            Berat:         v.Berat,
+            Panjang:       v.Panjang,
+            Lebar:         v.Lebar,
+            Tinggi:        v.Tinggi,
+            PhotoURLs:     photoURLs(v.Photos),
+        }
+        _ = json.Unmarshal([]byte(v.OpsiJSON), &ev.Opsi)
+        out.Variants = append(out.Variants, ev)
+    }
+    return out
+}
+
+// photoURLs lists the normalized image URLs of photos in display order,
+// primary photo first, so a re-import keeps the same cover photo.
+func photoURLs(photos []prodmodel.Photo) []string {
+    sorted := append([]prodmodel.Photo(nil), photos...)
+    sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].IsPrimary && !sorted[j].IsPrimary })
+    urls := make([]string, 0, len(sorted))
+    for _, ph := range sorted { urls = append(urls, ph.URL) }
+    return urls
+}
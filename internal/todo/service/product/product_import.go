@@ -0,0 +1,370 @@
+package product
+
+import (
+    "bytes"
+    "context"
+    "encoding/csv"
+    "errors"
+    "fmt"
+    "io"
+    "log"
+    "path"
+    "strconv"
+    "strings"
+    "time"
+
+    "project-evermos/internal/media"
+    prodmodel "project-evermos/internal/todo/model/product"
+)
+
+const (
+    // MaxImportRows caps the data rows of one import file.
+    MaxImportRows = 5000
+    // maxImportPhotos caps the photo URLs of one row.
+    maxImportPhotos = 10
+)
+
+// importColumns are the recognised CSV headers. category may hold a
+// category name or id; a row with an id updates that product of the toko.
+// attributes holds "kode=nilai" pairs separated by "|". berat is in grams,
+// panjang/lebar/tinggi in cm.
+var importColumns = []string{"id", "nama_produk", "category", "harga_reseller", "harga_konsumen", "stok", "stok_minimum", "berat", "panjang", "lebar", "tinggi", "deskripsi", "photo_urls", "attributes"}
+
+var importRequired = []string{"nama_produk", "category", "harga_reseller", "harga_konsumen", "stok"}
+
+// ImportRow is one data line of an import file.
+type ImportRow struct {
+    Line   int // 1-based line in the file, header is line 1
+    Fields map[string]string
+}
+
+// ImportParams is a parsed import file for a toko.
+type ImportParams struct {
+    UserID uint
+    TokoID uint
+    DryRun bool
+    Rows   []ImportRow
+}
+
+// ImportTracker receives the outcome of every processed row.
+type ImportTracker interface {
+    Row(ok bool)
+}
+
+// ImportError describes why a row was not imported.
+type ImportError struct {
+    Line       int
+    NamaProduk string
+    Errors     string
+}
+
+// ParseImportCSV reads the header and rows of an import file. Header
+// problems fail the whole file; row problems are reported by Import.
+func ParseImportCSV(r io.Reader) ([]ImportRow, error) {
+    cr := csv.NewReader(r)
+    cr.FieldsPerRecord = -1
+    cr.TrimLeadingSpace = true
+    header, err := cr.Read()
+    if err == io.EOF { return nil, errors.New("file CSV kosong") }
+    if err != nil { return nil, fmt.Errorf("CSV tidak valid: %w", err) }
+
+    cols := make([]string, len(header))
+    seen := map[string]bool{}
+    for i, h := range header {
+        h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+        if h == "category_id" { h = "category" }
+        cols[i] = h
+        seen[h] = true
+    }
+    var missing []string
+    for _, c := range importRequired {
+        if !seen[c] { missing = append(missing, c) }
+    }
+    if len(missing) > 0 {
+        return nil, fmt.Errorf("kolom wajib tidak ada: %s (kolom: %s)", strings.Join(missing, ", "), strings.Join(importColumns, ", "))
+    }
+
+    var rows []ImportRow
+    for {
+        rec, err := cr.Read()
+        if err == io.EOF { break }
+        if err != nil { return nil, fmt.Errorf("CSV tidak valid: %w", err) }
+        line, _ := cr.FieldPos(0)
+        if len(rows) >= MaxImportRows {
+            return nil, fmt.Errorf("maksimal %d baris per import", MaxImportRows)
+        }
+        f := make(map[string]string, len(cols))
+        empty := true
+        for i, v := range rec {
+            if i >= len(cols) || cols[i] == "" { continue }
+            f[cols[i]] = strings.TrimSpace(v)
+            if f[cols[i]] != "" { empty = false }
+        }
+        if empty { continue }
+        rows = append(rows, ImportRow{Line: line, Fields: f})
+    }
+    if len(rows) == 0 { return nil, errors.New("file CSV tidak berisi data") }
+    return rows, nil
+}
+
+// Import validates every row with the same rules as Create and, unless
+// DryRun, creates the valid ones or updates the product named by id.
+// Photos are downloaded from the row's photo_urls. Returns the rows that
+// failed.
+func (s *Service) Import(ctx context.Context, p ImportParams, t ImportTracker) ([]ImportError, error) {
+    categories := map[string]uint{}
+    var failed []ImportError
+    for _, row := range p.Rows {
+        if err := ctx.Err(); err != nil { return failed, err }
+        err := s.importRow(ctx, p, row, categories)
+        if err != nil {
+            failed = append(failed, ImportError{Line: row.Line, NamaProduk: row.Fields["nama_produk"], Errors: err.Error()})
+        }
+        t.Row(err == nil)
+    }
+    return failed, nil
+}
+
+func (s *Service) importRow(ctx context.Context, p ImportParams, row ImportRow, categories map[string]uint) error {
+    f := row.Fields
+    var errs []string
+    // empty numbers are treated like a missing form field in POST /product
+    num := func(col string) int {
+        v := f[col]
+        if v == "" { return -1 }
+        n, err := strconv.Atoi(v)
+        if err != nil {
+            errs = append(errs, col+" must be a number")
+            return 0
+        }
+        return n
+    }
+    cp := CreateParams{
+        UserID:        p.UserID,
+        TokoID:        p.TokoID,
+        NamaProduk:    f["nama_produk"],
+        HargaReseller: num("harga_reseller"),
+        HargaKonsumen: num("harga_konsumen"),
+        Stok:          num("stok"),
+        StokMinimum:   num("stok_minimum"),
+        Berat:         num("berat"),
+        Panjang:       num("panjang"),
+        Lebar:         num("lebar"),
+        Tinggi:        num("tinggi"),
+        Deskripsi:     f["deskripsi"],
+    }
+    // an empty stok_minimum keeps the default (create) or current (update) value
+    keepMinimum := cp.StokMinimum == -1
+    if keepMinimum { cp.StokMinimum = 0 }
+    catID, err := s.importCategory(f["category"], categories)
+    if err != nil { return err }
+    cp.CategoryID = catID
+    if catID == 0 && f["category"] != "" {
+        errs = append(errs, fmt.Sprintf("category %q tidak ditemukan", f["category"]))
+    }
+    // an empty attributes column keeps the current attributes on update
+    if v := f["attributes"]; v != "" {
+        attrs, err := parseAttributes(v)
+        if err != nil {
+            errs = append(errs, err.Error())
+        }
+        cp.Attributes = attrs
+    }
+    var existing *prodmodel.Product
+    if v := f["id"]; v != "" {
+        id, err := strconv.ParseUint(v, 10, 64)
+        if err != nil || id == 0 { return fmt.Errorf("id %q tidak valid", v) }
+        existing, err = s.repo.GetByID(uint(id))
+        if err != nil { return err }
+        if existing == nil || existing.IDToko != p.TokoID {
+            return fmt.Errorf("produk id %d tidak ditemukan di toko", id)
+        }
+        // empty weight/size columns keep the current values on update
+        keep := func(v *int, cur int) { if *v == -1 { *v = cur } }
+        keep(&cp.Berat, existing.Berat)
+        keep(&cp.Panjang, existing.Panjang)
+        keep(&cp.Lebar, existing.Lebar)
+        keep(&cp.Tinggi, existing.Tinggi)
+    }
+    urls := splitPhotoURLs(f["photo_urls"])
+    if existing != nil {
+        // photos already on the product (e.g. from an export) are kept as is
+        urls = newPhotoURLs(existing, urls)
+    }
+    if len(urls) > maxImportPhotos {
+        errs = append(errs, fmt.Sprintf("photo_urls maksimal %d", maxImportPhotos))
+    }
+    for _, u := range urls {
+        if media.CheckURL(u) != nil {
+            errs = append(errs, fmt.Sprintf("photo_urls %q bukan URL http/https", u))
+        }
+    }
+    if len(errs) > 0 { return errors.New(strings.Join(errs, "; ")) }
+    if err := s.validateCreate(cp); err != nil { return err }
+    // weight and size are required on create; an update only checks the
+    // values it changes, so older products without them can still be updated
+    dims := []*int{&cp.Berat, &cp.Panjang, &cp.Lebar, &cp.Tinggi}
+    if existing != nil {
+        cur := []int{existing.Berat, existing.Panjang, existing.Lebar, existing.Tinggi}
+        for i := range dims {
+            if *dims[i] == cur[i] { dims[i] = nil }
+        }
+    }
+    if errs := checkDimensi("", dims[0], dims[1], dims[2], dims[3]); len(errs) > 0 {
+        return errors.New(strings.Join(errs, "; "))
+    }
+    attrs := cp.Attributes
+    if attrs == nil && existing != nil { attrs = AttributeMap(existing) }
+    if _, err := s.buildAttributes(cp.CategoryID, attrs, cp.Attributes == nil); err != nil { return err }
+    if existing != nil {
+        if keepMinimum { cp.StokMinimum = existing.StokMinimum }
+        return s.importUpdate(ctx, p, existing, cp, urls)
+    }
+    if p.DryRun { return nil }
+
+    photos, err := s.fetchImportPhotos(ctx, urls)
+    if err != nil { return err }
+    cp.Photos = photos
+    if _, err := s.create(cp, prodmodel.MovementImport, "import CSV"); err != nil {
+        s.removeUploads(cp.Photos)
+        return err
+    }
+    return nil
+}
+
+// importUpdate applies a validated row to an existing product. Only changed
+// fields are written; a stock change is recorded in the ledger as import.
+func (s *Service) importUpdate(ctx context.Context, p ImportParams, existing *prodmodel.Product, cp CreateParams, urls []string) error {
+    stokChanged := cp.Stok != existing.Stok
+    if stokChanged && len(existing.Variants) > 0 {
+        return errors.New("stok produk bervarian diatur per varian, bukan lewat import")
+    }
+    if p.DryRun { return nil }
+
+    photos, err := s.fetchImportPhotos(ctx, urls)
+    if err != nil { return err }
+    up := UpdateParams{UserID: p.UserID, ID: existing.ID, Photos: photos}
+    if cp.NamaProduk != existing.NamaProduk { up.NamaProduk = &cp.NamaProduk }
+    if cp.CategoryID != existing.IDCategory { up.CategoryID = &cp.CategoryID }
+    if strconv.Itoa(cp.HargaReseller) != existing.HargaReseller { up.HargaReseller = &cp.HargaReseller }
+    if strconv.Itoa(cp.HargaKonsumen) != existing.HargaKonsumen { up.HargaKonsumen = &cp.HargaKonsumen }
+    if cp.StokMinimum != existing.StokMinimum { up.StokMinimum = &cp.StokMinimum }
+    if cp.Deskripsi != existing.Deskripsi { up.Deskripsi = &cp.Deskripsi }
+    if cp.Berat != existing.Berat { up.Berat = &cp.Berat }
+    if cp.Panjang != existing.Panjang { up.Panjang = &cp.Panjang }
+    if cp.Lebar != existing.Lebar { up.Lebar = &cp.Lebar }
+    if cp.Tinggi != existing.Tinggi { up.Tinggi = &cp.Tinggi }
+    up.Attributes = cp.Attributes
+    if err := s.Update(up); err != nil {
+        s.removeUploads(photos)
+        return err
+    }
+    if !stokChanged { return nil }
+    uid := p.UserID
+    m := &prodmodel.StockMovement{
+        IDProduk: existing.ID,
+        Tipe:     prodmodel.MovementImport,
+        Alasan:   "import CSV",
+        IDUser:   &uid,
+    }
+    stok := cp.Stok
+    return s.repo.AdjustStock(m, &stok)
+}
+
+// fetchImportPhotos downloads and stores the photos of a row. On failure
+// the photos stored so far are removed.
+func (s *Service) fetchImportPhotos(ctx context.Context, urls []string) ([]PhotoUpload, error) {
+    var photos []PhotoUpload
+    for i, u := range urls {
+        res, err := media.FetchURL(ctx, u)
+        if err != nil {
+            s.removeUploads(photos)
+            return nil, fmt.Errorf("photo_urls %q: %v", u, err)
+        }
+        name := fmt.Sprintf("%d-%s", time.Now().UnixNano(), importPhotoName(u, i))
+        sizes, err := res.Store(ctx, s.store, "products", name)
+        if err != nil {
+            s.removeUploads(photos)
+            return nil, err
+        }
+        photos = append(photos, PhotoUpload{URL: sizes["original"].URL, Sizes: sizes})
+    }
+    return photos, nil
+}
+
+// newPhotoURLs drops the URLs that already belong to a product's photos.
+func newPhotoURLs(p *prodmodel.Product, urls []string) []string {
+    have := map[string]bool{}
+    for _, ph := range p.Photos {
+        have[ph.URL] = true
+        for _, u := range media.ParseSizes(ph.SizesJSON).URLs() { have[u] = true }
+    }
+    var out []string
+    for _, u := range urls {
+        if !have[u] { out = append(out, u) }
+    }
+    return out
+}
+
+// importCategory resolves a category id or name, caching lookups per import.
+func (s *Service) importCategory(v string, cache map[string]uint) (uint, error) {
+    if v == "" { return 0, nil }
+    key := strings.ToLower(v)
+    if id, ok := cache[key]; ok { return id, nil }
+    var id uint
+    if n, err := strconv.ParseUint(v, 10, 64); err == nil {
+        ok, err := s.repo.CategoryExists(uint(n))
+        if err != nil { return 0, err }
+        if ok { id = uint(n) }
+    } else {
+        found, err := s.repo.CategoryIDByName(v)
+        if err != nil { return 0, err }
+        id = found
+    }
+    cache[key] = id
+    return id, nil
+}
+
+// ImportErrorsCSV renders failed rows as a downloadable report.
+func ImportErrorsCSV(rows []ImportError) []byte {
+    var buf bytes.Buffer
+    w := csv.NewWriter(&buf)
+    _ = w.Write([]string{"baris", "nama_produk", "errors"})
+    for _, r := range rows {
+        _ = w.Write([]string{strconv.Itoa(r.Line), r.NamaProduk, r.Errors})
+    }
+    w.Flush()
+    return buf.Bytes()
+}
+
+// removeUploads deletes stored files of photos that were not persisted.
+func (s *Service) removeUploads(photos []PhotoUpload) {
+    ctx := context.Background()
+    for _, ph := range photos {
+        for _, u := range ph.Sizes.URLs() {
+            key, ok := s.store.Key(u)
+            if !ok { continue }
+            if err := s.store.Delete(ctx, key); err != nil {
+                log.Printf("import: remove %s: %v", key, err)
+            }
+        }
+    }
+}
+
+// splitPhotoURLs splits photo_urls on "|", commas or whitespace.
+func splitPhotoURLs(v string) []string {
+    return strings.FieldsFunc(v, func(r rune) bool {
+        return r == '|' || r == ',' || r == ' ' || r == '\n' || r == '\t'
+    })
+}
+
+// importPhotoName derives a file name from the URL path.
+func importPhotoName(u string, i int) string {
+    if j := strings.IndexAny(u, "?#"); j >= 0 { u = u[:j] }
+    base := strings.TrimSuffix(path.Base(u), path.Ext(u))
+    base = reNonWord.ReplaceAllString(strings.ToLower(base), "-")
+    base = strings.Trim(base, "-")
+    if base == "" || base == "." { base = fmt.Sprintf("import-%d", i) }
+    if len(base) > 60 { base = base[:60] }
+    return base
+}
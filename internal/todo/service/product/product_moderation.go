@@ -0,0 +1,129 @@
+package product
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "strings"
+
+    notifmodel "project-evermos/internal/todo/model/notification"
+    prodmodel "project-evermos/internal/todo/model/product"
+    prodrepo "project-evermos/internal/todo/repository/product"
+)
+
+var (
+    // ErrNotSubmittable is returned when submitting a product that is not a draft or rejected
+    ErrNotSubmittable = errors.New("hanya produk draft atau rejected yang dapat diajukan")
+    // ErrNotReviewable is returned when approving a product that is not pending review
+    ErrNotReviewable = errors.New("produk tidak sedang menunggu review")
+    // ErrNotRejectable is returned when rejecting a draft or an already rejected product
+    ErrNotRejectable = errors.New("hanya produk pending_review atau published yang dapat ditolak")
+    // ErrReviewStatus is returned for an unknown moderation status filter
+    ErrReviewStatus = errors.New("status harus draft, pending_review, published, rejected atau all")
+    // ErrNoOrigin is returned when publishing a product of a toko without origin address
+    ErrNoOrigin = errors.New("atur alamat asal pengiriman toko sebelum menerbitkan produk")
+)
+
+// requireOrigin checks that a toko may publish products: buyers must know
+// where they ship from.
+func (s *Service) requireOrigin(tokoID uint) error {
+    ok, err := s.repo.TokoHasOrigin(tokoID)
+    if err != nil { return err }
+    if !ok { return ErrNoOrigin }
+    return nil
+}
+
+// Submit sends a draft or rejected product to the review queue.
+func (s *Service) Submit(id uint) (*prodmodel.Product, error) {
+    tokoID, err := s.repo.GetTokoIDByProductID(id)
+    if err != nil { return nil, err }
+    if err := s.requireOrigin(tokoID); err != nil { return nil, err }
+    err = s.repo.SetStatus(id, prodrepo.StatusChange{
+        From:   []string{prodmodel.StatusDraft, prodmodel.StatusRejected},
+        Status: prodmodel.StatusPendingReview,
+    })
+    if errors.Is(err, prodrepo.ErrStatusConflict) { return nil, ErrNotSubmittable }
+    if err != nil { return nil, err }
+    return s.repo.GetByID(id)
+}
+
+// Approve publishes a product waiting for review and notifies its seller.
+func (s *Service) Approve(id, adminID uint) (*prodmodel.Product, error) {
+    existing, err := s.repo.GetByID(id)
+    if err != nil { return nil, err }
+    if existing == nil { return nil, gormErrNotFound }
+    err = s.repo.SetStatus(id, prodrepo.StatusChange{
+        From:       []string{prodmodel.StatusPendingReview},
+        Status:     prodmodel.StatusPublished,
+        ReviewerID: adminID,
+        Notify: &notifmodel.Notification{
+            Tipe:     notifmodel.TypeProductApproved,
+            Judul:    "Produk disetujui: " + existing.NamaProduk,
+            Pesan:    fmt.Sprintf("%s sudah tayang dan dapat dibeli.", existing.NamaProduk),
+            DataJSON: moderationData(id, ""),
+        },
+    })
+    if errors.Is(err, prodrepo.ErrStatusConflict) { return nil, ErrNotReviewable }
+    if err != nil { return nil, err }
+    return s.repo.GetByID(id)
+}
+
+// Reject takes a product out of review (or out of the catalog when already
+// published) with a reason for the seller, who can edit and resubmit it.
+func (s *Service) Reject(id, adminID uint, alasan string) (*prodmodel.Product, error) {
+    alasan = strings.TrimSpace(alasan)
+    if len(alasan) < 5 { return nil, errors.New("alasan min 5 char") }
+    if len(alasan) > 500 { return nil, errors.New("alasan max 500 char") }
+    existing, err := s.repo.GetByID(id)
+    if err != nil { return nil, err }
+    if existing == nil { return nil, gormErrNotFound }
+    err = s.repo.SetStatus(id, prodrepo.StatusChange{
+        From:       []string{prodmodel.StatusPendingReview, prodmodel.StatusPublished},
+        Status:     prodmodel.StatusRejected,
+        Alasan:     &alasan,
+        ReviewerID: adminID,
+        Notify: &notifmodel.Notification{
+            Tipe:     notifmodel.TypeProductRejected,
+            Judul:    "Produk ditolak: " + existing.NamaProduk,
+            Pesan:    fmt.Sprintf("%s ditolak: %s. Perbaiki lalu ajukan kembali.", existing.NamaProduk, alasan),
+            DataJSON: moderationData(id, alasan),
+        },
+    })
+    if errors.Is(err, prodrepo.ErrStatusConflict) { return nil, ErrNotRejectable }
+    if err != nil { return nil, err }
+    return s.repo.GetByID(id)
+}
+
+func moderationData(productID uint, alasan string) string {
+    data := map[string]interface{}{"product_id": productID}
+    if alasan != "" { data["alasan"] = alasan }
+    b, _ := json.Marshal(data)
+    return string(b)
+}
+
+type ReviewPage struct {
+    Items []prodmodel.Product
+    Total int64
+    Limit int
+    Page  int
+}
+
+// ListForReview lists products by moderation status for admins; status
+// defaults to pending_review, "all" lists every status.
+func (s *Service) ListForReview(status string, limit, page int) (*ReviewPage, error) {
+    switch status {
+    case "":
+        status = prodmodel.StatusPendingReview
+    case "all":
+        status = ""
+    case prodmodel.StatusDraft, prodmodel.StatusPendingReview, prodmodel.StatusPublished, prodmodel.StatusRejected:
+    default:
+        return nil, ErrReviewStatus
+    }
+    if limit <= 0 { limit = 20 }
+    if limit > 100 { limit = 100 }
+    if page <= 0 { page = 1 }
+    rows, total, err := s.repo.List(prodrepo.ListFilter{Status: status, Limit: limit, Page: page})
+    if err != nil { return nil, err }
+    return &ReviewPage{Items: rows, Total: total, Limit: limit, Page: page}, nil
+}
@@ -1,13 +1,20 @@
 package product
 
 import (
+    "context"
+    "encoding/json"
     "errors"
     "fmt"
+    "log"
     "path/filepath"
     "regexp"
+    "strconv"
     "strings"
     "time"
 
+    "project-evermos/internal/media"
+    "project-evermos/internal/slug"
+    "project-evermos/internal/storage"
     prodmodel "project-evermos/internal/todo/model/product"
     prodrepo "project-evermos/internal/todo/repository/product"
 
@@ -16,22 +23,26 @@ import (
 
 type Service struct {
     repo *prodrepo.Repository
-    // baseURL used to construct file URLs for photos
-    baseURL string
+    // store holds the photo files
+    store storage.Storage
 }
 
-func NewService(repo *prodrepo.Repository, baseURL string) *Service {
-    return &Service{repo: repo, baseURL: strings.TrimRight(baseURL, "/")}
+func NewService(repo *prodrepo.Repository, store storage.Storage) *Service {
+    return &Service{repo: repo, store: store}
 }
 
 type ListParams struct {
-    NamaProduk string
-    CategoryID uint
-    TokoID     uint
-    MinHarga   *int
-    MaxHarga   *int
-    Limit      int
-    Page       int
+    NamaProduk  string
+    CategoryID  uint
+    TokoID      uint
+    Status      string // moderation state, "" = any
+    OwnerTokoID uint   // listed regardless of Status
+    Attributes  map[string][]string // attribute kode -> accepted values
+    MinHarga    *int
+    MaxHarga    *int
+    Verified    *bool // only products of (un)verified stores
+    Limit       int
+    Page        int
 }
 
 type CreateParams struct {
@@ -41,11 +52,23 @@ type CreateParams struct {
     HargaReseller int
     HargaKonsumen int
     Stok          int
+    StokMinimum   int // low stock threshold
     Deskripsi     string
-    // Photos holds already-saved file relative URLs (to be persisted)
-    PhotoURLs []string
+    // Berat (grams) and Panjang/Lebar/Tinggi (cm) are required for shipping
+    Berat         int
+    Panjang       int
+    Lebar         int
+    Tinggi        int
+    // Photos holds already-saved uploads (to be persisted)
+    Photos []PhotoUpload
     // TokoID must be the user's toko id
     TokoID uint
+    // Variants is optional; when set, stok is the sum of variant stock
+    Variants *VariantsInput
+    // Status is draft or pending_review (default); admins publish
+    Status string
+    // Attributes are kode -> nilai, checked against the category schema
+    Attributes map[string]string
 }
 
 type UpdateParams struct {
@@ -56,39 +79,147 @@ type UpdateParams struct {
     HargaReseller *int
     HargaKonsumen *int
     Stok          *int
+    StokMinimum   *int
     Deskripsi     *string
-    PhotoURLs     []string // new photos to add
+    Berat         *int
+    Panjang       *int
+    Lebar         *int
+    Tinggi        *int
+    Photos        []PhotoUpload // new photos to add
+    Variants      *VariantsInput // nil = keep current variants
+    Attributes    map[string]string // nil = keep current attributes
 }
 
+// VariantsInput is the full option/variant definition of a product.
+// On update it replaces the current definition; variants are matched by SKU.
+type VariantsInput struct {
+    Options  []OptionInput
+    Variants []VariantInput
+}
+
+type OptionInput struct {
+    Nama  string   `json:"nama"`
+    Nilai []string `json:"nilai"`
+}
+
+type VariantInput struct {
+    SKU           string            `json:"sku"`
+    Nama          string            `json:"nama"`
+    Opsi          map[string]string `json:"opsi"`
+    HargaReseller int               `json:"harga_reseller"`
+    HargaKonsumen int               `json:"harga_konsumen"`
+    Stok          int               `json:"stok"`
+    // shipping weight/size of the variant; nil = the product's value
+    Berat         *int              `json:"berat"`
+    Panjang       *int              `json:"panjang"`
+    Lebar         *int              `json:"lebar"`
+    Tinggi        *int              `json:"tinggi"`
+    // Photos holds already-saved photos for this variant
+    Photos []PhotoUpload `json:"-"`
+}
+
+// PhotoUpload is a saved photo: URL of the normalized image plus all sizes.
+type PhotoUpload struct {
+    URL   string
+    Sizes media.Sizes
+}
+
+func photoModels(in []PhotoUpload) []prodmodel.Photo {
+    var photos []prodmodel.Photo
+    for _, u := range in {
+        photos = append(photos, prodmodel.Photo{URL: u.URL, SizesJSON: u.Sizes.JSON()})
+    }
+    return photos
+}
+
+// Shipping weight and size limits of one unit.
+const (
+    MaxBerat   = 500000 // grams
+    MaxDimensi = 500    // cm
+)
+
 var (
     reNonWord        = regexp.MustCompile(`[^a-z0-9]+`)
     gormErrNotFound  = gorm.ErrRecordNotFound
+
+    // ErrStockDirectUpdate is returned when an update tries to overwrite stok
+    ErrStockDirectUpdate = errors.New("stok tidak dapat diubah langsung, gunakan POST /product/:id/stock/adjust")
 )
 
-func (s *Service) List(p ListParams) ([]prodmodel.Product, int64, error) {
+// ListPage is a page of products with the limit and page applied.
+type ListPage struct {
+    Items []prodmodel.Product
+    Total int64
+    Limit int
+    Page  int
+}
+
+func (s *Service) List(p ListParams) (*ListPage, error) {
     limit := p.Limit
     if limit <= 0 { limit = 10 }
     if limit > 100 { limit = 100 }
     page := p.Page
     if page <= 0 { page = 1 }
     f := prodrepo.ListFilter{
-        NamaProduk: p.NamaProduk,
-        CategoryID: p.CategoryID,
-        TokoID:     p.TokoID,
-        MinHarga:   p.MinHarga,
-        MaxHarga:   p.MaxHarga,
-        Limit:      limit,
-        Page:       page,
+        NamaProduk:  p.NamaProduk,
+        CategoryID:  p.CategoryID,
+        TokoID:      p.TokoID,
+        Status:      p.Status,
+        OwnerTokoID: p.OwnerTokoID,
+        Attributes:  p.Attributes,
+        MinHarga:    p.MinHarga,
+        MaxHarga:    p.MaxHarga,
+        Verified:    p.Verified,
+        Limit:       limit,
+        Page:        page,
     }
-    return s.repo.List(f)
+    items, total, err := s.repo.List(f)
+    if err != nil { return nil, err }
+    return &ListPage{Items: items, Total: total, Limit: limit, Page: page}, nil
 }
 
 func (s *Service) GetByID(id uint) (*prodmodel.Product, error) {
     return s.repo.GetByID(id)
 }
 
+// GetBySlug returns the product with the given slug. When slug is a former
+// slug of a product, the product is nil and its current slug is returned so
+// the caller can redirect.
+func (s *Service) GetBySlug(sl string) (*prodmodel.Product, string, error) {
+    p, err := s.repo.GetBySlug(sl)
+    if err != nil || p != nil { return p, "", err }
+    cur, err := s.repo.CurrentSlugByOld(sl)
+    return nil, cur, err
+}
+
 func (s *Service) Create(p CreateParams) (uint, error) {
+    return s.create(p, prodmodel.MovementInitial, "stok awal")
+}
+
+// create is Create with the stock ledger type/reason of the initial stock.
+func (s *Service) create(p CreateParams, tipe, alasan string) (uint, error) {
+    var vs *prodrepo.VariantSet
+    if p.Variants != nil {
+        set, errs := buildVariantSet(p.Variants)
+        if len(errs) > 0 { return 0, errors.New(strings.Join(errs, "; ")) }
+        if len(set.Variants) > 0 {
+            minRes, minKon, total := summarizeVariants(set.Variants)
+            if p.HargaReseller < 0 { p.HargaReseller = minRes }
+            if p.HargaKonsumen < 0 { p.HargaKonsumen = minKon }
+            p.Stok = total
+        }
+        vs = set
+    }
+    if p.Status == "" { p.Status = prodmodel.StatusPendingReview }
     if err := s.validateCreate(p); err != nil { return 0, err }
+    if p.Status != prodmodel.StatusDraft {
+        if err := s.requireOrigin(p.TokoID); err != nil { return 0, err }
+    }
+    if errs := checkDimensi("", &p.Berat, &p.Panjang, &p.Lebar, &p.Tinggi); len(errs) > 0 {
+        return 0, errors.New(strings.Join(errs, "; "))
+    }
+    attrs, err := s.buildAttributes(p.CategoryID, p.Attributes, false)
+    if err != nil { return 0, err }
     // build model
     prod := prodmodel.Product{
         NamaProduk:    p.NamaProduk,
@@ -96,88 +227,599 @@ func (s *Service) Create(p CreateParams) (uint, error) {
         HargaReseller: fmt.Sprintf("%d", p.HargaReseller),
         HargaKonsumen: fmt.Sprintf("%d", p.HargaKonsumen),
         Stok:          p.Stok,
+        StokMinimum:   p.StokMinimum,
         Deskripsi:     p.Deskripsi,
+        Berat:         p.Berat,
+        Panjang:       p.Panjang,
+        Lebar:         p.Lebar,
+        Tinggi:        p.Tinggi,
         IDToko:        p.TokoID,
         IDCategory:    p.CategoryID,
+        Status:        p.Status,
         CreatedAt:     time.Now(),
         UpdatedAt:     time.Now(),
     }
     // generate unique slug
-    slug, err := s.generateUniqueSlug(p.NamaProduk)
+    sl, err := s.generateUniqueSlug(p.NamaProduk, 0)
     if err != nil { return 0, err }
-    prod.Slug = slug
+    prod.Slug = sl
 
-    // convert urls to photo models
-    var photos []prodmodel.Photo
-    for _, url := range p.PhotoURLs {
-        photos = append(photos, prodmodel.Photo{URL: url})
-    }
+    photos := photoModels(p.Photos)
 
-    if err := s.repo.Create(&prod, photos); err != nil { return 0, err }
+    actor := prodrepo.StockActor{UserID: p.UserID, Tipe: tipe, Alasan: alasan}
+    if err := s.repo.Create(&prod, photos, vs, attrs, actor); err != nil { return 0, err }
     return prod.ID, nil
 }
 
 func (s *Service) Update(p UpdateParams) error {
+    if p.Stok != nil { return ErrStockDirectUpdate }
     // read existing product
     existing, err := s.repo.GetByID(p.ID)
     if err != nil { return err }
     if existing == nil { return gormErrNotFound }
 
     // apply partial updates
-    if p.NamaProduk != nil {
+    // content changes (not price or stock) of a published product need a new review
+    content := len(p.Photos) > 0 || p.Variants != nil
+    // the slug only follows a real rename; the old one keeps redirecting
+    if p.NamaProduk != nil && *p.NamaProduk != existing.NamaProduk {
+        content = true
         existing.NamaProduk = *p.NamaProduk
-        slug, err := s.generateUniqueSlug(*p.NamaProduk)
+        sl, err := s.generateUniqueSlug(*p.NamaProduk, existing.ID)
         if err != nil { return err }
-        existing.Slug = slug
+        existing.Slug = sl
+    }
+    catChanged := p.CategoryID != nil && *p.CategoryID != existing.IDCategory
+    if catChanged {
+        content = true
+        existing.IDCategory = *p.CategoryID
     }
-    if p.CategoryID != nil { existing.IDCategory = *p.CategoryID }
     if p.HargaReseller != nil { existing.HargaReseller = fmt.Sprintf("%d", *p.HargaReseller) }
     if p.HargaKonsumen != nil { existing.HargaKonsumen = fmt.Sprintf("%d", *p.HargaKonsumen) }
-    if p.Stok != nil { existing.Stok = *p.Stok }
-    if p.Deskripsi != nil { existing.Deskripsi = *p.Deskripsi }
+    if p.StokMinimum != nil {
+        if *p.StokMinimum < 0 { return errors.New("stok_minimum must be >= 0") }
+        existing.StokMinimum = *p.StokMinimum
+    }
+    if p.Deskripsi != nil && *p.Deskripsi != existing.Deskripsi {
+        content = true
+        existing.Deskripsi = *p.Deskripsi
+    }
+    // weight and size are shipping data, not reviewed content
+    if errs := checkDimensi("", p.Berat, p.Panjang, p.Lebar, p.Tinggi); len(errs) > 0 {
+        return errors.New(strings.Join(errs, "; "))
+    }
+    if p.Berat != nil { existing.Berat = *p.Berat }
+    if p.Panjang != nil { existing.Panjang = *p.Panjang }
+    if p.Lebar != nil { existing.Lebar = *p.Lebar }
+    if p.Tinggi != nil { existing.Tinggi = *p.Tinggi }
+    // attributes follow the (new) category schema; values the new category
+    // does not define are dropped
+    var attrs *prodrepo.AttributeSet
+    if p.Attributes != nil || catChanged {
+        in, dropUnknown := p.Attributes, false
+        if in == nil { in, dropUnknown = AttributeMap(existing), true }
+        set, err := s.buildAttributes(existing.IDCategory, in, dropUnknown)
+        if err != nil { return err }
+        if !sameAttributes(existing, set) {
+            content = true
+            attrs = set
+        }
+    }
+    if content && existing.Status == prodmodel.StatusPublished {
+        existing.Status = prodmodel.StatusPendingReview
+    }
     existing.UpdatedAt = time.Now()
 
+    var vs *prodrepo.VariantSet
+    if p.Variants != nil {
+        set, errs := buildVariantSet(p.Variants)
+        if len(errs) > 0 { return errors.New(strings.Join(errs, "; ")) }
+        if len(set.Variants) > 0 {
+            minRes, minKon, _ := summarizeVariants(set.Variants)
+            if p.HargaReseller == nil { existing.HargaReseller = fmt.Sprintf("%d", minRes) }
+            if p.HargaKonsumen == nil { existing.HargaKonsumen = fmt.Sprintf("%d", minKon) }
+        }
+        vs = set
+    }
+
     // photos to add
-    var photos []prodmodel.Photo
-    for _, url := range p.PhotoURLs {
-        photos = append(photos, prodmodel.Photo{URL: url})
+    photos := photoModels(p.Photos)
+
+    actor := prodrepo.StockActor{UserID: p.UserID, Tipe: prodmodel.MovementInitial, Alasan: "stok awal varian"}
+    removed, err := s.repo.Update(existing, photos, vs, attrs, actor)
+    if err != nil { return err }
+    s.removePhotoFiles(removed)
+    return nil
+}
+
+type AdjustStockParams struct {
+    UserID    uint
+    ProductID uint
+    VariantID uint
+    // Exactly one of Jumlah (delta) or StokBaru (absolute) must be set
+    Jumlah   *int
+    StokBaru *int
+    Alasan   string
+}
+
+// AdjustStock records a manual stock adjustment and returns the movement.
+func (s *Service) AdjustStock(p AdjustStockParams) (*prodmodel.StockMovement, error) {
+    var errs []string
+    if (p.Jumlah == nil) == (p.StokBaru == nil) {
+        errs = append(errs, "isi salah satu: jumlah atau stok_baru")
+    }
+    if p.Jumlah != nil && *p.Jumlah == 0 {
+        errs = append(errs, "jumlah tidak boleh 0")
+    }
+    if p.StokBaru != nil && *p.StokBaru < 0 {
+        errs = append(errs, "stok_baru must be >= 0")
     }
+    if len(strings.TrimSpace(p.Alasan)) < 3 {
+        errs = append(errs, "alasan min 3 char")
+    }
+    if len(errs) > 0 { return nil, errors.New(strings.Join(errs, "; ")) }
+
+    existing, err := s.repo.GetByID(p.ProductID)
+    if err != nil { return nil, err }
+    if existing == nil { return nil, gormErrNotFound }
 
-    return s.repo.Update(existing, photos)
+    var variantID *uint
+    if len(existing.Variants) > 0 {
+        if p.VariantID == 0 { return nil, errors.New("variant_id required for product with variants") }
+        found := false
+        for _, v := range existing.Variants {
+            if v.ID == p.VariantID { found = true; break }
+        }
+        if !found { return nil, errors.New("variant_id invalid") }
+        vid := p.VariantID
+        variantID = &vid
+    } else if p.VariantID != 0 {
+        return nil, errors.New("variant_id invalid")
+    }
+
+    uid := p.UserID
+    m := &prodmodel.StockMovement{
+        IDProduk: p.ProductID,
+        IDVarian: variantID,
+        Tipe:     prodmodel.MovementAdjustment,
+        Alasan:   strings.TrimSpace(p.Alasan),
+        IDUser:   &uid,
+    }
+    if p.Jumlah != nil { m.Jumlah = *p.Jumlah }
+    if err := s.repo.AdjustStock(m, p.StokBaru); err != nil {
+        if errors.Is(err, prodrepo.ErrInsufficientStock) {
+            return nil, errors.New("stok tidak boleh kurang dari 0")
+        }
+        return nil, err
+    }
+    return m, nil
 }
 
+type StockHistoryParams struct {
+    ProductID uint
+    VariantID uint
+    Tipe      string
+    Limit     int
+    Page      int
+}
+
+type StockHistoryPage struct {
+    Items []prodmodel.StockMovement
+    Total int64
+    Limit int
+    Page  int
+}
+
+// StockHistory lists stock movements of a product, newest first.
+func (s *Service) StockHistory(p StockHistoryParams) (*StockHistoryPage, error) {
+    limit := p.Limit
+    if limit <= 0 { limit = 20 }
+    if limit > 100 { limit = 100 }
+    page := p.Page
+    if page <= 0 { page = 1 }
+    rows, total, err := s.repo.ListStockMovements(prodrepo.StockHistoryFilter{
+        ProductID: p.ProductID,
+        VariantID: p.VariantID,
+        Tipe:      p.Tipe,
+        Limit:     limit,
+        Page:      page,
+    })
+    if err != nil { return nil, err }
+    return &StockHistoryPage{Items: rows, Total: total, Limit: limit, Page: page}, nil
+}
+
+type PriceHistoryParams struct {
+    ProductID uint
+    VariantID uint
+    Days      int
+}
+
+// PriceHistoryResult is the price of a product (or variant) over the last
+// Days days. Items are price changes, oldest first; the first one is the
+// price in effect at the start of the period.
+type PriceHistoryResult struct {
+    Items         []prodmodel.PriceHistory
+    Days          int
+    HargaReseller int // current
+    HargaKonsumen int // current
+    MinKonsumen   int
+    MaxKonsumen   int
+}
+
+// ErrVariantInvalid is returned when a variant does not belong to the product.
+var ErrVariantInvalid = errors.New("variant_id invalid")
+
+// PriceHistory returns the price changes of a product, or of one variant.
+func (s *Service) PriceHistory(p PriceHistoryParams) (*PriceHistoryResult, error) {
+    days := p.Days
+    if days <= 0 { days = 90 }
+    if days > 365 { days = 365 }
+    existing, err := s.repo.GetByID(p.ProductID)
+    if err != nil { return nil, err }
+    if existing == nil { return nil, gormErrNotFound }
+
+    res := &PriceHistoryResult{Days: days}
+    res.HargaReseller, _ = strconv.Atoi(existing.HargaReseller)
+    res.HargaKonsumen, _ = strconv.Atoi(existing.HargaKonsumen)
+    var variantID *uint
+    if p.VariantID != 0 {
+        found := false
+        for _, v := range existing.Variants {
+            if v.ID == p.VariantID {
+                found = true
+                res.HargaReseller, res.HargaKonsumen = v.HargaReseller, v.HargaKonsumen
+                break
+            }
+        }
+        if !found { return nil, ErrVariantInvalid }
+        vid := p.VariantID
+        variantID = &vid
+    }
+
+    rows, err := s.repo.ListPriceHistory(p.ProductID, variantID, time.Now().AddDate(0, 0, -days))
+    if err != nil { return nil, err }
+    // order snapshots repeat the same price; keep only the changes
+    for _, r := range rows {
+        if n := len(res.Items); n > 0 {
+            last := res.Items[n-1]
+            if last.HargaReseller == r.HargaReseller && last.HargaKonsumen == r.HargaKonsumen { continue }
+        }
+        res.Items = append(res.Items, r)
+    }
+    res.MinKonsumen, res.MaxKonsumen = res.HargaKonsumen, res.HargaKonsumen
+    for _, r := range res.Items {
+        if r.HargaKonsumen < res.MinKonsumen { res.MinKonsumen = r.HargaKonsumen }
+        if r.HargaKonsumen > res.MaxKonsumen { res.MaxKonsumen = r.HargaKonsumen }
+    }
+    return res, nil
+}
+
+type StockAlertPage struct {
+    Items []prodrepo.StockAlertRow
+    Total int64
+    Limit int
+    Page  int
+}
+
+// ErrAlertStatus is returned for an unknown alert status filter.
+var ErrAlertStatus = errors.New("status harus open, resolved atau all")
+
+// ListStockAlerts lists a toko's low stock alerts, newest first. status is
+// open, resolved or all.
+func (s *Service) ListStockAlerts(tokoID uint, status string, limit, page int) (*StockAlertPage, error) {
+    switch status {
+    case "", "all":
+        status = ""
+    case "open", "resolved":
+    default:
+        return nil, ErrAlertStatus
+    }
+    if limit <= 0 { limit = 20 }
+    if limit > 100 { limit = 100 }
+    if page <= 0 { page = 1 }
+    rows, total, err := s.repo.ListStockAlerts(tokoID, status, limit, page)
+    if err != nil { return nil, err }
+    return &StockAlertPage{Items: rows, Total: total, Limit: limit, Page: page}, nil
+}
+
+// Delete moves a product to the trash; it can be restored until purged.
 func (s *Service) Delete(id uint) error {
     return s.repo.Delete(id)
 }
 
-// Ownership helper for handlers
-func (s *Service) RepoOwnerUserID(productID uint) (uint, error) {
-    return s.repo.GetOwnerUserIDByProductID(productID)
+// ErrPhotoNotFound is returned when a photo does not belong to the product.
+var ErrPhotoNotFound = errors.New("photo not found")
+
+// DeletePhoto removes a product photo and its files.
+func (s *Service) DeletePhoto(productID, photoID uint) error {
+    ph, err := s.repo.DeletePhoto(productID, photoID)
+    if err != nil { return err }
+    if ph == nil { return ErrPhotoNotFound }
+    s.removePhotoFiles([]prodmodel.Photo{*ph})
+    return nil
 }
 
-// generateUniqueSlug builds slug from name and appends numeric suffix when clashing
-func (s *Service) generateUniqueSlug(name string) (string, error) {
-    base := strings.ToLower(strings.TrimSpace(name))
-    base = reNonWord.ReplaceAllString(base, "-")
-    base = strings.Trim(base, "-")
-    if base == "" { base = fmt.Sprintf("produk-%d", time.Now().Unix()) }
+// ReorderPhotos sets the display order and/or cover of a product's photos.
+// A non-empty photoIDs must list every product photo (not variant photos)
+// exactly once; primaryID, when set, must be one of them.
+func (s *Service) ReorderPhotos(productID uint, photoIDs []uint, primaryID *uint) (*prodmodel.Product, error) {
+    existing, err := s.repo.GetByID(productID)
+    if err != nil { return nil, err }
+    if existing == nil { return nil, gormErrNotFound }
 
-    slug := base
-    // naive loop: try up to reasonable attempts by checking in DB
-    for i := 0; i < 1000; i++ {
-        exists, err := s.slugExists(slug)
-        if err != nil { return "", err }
-        if !exists { return slug, nil }
-        slug = fmt.Sprintf("%s-%d", base, i+1)
+    own := make(map[uint]bool, len(existing.Photos))
+    for _, ph := range existing.Photos { own[ph.ID] = true }
+    var errs []string
+    seen := make(map[uint]bool, len(photoIDs))
+    for _, id := range photoIDs {
+        if !own[id] {
+            errs = append(errs, fmt.Sprintf("photo %d bukan foto produk ini", id))
+        } else if seen[id] {
+            errs = append(errs, fmt.Sprintf("photo %d duplicated", id))
+        }
+        seen[id] = true
+    }
+    if len(photoIDs) == 0 && primaryID == nil {
+        errs = append(errs, "photo_ids atau primary_photo_id wajib diisi")
+    } else if len(photoIDs) > 0 && len(errs) == 0 && len(seen) != len(own) {
+        errs = append(errs, "photo_ids harus berisi semua foto produk")
     }
-    return "", errors.New("failed to generate unique slug")
+    if primaryID != nil && !own[*primaryID] {
+        errs = append(errs, "primary_photo_id bukan foto produk ini")
+    }
+    if len(errs) > 0 { return nil, errors.New(strings.Join(errs, "; ")) }
+
+    if err := s.repo.ReorderPhotos(productID, photoIDs, primaryID); err != nil { return nil, err }
+    return s.repo.GetByID(productID)
 }
 
-func (s *Service) slugExists(slug string) (bool, error) {
-    // check by query
-    itm, err := s.repo.GetBySlug(slug)
-    if err != nil { return false, err }
-    return itm != nil, nil
+type TrashPage struct {
+    Items []prodmodel.Product
+    Total int64
+    Limit int
+    Page  int
+}
+
+// ListTrash lists a toko's trashed products.
+func (s *Service) ListTrash(tokoID uint, limit, page int) (*TrashPage, error) {
+    if limit <= 0 { limit = 10 }
+    if limit > 100 { limit = 100 }
+    if page <= 0 { page = 1 }
+    rows, total, err := s.repo.ListTrashed(tokoID, limit, page)
+    if err != nil { return nil, err }
+    return &TrashPage{Items: rows, Total: total, Limit: limit, Page: page}, nil
+}
+
+// ListFeed lists new products of the stores userID follows.
+func (s *Service) ListFeed(userID uint, limit, page int) (*ListPage, error) {
+    if limit <= 0 { limit = 10 }
+    if limit > 100 { limit = 100 }
+    if page <= 0 { page = 1 }
+    items, total, err := s.repo.ListFeed(userID, limit, page)
+    if err != nil { return nil, err }
+    return &ListPage{Items: items, Total: total, Limit: limit, Page: page}, nil
+}
+
+func (s *Service) Restore(id uint) error {
+    return s.repo.Restore(id)
+}
+
+func (s *Service) TrashedTokoID(productID uint) (uint, error) {
+    return s.repo.GetTrashedTokoID(productID)
+}
+
+// PurgeTrash permanently deletes products trashed longer than retention and
+// removes their photo files. Returns the number purged.
+func (s *Service) PurgeTrash(retention time.Duration) (int, error) {
+    before := time.Now().Add(-retention)
+    total := 0
+    for {
+        n, photos, err := s.repo.PurgeTrashed(before, 100)
+        if err != nil { return total, err }
+        total += n
+        s.removePhotoFiles(photos)
+        if n < 100 { return total, nil }
+    }
+}
+
+// StartTrashPurger runs PurgeTrash every interval in the background.
+// A non-positive interval disables the purger.
+func (s *Service) StartTrashPurger(retention, interval time.Duration) {
+    if interval <= 0 { return }
+    go func() {
+        t := time.NewTicker(interval)
+        defer t.Stop()
+        for {
+            if n, err := s.PurgeTrash(retention); err != nil {
+                log.Printf("purge: %v", err)
+            } else if n > 0 {
+                log.Printf("purge: %d produk dihapus permanen", n)
+            }
+            <-t.C
+        }
+    }()
+}
+
+// removePhotoFiles deletes the files of every size of the given photos.
+// Failures are logged; the rows are already gone. URLs not produced by the
+// store (external links) are left alone.
+func (s *Service) removePhotoFiles(photos []prodmodel.Photo) {
+    ctx := context.Background()
+    for _, ph := range photos {
+        urls := append([]string{ph.URL}, media.ParseSizes(ph.SizesJSON).URLs()...)
+        seen := map[string]bool{}
+        for _, u := range urls {
+            key, ok := s.store.Key(u)
+            if !ok || seen[key] { continue }
+            seen[key] = true
+            if err := s.store.Delete(ctx, key); err != nil {
+                log.Printf("remove photo file %s: %v", key, err)
+            }
+        }
+    }
+}
+
+// Ownership helper for handlers: the toko of a product, 0 when not found.
+func (s *Service) RepoTokoID(productID uint) (uint, error) {
+    return s.repo.GetTokoIDByProductID(productID)
+}
+
+// generateUniqueSlug returns a slug for name that no other product uses,
+// now or formerly. selfID is the product being renamed, 0 when creating.
+func (s *Service) generateUniqueSlug(name string, selfID uint) (string, error) {
+    base := slug.Make(name, fmt.Sprintf("produk-%d", time.Now().Unix()))
+    return slug.Unique(base, func(c string) (bool, error) { return s.repo.SlugTaken(c, selfID) })
+}
+
+// buildVariantSet validates the option/variant definition and converts it to models.
+func buildVariantSet(in *VariantsInput) (*prodrepo.VariantSet, []string) {
+    var errs []string
+    now := time.Now()
+    set := &prodrepo.VariantSet{}
+
+    allowed := make(map[string]map[string]bool, len(in.Options))
+    names := make([]string, 0, len(in.Options))
+    for i, o := range in.Options {
+        name := strings.TrimSpace(o.Nama)
+        if name == "" {
+            errs = append(errs, fmt.Sprintf("options[%d].nama required", i))
+            continue
+        }
+        if _, dup := allowed[strings.ToLower(name)]; dup {
+            errs = append(errs, fmt.Sprintf("option %q duplicated", name))
+            continue
+        }
+        vals := make([]string, 0, len(o.Nilai))
+        seen := map[string]bool{}
+        for _, v := range o.Nilai {
+            v = strings.TrimSpace(v)
+            if v == "" || seen[strings.ToLower(v)] { continue }
+            seen[strings.ToLower(v)] = true
+            vals = append(vals, v)
+        }
+        if len(vals) == 0 {
+            errs = append(errs, fmt.Sprintf("option %q must have at least one value", name))
+            continue
+        }
+        allowed[strings.ToLower(name)] = seen
+        names = append(names, name)
+        b, _ := json.Marshal(vals)
+        set.Options = append(set.Options, prodmodel.Option{
+            NamaOpsi: name, NilaiJSON: string(b), Urutan: i, CreatedAt: now, UpdatedAt: now,
+        })
+    }
+    if len(set.Options) > 0 && len(in.Variants) == 0 {
+        errs = append(errs, "variants required when options are set")
+    }
+
+    skus := map[string]bool{}
+    combos := map[string]bool{}
+    for i, v := range in.Variants {
+        sku := strings.TrimSpace(v.SKU)
+        if sku == "" {
+            errs = append(errs, fmt.Sprintf("variants[%d].sku required", i))
+        } else if skus[strings.ToLower(sku)] {
+            errs = append(errs, fmt.Sprintf("sku %q duplicated", sku))
+        }
+        skus[strings.ToLower(sku)] = true
+        if v.HargaReseller < 0 || v.HargaKonsumen < 0 || v.Stok < 0 {
+            errs = append(errs, fmt.Sprintf("variants[%d] harga/stok must be >= 0", i))
+        }
+        errs = append(errs, checkDimensi(fmt.Sprintf("variants[%d].", i), v.Berat, v.Panjang, v.Lebar, v.Tinggi)...)
+
+        // every defined option must be chosen with an allowed value
+        opsi := make(map[string]string, len(names))
+        parts := make([]string, 0, len(names))
+        for _, name := range names {
+            val, ok := lookupOption(v.Opsi, name)
+            if !ok {
+                errs = append(errs, fmt.Sprintf("variants[%d].opsi %q required", i, name))
+                continue
+            }
+            if !allowed[strings.ToLower(name)][strings.ToLower(val)] {
+                errs = append(errs, fmt.Sprintf("variants[%d].opsi %q value %q not allowed", i, name, val))
+                continue
+            }
+            opsi[name] = val
+            parts = append(parts, val)
+        }
+        if len(v.Opsi) > len(names) {
+            errs = append(errs, fmt.Sprintf("variants[%d].opsi contains unknown option", i))
+        }
+        if len(parts) > 0 {
+            key := strings.ToLower(strings.Join(parts, "\x00"))
+            if combos[key] {
+                errs = append(errs, fmt.Sprintf("variants[%d] duplicates another option combination", i))
+            }
+            combos[key] = true
+        }
+
+        nama := strings.TrimSpace(v.Nama)
+        if nama == "" {
+            nama = strings.Join(parts, " / ")
+        }
+        if nama == "" {
+            nama = sku
+        }
+        var opsiJSON string
+        if len(opsi) > 0 {
+            b, _ := json.Marshal(opsi)
+            opsiJSON = string(b)
+        }
+        photos := photoModels(v.Photos)
+        set.Variants = append(set.Variants, prodmodel.Variant{
+            SKU:           sku,
+            NamaVarian:    nama,
+            OpsiJSON:      opsiJSON,
+            HargaReseller: v.HargaReseller,
+            HargaKonsumen: v.HargaKonsumen,
+            Stok:          v.Stok,
+            Berat:         v.Berat,
+            Panjang:       v.Panjang,
+            Lebar:         v.Lebar,
+            Tinggi:        v.Tinggi,
+            CreatedAt:     now,
+            UpdatedAt:     now,
+            Photos:        photos,
+        })
+    }
+    return set, errs
+}
+
+// lookupOption finds an option value by case-insensitive option name.
+func lookupOption(opsi map[string]string, name string) (string, bool) {
+    for k, v := range opsi {
+        if strings.EqualFold(strings.TrimSpace(k), name) {
+            v = strings.TrimSpace(v)
+            return v, v != ""
+        }
+    }
+    return "", false
+}
+
+// checkDimensi validates a shipping weight (grams) and size (cm); prefix
+// names the fields in messages. nil values are not set and not checked.
+func checkDimensi(prefix string, berat, panjang, lebar, tinggi *int) []string {
+    var errs []string
+    if berat != nil && (*berat < 1 || *berat > MaxBerat) {
+        errs = append(errs, fmt.Sprintf("%sberat must be 1-%d gram", prefix, MaxBerat))
+    }
+    for _, d := range []struct{ name string; v *int }{{"panjang", panjang}, {"lebar", lebar}, {"tinggi", tinggi}} {
+        if d.v != nil && (*d.v < 1 || *d.v > MaxDimensi) {
+            errs = append(errs, fmt.Sprintf("%s%s must be 1-%d cm", prefix, d.name, MaxDimensi))
+        }
+    }
+    return errs
+}
+
+// summarizeVariants returns the cheapest prices and total stock across variants.
+func summarizeVariants(vs []prodmodel.Variant) (minRes, minKon, total int) {
+    for i, v := range vs {
+        if i == 0 || v.HargaReseller < minRes { minRes = v.HargaReseller }
+        if i == 0 || v.HargaKonsumen < minKon { minKon = v.HargaKonsumen }
+        total += v.Stok
+    }
+    return minRes, minKon, total
 }
 
 // Helpers
@@ -204,6 +846,12 @@ func (s *Service) validateCreate(p CreateParams) error {
     if p.HargaReseller < 0 || p.HargaKonsumen < 0 || p.Stok < 0 {
         errs = append(errs, "harga_reseller/harga_konsumen/stok must be >= 0")
     }
+    if p.StokMinimum < 0 {
+        errs = append(errs, "stok_minimum must be >= 0")
+    }
+    if p.Status != prodmodel.StatusDraft && p.Status != prodmodel.StatusPendingReview {
+        errs = append(errs, "status harus draft atau pending_review")
+    }
     if len(errs) > 0 { return errors.New(strings.Join(errs, "; ")) }
     return nil
 }
\ No newline at end of file
@@ -0,0 +1,139 @@
+package toko
+
+import (
+	"errors"
+
+	model "project-evermos/internal/todo/model/toko"
+	repo "project-evermos/internal/todo/repository/toko"
+)
+
+// HeaderToko selects the store a member acts on in /toko/my/... and product
+// endpoints. Without it the user's own store is used, or their only
+// membership.
+const HeaderToko = "X-Toko-ID"
+
+// Permission is something a store role allows.
+type Permission string
+
+const (
+	PermView    Permission = "view"    // see the store's unpublished products, members
+	PermStore   Permission = "store"   // edit the store profile
+	PermCatalog Permission = "catalog" // products, stock, import/export, stock alerts
+	PermOrders  Permission = "orders"  // the store's orders
+	PermMembers Permission = "members" // invite and manage members
+)
+
+var rolePerms = map[string][]Permission{
+	model.RoleOwner:        {PermView, PermStore, PermCatalog, PermOrders, PermMembers},
+	model.RoleManager:      {PermView, PermStore, PermCatalog, PermOrders, PermMembers},
+	model.RoleStaffOrders:  {PermView, PermOrders},
+	model.RoleStaffCatalog: {PermView, PermCatalog},
+}
+
+// ErrTokoRequired is returned when a user belongs to several stores and did
+// not choose one with HeaderToko.
+var ErrTokoRequired = errors.New("pilih toko dengan header " + HeaderToko)
+
+// ValidRole reports whether role can be granted by invitation.
+func ValidRole(role string) bool {
+	_, ok := rolePerms[role]
+	return ok && role != model.RoleOwner
+}
+
+// Can reports whether role allows perm.
+func Can(role string, perm Permission) bool {
+	for _, p := range rolePerms[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// Access authorizes what users may do in stores. It replaces comparing
+// toko.id_user with the requester: the owner and members with a role that
+// allows the permission pass.
+type Access struct {
+	repo *repo.Repository
+}
+
+func NewAccess(r *repo.Repository) *Access { return &Access{repo: r} }
+
+// Role returns the role of a user in a store, "" when they do not belong to
+// it. Returns ErrNotFound for an unknown store.
+func (a *Access) Role(tokoID, userID uint) (string, error) {
+	t, err := a.repo.FindByID(tokoID)
+	if err != nil {
+		return "", err
+	}
+	if t == nil {
+		return "", ErrNotFound
+	}
+	return a.role(t, userID)
+}
+
+func (a *Access) role(t *model.Toko, userID uint) (string, error) {
+	if t.IDUser == userID {
+		return model.RoleOwner, nil
+	}
+	m, err := a.repo.FindMember(t.ID, userID)
+	if err != nil || m == nil {
+		return "", err
+	}
+	return m.Role, nil
+}
+
+// Authorize checks that a user may perm in a store and returns their role.
+// Returns ErrNotFound for an unknown store and ErrForbidden otherwise.
+func (a *Access) Authorize(tokoID, userID uint, perm Permission) (string, error) {
+	role, err := a.Role(tokoID, userID)
+	if err != nil {
+		return "", err
+	}
+	if !Can(role, perm) {
+		return "", ErrForbidden
+	}
+	return role, nil
+}
+
+// Store resolves the store a user acts on: tokoID when set (from
+// HeaderToko), else the store they own, else their only membership. Returns
+// ErrNotFound when they have none, ErrTokoRequired when the choice is
+// ambiguous and ErrForbidden when their role lacks perm.
+func (a *Access) Store(userID, tokoID uint, perm Permission) (*model.Toko, string, error) {
+	var t *model.Toko
+	var role string
+	if tokoID != 0 {
+		found, err := a.repo.FindByID(tokoID)
+		if err != nil {
+			return nil, "", err
+		}
+		if found == nil {
+			return nil, "", ErrNotFound
+		}
+		if role, err = a.role(found, userID); err != nil {
+			return nil, "", err
+		}
+		if role == "" {
+			return nil, "", ErrForbidden
+		}
+		t = found
+	} else {
+		ms, err := a.repo.ListMemberships(userID)
+		if err != nil {
+			return nil, "", err
+		}
+		switch {
+		case len(ms) == 0:
+			return nil, "", ErrNotFound
+		case ms[0].Role == model.RoleOwner, len(ms) == 1:
+			t, role = &ms[0].Toko, ms[0].Role
+		default:
+			return nil, "", ErrTokoRequired
+		}
+	}
+	if !Can(role, perm) {
+		return nil, "", ErrForbidden
+	}
+	return t, role, nil
+}
@@ -0,0 +1,134 @@
+package toko
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	addrmodel "project-evermos/internal/todo/model/address"
+	model "project-evermos/internal/todo/model/toko"
+	addrsvc "project-evermos/internal/todo/service/address"
+)
+
+var (
+	// ErrWilayah is returned when id_provinsi/id_kota are unknown or do not match
+	ErrWilayah = errors.New("id_kota tidak ditemukan di id_provinsi")
+	// ErrWilayahUnavailable is returned when the address service cannot be reached
+	ErrWilayahUnavailable = errors.New("data wilayah tidak dapat dicek, coba lagi")
+
+	reKodePos = regexp.MustCompile(`^[0-9]{5}$`)
+	reWilayah = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// Wilayah looks up provinces and cities; implemented by address.Service.
+type Wilayah interface {
+	DetailProvince(ctx context.Context, id string) (*addrmodel.Province, error)
+	DetailCity(ctx context.Context, id string) (*addrmodel.Regency, error)
+}
+
+// SetWilayah sets the address lookup used to validate origin addresses.
+func (s *Service) SetWilayah(w Wilayah) { s.wilayah = w }
+
+// AlamatAsalRequest sets the origin address of a toko.
+type AlamatAsalRequest struct {
+	IDProvinsi   string `json:"id_provinsi"`
+	IDKota       string `json:"id_kota"`
+	DetailAlamat string `json:"detail_alamat"`
+	KodePos      string `json:"kode_pos"`
+}
+
+// ShipsFrom is the public "ships from" label of a toko, nil without origin
+// address.
+func ShipsFrom(a model.AlamatAsal) map[string]interface{} {
+	if !a.Set() {
+		return nil
+	}
+	return map[string]interface{}{
+		"id_kota":       a.IDKota,
+		"nama_kota":     a.NamaKota,
+		"id_provinsi":   a.IDProvinsi,
+		"nama_provinsi": a.NamaProvinsi,
+	}
+}
+
+// alamatResp is the full origin address, for the toko's team; nil when
+// not set.
+func alamatResp(a model.AlamatAsal) map[string]interface{} {
+	out := ShipsFrom(a)
+	if out != nil {
+		out["detail_alamat"] = a.DetailAlamat
+		out["kode_pos"] = a.KodePos
+	}
+	return out
+}
+
+// UpdateAlamatAsal sets the origin address of a toko; the city must belong
+// to the province. Owners and managers may change it.
+func (s *Service) UpdateAlamatAsal(ctx context.Context, tokoID, userID uint, req AlamatAsalRequest) (map[string]interface{}, error) {
+	t, err := s.repo.FindByID(tokoID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrNotFound
+	}
+	if _, err := s.access.Authorize(tokoID, userID, PermStore); err != nil {
+		return nil, err
+	}
+	a := model.AlamatAsal{
+		IDProvinsi:   strings.TrimSpace(req.IDProvinsi),
+		IDKota:       strings.TrimSpace(req.IDKota),
+		DetailAlamat: strings.TrimSpace(req.DetailAlamat),
+		KodePos:      strings.TrimSpace(req.KodePos),
+	}
+	var errs []string
+	if a.IDProvinsi == "" || a.IDKota == "" {
+		errs = append(errs, "id_provinsi dan id_kota wajib diisi")
+	} else if !reWilayah.MatchString(a.IDProvinsi) || !reWilayah.MatchString(a.IDKota) {
+		errs = append(errs, "id_provinsi dan id_kota harus berupa angka")
+	}
+	if len(a.DetailAlamat) < 10 || len(a.DetailAlamat) > 255 {
+		errs = append(errs, "detail_alamat 10-255 karakter")
+	}
+	if a.KodePos != "" && !reKodePos.MatchString(a.KodePos) {
+		errs = append(errs, "kode_pos harus 5 digit")
+	}
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "; "))
+	}
+	if err := s.lookupWilayah(ctx, &a); err != nil {
+		return nil, err
+	}
+	t.AlamatAsal = a
+	t.UpdatedAt = time.Now()
+	if err := s.repo.Update(t); err != nil {
+		return nil, err
+	}
+	return alamatResp(a), nil
+}
+
+// lookupWilayah checks a's province and city with the address service and
+// fills in their names.
+func (s *Service) lookupWilayah(ctx context.Context, a *model.AlamatAsal) error {
+	if s.wilayah == nil {
+		return ErrWilayahUnavailable
+	}
+	city, err := s.wilayah.DetailCity(ctx, a.IDKota)
+	if err == nil && city.ProvinceID != a.IDProvinsi {
+		return ErrWilayah
+	}
+	var prov *addrmodel.Province
+	if err == nil {
+		prov, err = s.wilayah.DetailProvince(ctx, a.IDProvinsi)
+	}
+	switch {
+	case errors.Is(err, addrsvc.ErrNotFound):
+		return ErrWilayah
+	case err != nil:
+		return ErrWilayahUnavailable
+	}
+	a.NamaKota, a.NamaProvinsi = city.Name, prov.Name
+	return nil
+}
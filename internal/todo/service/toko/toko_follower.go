@@ -0,0 +1,40 @@
+package toko
+
+// Follow makes userID follow a toko and returns its follow state. Following
+// a toko already followed is not an error.
+func (s *Service) Follow(tokoID, userID uint) (map[string]interface{}, error) {
+	return s.setFollow(tokoID, userID, true)
+}
+
+// Unfollow stops userID following a toko and returns its follow state.
+func (s *Service) Unfollow(tokoID, userID uint) (map[string]interface{}, error) {
+	return s.setFollow(tokoID, userID, false)
+}
+
+func (s *Service) setFollow(tokoID, userID uint, follow bool) (map[string]interface{}, error) {
+	t, err := s.repo.FindByID(tokoID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrNotFound
+	}
+	if follow {
+		err = s.repo.Follow(tokoID, userID)
+	} else {
+		err = s.repo.Unfollow(tokoID, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	n, err := s.repo.Followers(tokoID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"id_toko": tokoID, "diikuti": follow, "pengikut": n}, nil
+}
+
+// IsFollowing reports whether userID follows a toko.
+func (s *Service) IsFollowing(tokoID, userID uint) (bool, error) {
+	return s.repo.IsFollowing(tokoID, userID)
+}
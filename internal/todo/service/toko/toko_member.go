@@ -0,0 +1,349 @@
+package toko
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	model "project-evermos/internal/todo/model/toko"
+	repo "project-evermos/internal/todo/repository/toko"
+)
+
+// InvitationTTL is how long an invitation can be answered.
+const InvitationTTL = 7 * 24 * time.Hour
+
+var (
+	// ErrInvited is returned when kontak already has a pending invitation
+	ErrInvited = errors.New("kontak sudah memiliki undangan yang menunggu jawaban")
+	// ErrMember is returned when inviting someone who already belongs to the store
+	ErrMember = errors.New("user sudah menjadi anggota toko")
+	// ErrInvitationClosed is returned when answering an answered, cancelled or expired invitation
+	ErrInvitationClosed = errors.New("undangan sudah tidak berlaku")
+
+	rePhone = regexp.MustCompile(`^\+?[0-9]{8,15}$`)
+)
+
+// Member is a store member as shown to the team.
+type Member struct {
+	IDUser    uint      `json:"id_user"`
+	Nama      string    `json:"nama"`
+	Email     string    `json:"email"`
+	NoTelp    string    `json:"notelp"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Invitation is a pending invitation. Toko is set for the invitee.
+type Invitation struct {
+	ID        uint                   `json:"id"`
+	Kontak    string                 `json:"kontak"`
+	Role      string                 `json:"role"`
+	Status    string                 `json:"status"`
+	ExpiresAt time.Time              `json:"expires_at"`
+	CreatedAt time.Time              `json:"created_at"`
+	Toko      map[string]interface{} `json:"toko,omitempty"`
+}
+
+func toInvitation(inv *model.Invitation) Invitation {
+	out := Invitation{ID: inv.ID, Kontak: inv.Kontak, Role: inv.Role, Status: inv.Status, ExpiresAt: inv.ExpiresAt, CreatedAt: inv.CreatedAt}
+	if inv.Toko != nil {
+		out.Toko = map[string]interface{}{
+			"id":        inv.Toko.ID,
+			"nama_toko": strings.TrimSpace(inv.Toko.NamaToko),
+			"slug":      inv.Toko.Slug,
+			"url_foto":  strings.TrimSpace(inv.Toko.UrlFoto),
+		}
+	}
+	return out
+}
+
+// normalizeKontak returns an email in lower case or a phone number without
+// spaces and dashes; "" when kontak is neither.
+func normalizeKontak(kontak string) string {
+	k := strings.TrimSpace(kontak)
+	if strings.Contains(k, "@") {
+		k = strings.ToLower(k)
+		if i := strings.LastIndex(k, "@"); i > 0 && i < len(k)-1 && !strings.ContainsAny(k, " ") {
+			return k
+		}
+		return ""
+	}
+	k = strings.NewReplacer(" ", "", "-", "").Replace(k)
+	if rePhone.MatchString(k) {
+		return k
+	}
+	return ""
+}
+
+// canManage reports whether actorRole may grant or change role: only the
+// owner manages managers.
+func canManage(actorRole, role string) bool {
+	if !Can(actorRole, PermMembers) {
+		return false
+	}
+	return role != model.RoleManager || actorRole == model.RoleOwner
+}
+
+// MyStores lists the stores a user owns or helps run, with their role.
+func (s *Service) MyStores(userID uint) ([]map[string]interface{}, error) {
+	ms, err := s.repo.ListMemberships(userID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]map[string]interface{}, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, map[string]interface{}{
+			"id":        m.Toko.ID,
+			"nama_toko": strings.TrimSpace(m.Toko.NamaToko),
+			"slug":      m.Toko.Slug,
+			"url_foto":  strings.TrimSpace(m.Toko.UrlFoto),
+			"verified":  m.Toko.Verified,
+			"role":      m.Role,
+		})
+	}
+	return out, nil
+}
+
+// ListMembers returns the owner and members of a store; any member may see
+// the team.
+func (s *Service) ListMembers(tokoID, userID uint) ([]Member, error) {
+	if _, err := s.access.Authorize(tokoID, userID, PermView); err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.ListMembers(tokoID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Member, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, Member(r))
+	}
+	return out, nil
+}
+
+// Invite invites the user with email or phone kontak to a store. Owners
+// and managers invite; only the owner invites managers.
+func (s *Service) Invite(tokoID, userID uint, kontak, role string) (*Invitation, error) {
+	actorRole, err := s.access.Authorize(tokoID, userID, PermMembers)
+	if err != nil {
+		return nil, err
+	}
+	k := normalizeKontak(kontak)
+	if k == "" {
+		return nil, errors.New("kontak harus email atau no telp yang valid")
+	}
+	if !ValidRole(role) {
+		return nil, errors.New("role harus manager, staff-orders atau staff-catalog")
+	}
+	if !canManage(actorRole, role) {
+		return nil, ErrForbidden
+	}
+	inviteeID, err := s.repo.FindUserIDByKontak(k)
+	if err != nil {
+		return nil, err
+	}
+	if inviteeID != 0 {
+		existing, err := s.access.Role(tokoID, inviteeID)
+		if err != nil {
+			return nil, err
+		}
+		if existing != "" {
+			return nil, ErrMember
+		}
+	}
+	now := time.Now()
+	pending, err := s.repo.FindPendingInvitation(tokoID, k, now)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, ErrInvited
+	}
+	inv := &model.Invitation{
+		IDToko:    tokoID,
+		Kontak:    k,
+		Role:      role,
+		Status:    model.InvitePending,
+		InvitedBy: &userID,
+		ExpiresAt: now.Add(InvitationTTL),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.CreateInvitation(inv, inviteeID); err != nil {
+		return nil, err
+	}
+	out := toInvitation(inv)
+	return &out, nil
+}
+
+// ListInvitations returns the pending invitations of a store.
+func (s *Service) ListInvitations(tokoID, userID uint) ([]Invitation, error) {
+	if _, err := s.access.Authorize(tokoID, userID, PermMembers); err != nil {
+		return nil, err
+	}
+	items, err := s.repo.ListInvitations(tokoID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Invitation, 0, len(items))
+	for i := range items {
+		out = append(out, toInvitation(&items[i]))
+	}
+	return out, nil
+}
+
+// CancelInvitation withdraws a pending invitation of a store.
+func (s *Service) CancelInvitation(tokoID, userID, id uint) error {
+	if _, err := s.access.Authorize(tokoID, userID, PermMembers); err != nil {
+		return err
+	}
+	ok, err := s.repo.CancelInvitation(tokoID, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// MyInvitations returns the pending invitations sent to a user's email or
+// phone.
+func (s *Service) MyInvitations(userID uint) ([]Invitation, error) {
+	kontak, err := s.userKontak(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(kontak) == 0 {
+		return []Invitation{}, nil
+	}
+	items, err := s.repo.ListInvitationsByKontak(kontak, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Invitation, 0, len(items))
+	for i := range items {
+		out = append(out, toInvitation(&items[i]))
+	}
+	return out, nil
+}
+
+// AnswerInvitation accepts or declines an invitation sent to the user's
+// email or phone. Accepting makes them a member with the invited role.
+func (s *Service) AnswerInvitation(userID, id uint, accept bool) (*Invitation, error) {
+	inv, err := s.repo.FindInvitation(id)
+	if err != nil {
+		return nil, err
+	}
+	kontak, err := s.userKontak(userID)
+	if err != nil {
+		return nil, err
+	}
+	if inv == nil || !contains(kontak, inv.Kontak) {
+		return nil, ErrNotFound
+	}
+	if inv.Status != model.InvitePending || !inv.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvitationClosed
+	}
+	if accept && inv.Toko != nil && inv.Toko.IDUser == userID {
+		return nil, ErrMember
+	}
+	if err := s.repo.AnswerInvitation(inv, userID, accept); err != nil {
+		if errors.Is(err, repo.ErrInvitationClosed) {
+			return nil, ErrInvitationClosed
+		}
+		return nil, err
+	}
+	inv.Status = model.InviteDeclined
+	if accept {
+		inv.Status = model.InviteAccepted
+	}
+	out := toInvitation(inv)
+	return &out, nil
+}
+
+// userKontak returns the normalized email and phone of a user.
+func (s *Service) userKontak(userID uint) ([]string, error) {
+	email, noTelp, err := s.repo.UserKontak(userID)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, k := range []string{email, noTelp} {
+		if n := normalizeKontak(k); n != "" {
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
+
+func contains(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateMember changes the role of a member. The owner's role cannot be
+// changed and only the owner changes managers.
+func (s *Service) UpdateMember(tokoID, userID, memberID uint, role string) error {
+	actorRole, err := s.access.Authorize(tokoID, userID, PermMembers)
+	if err != nil {
+		return err
+	}
+	if !ValidRole(role) {
+		return errors.New("role harus manager, staff-orders atau staff-catalog")
+	}
+	cur, err := s.access.Role(tokoID, memberID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case cur == "":
+		return ErrNotFound
+	case cur == model.RoleOwner, !canManage(actorRole, cur), !canManage(actorRole, role):
+		return ErrForbidden
+	}
+	ok, err := s.repo.UpdateMemberRole(tokoID, memberID, role)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RemoveMember removes a member from a store. Members may leave on their
+// own; the owner cannot be removed and only the owner removes managers.
+func (s *Service) RemoveMember(tokoID, userID, memberID uint) error {
+	var actorRole string
+	if memberID != userID {
+		r, err := s.access.Authorize(tokoID, userID, PermMembers)
+		if err != nil {
+			return err
+		}
+		actorRole = r
+	}
+	cur, err := s.access.Role(tokoID, memberID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case cur == "":
+		return ErrNotFound
+	case cur == model.RoleOwner, memberID != userID && !canManage(actorRole, cur):
+		return ErrForbidden
+	}
+	ok, err := s.repo.DeleteMember(tokoID, memberID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	return nil
+}
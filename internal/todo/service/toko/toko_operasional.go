@@ -0,0 +1,233 @@
+package toko
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	model "project-evermos/internal/todo/model/toko"
+)
+
+// MaxPesanLibur is the max length of the vacation message.
+const MaxPesanLibur = 255
+
+// zones are the Indonesian time zones a toko keeps its hours in.
+var zones = map[string]*time.Location{
+	"WIB":  time.FixedZone("WIB", 7*3600),
+	"WITA": time.FixedZone("WITA", 8*3600),
+	"WIT":  time.FixedZone("WIT", 9*3600),
+}
+
+// Jam is one opening period of a week day (0 = Minggu ... 6 = Sabtu). Buka
+// and Tutup are "HH:MM" in the toko's time zone; Tutup "24:00" closes at
+// midnight. A day may have several periods; a day without any is closed.
+type Jam struct {
+	Hari  int    `json:"hari"`
+	Buka  string `json:"buka"`
+	Tutup string `json:"tutup"`
+}
+
+// Operasional are the opening hours and vacation settings of a toko as
+// shown to its team and sent to update them.
+type Operasional struct {
+	JamOperasional []Jam      `json:"jam_operasional"`
+	ZonaWaktu      string     `json:"zona_waktu"`
+	Libur          bool       `json:"libur"`
+	LiburSampai    *time.Time `json:"libur_sampai"`
+	PesanLibur     string     `json:"pesan_libur"`
+}
+
+// Status tells whether a toko is open now. BukaLagi is when it opens next,
+// nil when open or on vacation without end date.
+type Status struct {
+	Buka     bool       `json:"buka"`
+	Libur    bool       `json:"libur"`
+	Pesan    string     `json:"pesan,omitempty"`
+	BukaLagi *time.Time `json:"buka_lagi"`
+}
+
+// ParseJam decodes stored weekly hours; invalid JSON reads as none.
+func ParseJam(s string) []Jam {
+	var out []Jam
+	if strings.TrimSpace(s) == "" || json.Unmarshal([]byte(s), &out) != nil {
+		return []Jam{}
+	}
+	return out
+}
+
+// minutes parses "HH:MM" into minutes since midnight.
+func minutes(hhmm string) (int, bool) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		if hhmm == "24:00" {
+			return 24 * 60, true
+		}
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+// validateJam checks and sorts weekly hours.
+func validateJam(jam []Jam) ([]Jam, error) {
+	out := make([]Jam, 0, len(jam))
+	for _, j := range jam {
+		j.Buka, j.Tutup = strings.TrimSpace(j.Buka), strings.TrimSpace(j.Tutup)
+		if j.Hari < 0 || j.Hari > 6 {
+			return nil, errors.New("hari harus 0 (Minggu) sampai 6 (Sabtu)")
+		}
+		b, okB := minutes(j.Buka)
+		t, okT := minutes(j.Tutup)
+		if !okB || !okT || b >= 24*60 {
+			return nil, errors.New("jam buka/tutup harus berformat HH:MM")
+		}
+		if t <= b {
+			return nil, fmt.Errorf("jam tutup harus setelah jam buka (hari %d)", j.Hari)
+		}
+		out = append(out, j)
+	}
+	sort.SliceStable(out, func(a, b int) bool {
+		if out[a].Hari != out[b].Hari {
+			return out[a].Hari < out[b].Hari
+		}
+		return out[a].Buka < out[b].Buka
+	})
+	for i := 1; i < len(out); i++ {
+		if out[i].Hari == out[i-1].Hari && out[i].Buka < out[i-1].Tutup {
+			return nil, fmt.Errorf("jam operasional hari %d saling tumpang tindih", out[i].Hari)
+		}
+	}
+	return out, nil
+}
+
+// Location returns the time zone of a toko's hours, WIB when unknown.
+func Location(zona string) *time.Location {
+	if loc, ok := zones[zona]; ok {
+		return loc
+	}
+	return zones["WIB"]
+}
+
+// onVacation reports whether o is on vacation at now; a vacation with an
+// end date ends by itself.
+func onVacation(o model.Operasional, now time.Time) bool {
+	return o.Libur && (o.LiburSampai == nil || now.Before(*o.LiburSampai))
+}
+
+// StatusOf tells whether a toko with settings o is open at now.
+func StatusOf(o model.Operasional, now time.Time) Status {
+	if onVacation(o, now) {
+		st := Status{Libur: true, Pesan: o.PesanLibur}
+		if o.LiburSampai != nil {
+			st.BukaLagi = nextOpen(ParseJam(o.JamOperasionalJSON), Location(o.ZonaWaktu), *o.LiburSampai)
+		}
+		return st
+	}
+	jam := ParseJam(o.JamOperasionalJSON)
+	next := nextOpen(jam, Location(o.ZonaWaktu), now)
+	if next != nil && !next.After(now) {
+		return Status{Buka: true}
+	}
+	return Status{BukaLagi: next}
+}
+
+// nextOpen returns the first moment at or after from when the toko is open,
+// nil when its hours have no opening at all. Without hours it is from.
+func nextOpen(jam []Jam, loc *time.Location, from time.Time) *time.Time {
+	if len(jam) == 0 {
+		return &from
+	}
+	local := from.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	for d := 0; d <= 7; d++ {
+		date := day.AddDate(0, 0, d)
+		for _, j := range jam {
+			if j.Hari != int(date.Weekday()) {
+				continue
+			}
+			b, _ := minutes(j.Buka)
+			t, _ := minutes(j.Tutup)
+			start := date.Add(time.Duration(b) * time.Minute)
+			end := date.Add(time.Duration(t) * time.Minute)
+			if !end.After(local) {
+				continue
+			}
+			if start.Before(local) {
+				return &from
+			}
+			return &start
+		}
+	}
+	return nil
+}
+
+// UpdateOperasional replaces the opening hours and vacation settings of a
+// toko. Owners and managers may change them.
+func (s *Service) UpdateOperasional(tokoID, userID uint, req Operasional) (map[string]interface{}, error) {
+	t, err := s.repo.FindByID(tokoID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrNotFound
+	}
+	if _, err := s.access.Authorize(tokoID, userID, PermStore); err != nil {
+		return nil, err
+	}
+	jam, err := validateJam(req.JamOperasional)
+	if err != nil {
+		return nil, err
+	}
+	zona := strings.ToUpper(strings.TrimSpace(req.ZonaWaktu))
+	if zona == "" {
+		zona = "WIB"
+	}
+	if _, ok := zones[zona]; !ok {
+		return nil, errors.New("zona_waktu harus WIB, WITA atau WIT")
+	}
+	pesan := strings.TrimSpace(req.PesanLibur)
+	if len(pesan) > MaxPesanLibur {
+		return nil, fmt.Errorf("pesan_libur maksimal %d karakter", MaxPesanLibur)
+	}
+	now := time.Now()
+	if req.Libur && req.LiburSampai != nil && !req.LiburSampai.After(now) {
+		return nil, errors.New("libur_sampai harus di masa depan")
+	}
+	o := model.Operasional{ZonaWaktu: zona, Libur: req.Libur, PesanLibur: pesan}
+	if len(jam) > 0 {
+		b, _ := json.Marshal(jam)
+		o.JamOperasionalJSON = string(b)
+	}
+	if req.Libur {
+		o.LiburSampai = req.LiburSampai
+	}
+	t.Operasional = o
+	t.UpdatedAt = now
+	if err := s.repo.Update(t); err != nil {
+		return nil, err
+	}
+	return operasionalResp(o, now), nil
+}
+
+// operasionalResp renders the settings of a toko with its status at now.
+func operasionalResp(o model.Operasional, now time.Time) map[string]interface{} {
+	libur := onVacation(o, now)
+	var sampai *time.Time
+	if libur {
+		sampai = o.LiburSampai
+	}
+	zona := o.ZonaWaktu
+	if _, ok := zones[zona]; !ok {
+		zona = "WIB"
+	}
+	return map[string]interface{}{
+		"jam_operasional": ParseJam(o.JamOperasionalJSON),
+		"zona_waktu":      zona,
+		"libur":           libur,
+		"libur_sampai":    sampai,
+		"pesan_libur":     o.PesanLibur,
+		"status":          StatusOf(o, now),
+	}
+}
@@ -2,9 +2,12 @@ package toko
 
 import (
 	"errors"
+	"math"
 	"strings"
 	"time"
 
+	"project-evermos/internal/media"
+	model "project-evermos/internal/todo/model/toko"
 	repo "project-evermos/internal/todo/repository/toko"
 )
 
@@ -16,29 +19,39 @@ var (
 
 // Service contains business logic for toko domain.
 type Service struct {
-	repo *repo.Repository
+	repo    *repo.Repository
+	access  *Access
+	wilayah Wilayah
 }
 
-func NewService(r *repo.Repository) *Service { return &Service{repo: r} }
+func NewService(r *repo.Repository) *Service { return &Service{repo: r, access: NewAccess(r)} }
 
-// GetMyStore returns the store owned by the given userID.
-func (s *Service) GetMyStore(userID uint) (map[string]interface{}, error) {
-	t, err := s.repo.FindByUserID(userID)
+// GetMyStore returns the store the given userID acts on (see Access.Store)
+// with their role there; nil when they have no store.
+func (s *Service) GetMyStore(userID, tokoID uint) (map[string]interface{}, error) {
+	t, role, err := s.access.Store(userID, tokoID, PermView)
+	if errors.Is(err, ErrNotFound) && tokoID == 0 {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	if t == nil {
-		return nil, nil
-	}
 	return map[string]interface{}{
 		"id":        t.ID,
 		"nama_toko": strings.TrimSpace(t.NamaToko),
+		"slug":      t.Slug,
 		"url_foto":  strings.TrimSpace(t.UrlFoto),
+		"foto_sizes": media.ParseSizes(t.FotoSizesJSON),
+		"role":       role,
+		"operasional": operasionalResp(t.Operasional, time.Now()),
+		"alamat_asal": alamatResp(t.AlamatAsal),
+		"verified":    t.Verified,
 	}, nil
 }
 
-// UpdateStore updates store by id. Only owner can update.
-func (s *Service) UpdateStore(id uint, userID uint, nama string, urlFoto string) error {
+// UpdateStore updates store by id. Only the owner and managers can update.
+// sizes is set when the photo was uploaded and processed; a photo given as URL has none.
+func (s *Service) UpdateStore(id uint, userID uint, nama string, urlFoto string, sizes media.Sizes) error {
 	t, err := s.repo.FindByID(id)
 	if err != nil {
 		return err
@@ -46,22 +59,31 @@ func (s *Service) UpdateStore(id uint, userID uint, nama string, urlFoto string)
 	if t == nil {
 		return ErrNotFound
 	}
-	if t.IDUser != userID {
-		return ErrForbidden
+	if _, err := s.access.Authorize(id, userID, PermStore); err != nil {
+		return err
 	}
 	if len(strings.TrimSpace(nama)) < 3 {
 		return errors.New("nama_toko minimal 3 karakter")
 	}
-	t.NamaToko = strings.TrimSpace(nama)
+	if nama = strings.TrimSpace(nama); nama != t.NamaToko {
+		t.NamaToko = nama
+		// the old slug keeps redirecting to the new one
+		sl, err := s.repo.UniqueSlug(nama, t.ID)
+		if err != nil {
+			return err
+		}
+		t.Slug = sl
+	}
 	// Only update photo when provided (non-empty)
 	if strings.TrimSpace(urlFoto) != "" {
 		t.UrlFoto = strings.TrimSpace(urlFoto)
+		t.FotoSizesJSON = sizes.JSON()
 	}
 	t.UpdatedAt = time.Now()
 	return s.repo.Update(t)
 }
 
-// GetByID returns store by id. If public access, omit user_id.
+// GetByID returns the store page of a store by id. If public access, omit user_id.
 func (s *Service) GetByID(id uint, public bool, requesterUserID uint) (map[string]interface{}, error) {
 	t, err := s.repo.FindByID(id)
 	if err != nil {
@@ -70,35 +92,91 @@ func (s *Service) GetByID(id uint, public bool, requesterUserID uint) (map[strin
 	if t == nil {
 		return nil, ErrNotFound
 	}
-	if !public && t.IDUser != requesterUserID {
-		return nil, ErrForbidden
+	if !public {
+		if _, err := s.access.Authorize(id, requesterUserID, PermView); err != nil {
+			return nil, err
+		}
 	}
-	resp := map[string]interface{}{
-		"id":        t.ID,
-		"nama_toko": strings.TrimSpace(t.NamaToko),
-		"url_foto":  strings.TrimSpace(t.UrlFoto),
+	return s.storePage(t)
+}
+
+// storePage renders the public page of a store: its info, join date and
+// stats (published products, units sold, average rating, followers).
+func (s *Service) storePage(t *model.Toko) (map[string]interface{}, error) {
+	st, err := s.repo.Stats(t.ID)
+	if err != nil {
+		return nil, err
+	}
+	var rating interface{}
+	if st.Rating != nil {
+		rating = math.Round(*st.Rating*10) / 10
+	}
+	return map[string]interface{}{
+		"id":         t.ID,
+		"nama_toko":  strings.TrimSpace(t.NamaToko),
+		"slug":       t.Slug,
+		"url_foto":   strings.TrimSpace(t.UrlFoto),
+		"foto_sizes": media.ParseSizes(t.FotoSizesJSON),
+		"bergabung":  t.CreatedAt,
+		"operasional": operasionalResp(t.Operasional, time.Now()),
+		"dikirim_dari": ShipsFrom(t.AlamatAsal),
+		"verified":     t.Verified,
+		"stats": map[string]interface{}{
+			"jumlah_produk": st.JumlahProduk,
+			"total_terjual": st.TotalTerjual,
+			"rating":        rating,
+			"jumlah_ulasan": st.JumlahUlasan,
+			"pengikut":      st.Pengikut,
+		},
+	}, nil
+}
+
+// GetBySlug returns the store page of a store by slug. When slug is a
+// former slug, the data is nil and the current slug is returned instead.
+func (s *Service) GetBySlug(sl string) (map[string]interface{}, string, error) {
+	t, err := s.repo.FindBySlug(sl)
+	if err != nil {
+		return nil, "", err
+	}
+	if t == nil {
+		cur, err := s.repo.CurrentSlugByOld(sl)
+		if err != nil {
+			return nil, "", err
+		}
+		if cur == "" {
+			return nil, "", ErrNotFound
+		}
+		return nil, cur, nil
 	}
-	return resp, nil
+	data, err := s.storePage(t)
+	return data, "", err
 }
 
-// List returns paginated stores.
-func (s *Service) List(limit, page int, name string) (map[string]interface{}, error) {
+// List returns paginated stores; verified, when set, keeps only verified
+// (or unverified) stores.
+func (s *Service) List(limit, page int, name string, verified *bool) (map[string]interface{}, error) {
 	if limit <= 0 {
 		limit = 10
 	}
 	if page <= 0 {
 		page = 1
 	}
-	items, total, err := s.repo.List(limit, page, name)
+	items, total, err := s.repo.List(limit, page, name, verified)
 	if err != nil {
 		return nil, err
 	}
+	now := time.Now()
 	respItems := make([]map[string]interface{}, 0, len(items))
 	for _, t := range items {
 		respItems = append(respItems, map[string]interface{}{
 			"id":        t.ID,
 			"nama_toko": strings.TrimSpace(t.NamaToko),
+			"slug":      t.Slug,
 			"url_foto":  strings.TrimSpace(t.UrlFoto),
+			"foto_sizes": media.ParseSizes(t.FotoSizesJSON),
+			"buka":       StatusOf(t.Operasional, now).Buka,
+			"dikirim_dari": ShipsFrom(t.AlamatAsal),
+			"verified":     t.Verified,
 		})
 	}
 	return map[string]interface{}{
@@ -0,0 +1,245 @@
+package toko
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	model "project-evermos/internal/todo/model/toko"
+	repo "project-evermos/internal/todo/repository/toko"
+)
+
+// MaxDokumen is the max number of documents of a verification request.
+const MaxDokumen = 5
+
+var (
+	// ErrVerified is returned when a verified store asks for verification
+	ErrVerified = errors.New("toko sudah terverifikasi")
+	// ErrVerificationPending is returned when a request is already waiting for review
+	ErrVerificationPending = errors.New("pengajuan verifikasi sebelumnya masih menunggu review")
+	// ErrVerificationClosed is returned when reviewing a request that was already reviewed
+	ErrVerificationClosed = errors.New("pengajuan verifikasi sudah direview")
+	// ErrVerifyStatus is returned for an unknown verification status filter
+	ErrVerifyStatus = errors.New("status harus pending, approved, rejected atau all")
+)
+
+// Dokumen is an uploaded verification document. Key is its private storage
+// key; URL is a short-lived link filled in by the handler.
+type Dokumen struct {
+	Nama        string `json:"nama"`
+	ContentType string `json:"content_type"`
+	Ukuran      int64  `json:"ukuran"`
+	Key         string `json:"-"`
+	URL         string `json:"url,omitempty"`
+}
+
+// dokumenJSON is how a Dokumen is stored in toko_verifikasi.dokumen_json.
+type dokumenJSON struct {
+	Nama        string `json:"nama"`
+	ContentType string `json:"content_type"`
+	Ukuran      int64  `json:"ukuran"`
+	Key         string `json:"key"`
+}
+
+// Verification is a verification request. Toko is set in the admin queue.
+type Verification struct {
+	ID         uint                   `json:"id"`
+	Status     string                 `json:"status"`
+	Catatan    string                 `json:"catatan"`
+	Alasan     string                 `json:"alasan"`
+	Dokumen    []Dokumen              `json:"dokumen"`
+	ReviewedAt *time.Time             `json:"reviewed_at"`
+	CreatedAt  time.Time              `json:"created_at"`
+	Toko       map[string]interface{} `json:"toko,omitempty"`
+}
+
+// VerificationState is the badge of a store with its latest request, nil
+// when it never asked.
+type VerificationState struct {
+	Verified   bool          `json:"verified"`
+	VerifiedAt *time.Time    `json:"verified_at"`
+	Pengajuan  *Verification `json:"pengajuan"`
+}
+
+// VerificationPage is a page of the admin review queue.
+type VerificationPage struct {
+	Items []Verification
+	Total int64
+	Limit int
+	Page  int
+}
+
+func toVerification(v *model.Verification) Verification {
+	var stored []dokumenJSON
+	_ = json.Unmarshal([]byte(v.DokumenJSON), &stored)
+	docs := make([]Dokumen, 0, len(stored))
+	for _, d := range stored {
+		docs = append(docs, Dokumen{Nama: d.Nama, ContentType: d.ContentType, Ukuran: d.Ukuran, Key: d.Key})
+	}
+	out := Verification{ID: v.ID, Status: v.Status, Catatan: v.Catatan, Alasan: v.Alasan, Dokumen: docs,
+		ReviewedAt: v.ReviewedAt, CreatedAt: v.CreatedAt}
+	if v.Toko != nil {
+		out.Toko = map[string]interface{}{
+			"id":        v.Toko.ID,
+			"nama_toko": strings.TrimSpace(v.Toko.NamaToko),
+			"slug":      v.Toko.Slug,
+			"url_foto":  strings.TrimSpace(v.Toko.UrlFoto),
+			"verified":  v.Toko.Verified,
+		}
+	}
+	return out
+}
+
+// CanRequestVerification checks that the user may ask verification for a
+// store now: owners and managers of an unverified store without a pending
+// request. Handlers call it before storing the documents.
+func (s *Service) CanRequestVerification(tokoID, userID uint) error {
+	t, err := s.repo.FindByID(tokoID)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return ErrNotFound
+	}
+	if _, err := s.access.Authorize(tokoID, userID, PermStore); err != nil {
+		return err
+	}
+	if t.Verified {
+		return ErrVerified
+	}
+	v, err := s.repo.LatestVerification(tokoID)
+	if err != nil {
+		return err
+	}
+	if v != nil && v.Status == model.VerifyPending {
+		return ErrVerificationPending
+	}
+	return nil
+}
+
+// RequestVerification sends the documents of a store to the admin review
+// queue. Owners and managers may ask; a store has one pending request at a
+// time.
+func (s *Service) RequestVerification(tokoID, userID uint, catatan string, docs []Dokumen) (*Verification, error) {
+	if err := s.CanRequestVerification(tokoID, userID); err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, errors.New("dokumen wajib diunggah")
+	}
+	if len(docs) > MaxDokumen {
+		return nil, fmt.Errorf("dokumen maksimal %d file", MaxDokumen)
+	}
+	catatan = strings.TrimSpace(catatan)
+	if len(catatan) > 500 {
+		return nil, errors.New("catatan max 500 char")
+	}
+	stored := make([]dokumenJSON, 0, len(docs))
+	for _, d := range docs {
+		stored = append(stored, dokumenJSON{Nama: d.Nama, ContentType: d.ContentType, Ukuran: d.Ukuran, Key: d.Key})
+	}
+	b, _ := json.Marshal(stored)
+	now := time.Now()
+	v := &model.Verification{IDToko: tokoID, IDUser: userID, Status: model.VerifyPending, DokumenJSON: string(b),
+		Catatan: catatan, CreatedAt: now, UpdatedAt: now}
+	if err := s.repo.CreateVerification(v); err != nil {
+		if errors.Is(err, repo.ErrVerificationPending) {
+			return nil, ErrVerificationPending
+		}
+		return nil, err
+	}
+	out := toVerification(v)
+	return &out, nil
+}
+
+// VerificationStatus returns the badge and latest request of a store; any
+// member may see it.
+func (s *Service) VerificationStatus(tokoID, userID uint) (*VerificationState, error) {
+	t, err := s.repo.FindByID(tokoID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrNotFound
+	}
+	if _, err := s.access.Authorize(tokoID, userID, PermView); err != nil {
+		return nil, err
+	}
+	v, err := s.repo.LatestVerification(tokoID)
+	if err != nil {
+		return nil, err
+	}
+	st := &VerificationState{Verified: t.Verified, VerifiedAt: t.VerifiedAt}
+	if v != nil {
+		out := toVerification(v)
+		st.Pengajuan = &out
+	}
+	return st, nil
+}
+
+// ListVerifications lists verification requests for admins; status
+// defaults to pending, "all" lists every status.
+func (s *Service) ListVerifications(status string, limit, page int) (*VerificationPage, error) {
+	switch status {
+	case "":
+		status = model.VerifyPending
+	case "all":
+		status = ""
+	case model.VerifyPending, model.VerifyApproved, model.VerifyRejected:
+	default:
+		return nil, ErrVerifyStatus
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if page <= 0 {
+		page = 1
+	}
+	rows, total, err := s.repo.ListVerifications(status, limit, page)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]Verification, 0, len(rows))
+	for i := range rows {
+		items = append(items, toVerification(&rows[i]))
+	}
+	return &VerificationPage{Items: items, Total: total, Limit: limit, Page: page}, nil
+}
+
+// ReviewVerification approves (granting the verified badge) or rejects a
+// pending request; a rejection needs a reason for the seller, who can send
+// a new request.
+func (s *Service) ReviewVerification(id, adminID uint, approve bool, alasan string) (*Verification, error) {
+	alasan = strings.TrimSpace(alasan)
+	switch {
+	case approve:
+		alasan = ""
+	case len(alasan) < 5:
+		return nil, errors.New("alasan min 5 char")
+	case len(alasan) > 500:
+		return nil, errors.New("alasan max 500 char")
+	}
+	v, err := s.repo.FindVerification(id)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, ErrNotFound
+	}
+	if err := s.repo.ReviewVerification(v, adminID, approve, alasan); err != nil {
+		if errors.Is(err, repo.ErrVerificationClosed) {
+			return nil, ErrVerificationClosed
+		}
+		return nil, err
+	}
+	if v, err = s.repo.FindVerification(id); err != nil {
+		return nil, err
+	}
+	out := toVerification(v)
+	return &out, nil
+}
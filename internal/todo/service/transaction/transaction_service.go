@@ -5,10 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	prodmodel "project-evermos/internal/todo/model/product"
+	tokomodel "project-evermos/internal/todo/model/toko"
 	trxmodel "project-evermos/internal/todo/model/transaction"
+	prodrepo "project-evermos/internal/todo/repository/product"
 	trxrepo "project-evermos/internal/todo/repository/transaction"
+	tokosvc "project-evermos/internal/todo/service/toko"
 
 	"gorm.io/gorm"
 )
@@ -36,8 +41,28 @@ type TrxItem struct {
 	MethodBayar string          `json:"method_bayar"`
 	AlamatKirim AlamatKirimResp `json:"alamat_kirim"`
 	DetailTrx   []DetailTrxResp `json:"detail_trx"`
+	// Paket is the parcel of every toko in the order, for courier rates
+	Paket []PaketResp `json:"paket"`
 }
 
+// PaketResp is what one toko ships for an order. Weights are in grams:
+// Berat is the actual weight, BeratVolumetrik the volume weight
+// (p x l x t / VolumetricDivisor) and BeratTagihan the larger of both,
+// which couriers charge for.
+type PaketResp struct {
+	IDToko          uint   `json:"id_toko"`
+	NamaToko        string `json:"nama_toko"`
+	AsalIDKota      string `json:"asal_id_kota"` // origin city, empty until the toko sets its address
+	AsalKota        string `json:"asal_kota"`
+	Kuantitas       int    `json:"kuantitas"`
+	Berat           int    `json:"berat"`
+	BeratVolumetrik int    `json:"berat_volumetrik"`
+	BeratTagihan    int    `json:"berat_tagihan"`
+}
+
+// VolumetricDivisor converts a volume in cm3 to a volume weight in kg.
+const VolumetricDivisor = 6000
+
 type AlamatKirimResp struct {
 	ID           uint   `json:"id"`
 	JudulAlamat  string `json:"judul_alamat"`
@@ -47,22 +72,52 @@ type AlamatKirimResp struct {
 }
 
 type DetailTrxResp struct {
+	ID         uint        `json:"id"`
 	Product    ProductResp `json:"product"`
 	Toko       TokoResp    `json:"toko"`
 	Kuantitas  int         `json:"kuantitas"`
 	HargaTotal int         `json:"harga_total"`
+	// Ulasan is the buyer's rating of the item, nil until rated
+	Ulasan *UlasanResp `json:"ulasan"`
+}
+
+// UlasanResp is a buyer's rating of an order item.
+type UlasanResp struct {
+	ID        uint      `json:"id"`
+	Rating    int       `json:"rating"`
+	Komentar  *string   `json:"komentar"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type ProductResp struct {
-	ID            uint         `json:"id"`
-	NamaProduk    string       `json:"nama_produk"`
-	Slug          string       `json:"slug"`
-	HargaReseller int          `json:"harga_reseler"`
-	HargaKonsumen int          `json:"harga_konsumen"`
-	Deskripsi     string       `json:"deskripsi"`
-	Toko          TokoResp     `json:"toko"`
-	Category      CategoryResp `json:"category"`
-	Photos        []PhotoResp  `json:"photos"`
+	ID            uint           `json:"id"`
+	NamaProduk    string         `json:"nama_produk"`
+	Slug          string         `json:"slug"`
+	HargaReseller int            `json:"harga_reseler"`
+	HargaKonsumen int            `json:"harga_konsumen"`
+	Deskripsi     string         `json:"deskripsi"`
+	Toko          TokoResp       `json:"toko"`
+	Category      CategoryResp   `json:"category"`
+	Photos        []PhotoResp    `json:"photos"`
+	Variant       *VariantResp   `json:"variant,omitempty"`
+	FlashSale     *FlashSaleResp `json:"flash_sale,omitempty"`
+	Berat         int            `json:"berat"` // grams per unit
+	Panjang       int            `json:"panjang"`
+	Lebar         int            `json:"lebar"`
+	Tinggi        int            `json:"tinggi"`
+}
+
+type VariantResp struct {
+	ID   uint              `json:"id"`
+	SKU  string            `json:"sku"`
+	Nama string            `json:"nama"`
+	Opsi map[string]string `json:"opsi"`
+}
+
+// FlashSaleResp is the flash sale an order item was bought in.
+type FlashSaleResp struct {
+	ID          uint `json:"id"`
+	HargaNormal int  `json:"harga_normal"`
 }
 
 type TokoResp struct {
@@ -90,9 +145,54 @@ type CreateRequest struct {
 
 type CreateItemReq struct {
 	ProductID uint `json:"product_id"`
+	// VariantID is required when the product has variants
+	VariantID uint `json:"variant_id"`
 	Kuantitas int  `json:"kuantitas"`
 }
 
+// UlasanRequest rates one item of a transaction.
+type UlasanRequest struct {
+	IDDetailTrx uint   `json:"id_detail_trx"`
+	Rating      int    `json:"rating"`
+	Komentar    string `json:"komentar"`
+}
+
+// MaxKomentar caps the length of a rating comment.
+const MaxKomentar = 1000
+
+var (
+	// ErrSaleQuota is returned when a flash sale quota ran out during checkout.
+	ErrSaleQuota = errors.New("flash sale quota exhausted")
+	// ErrRated is returned when an order item already has a rating.
+	ErrRated = errors.New("already rated")
+	// ErrTokoClosed is returned when ordering from a toko that is closed or
+	// on vacation; the error is a *ClosedError.
+	ErrTokoClosed = errors.New("toko closed")
+)
+
+// ClosedError tells which toko of an order is closed and when it opens again.
+type ClosedError struct {
+	NamaToko string
+	Status   tokosvc.Status
+	Zona     *time.Location
+}
+
+func (e *ClosedError) Error() string {
+	msg := fmt.Sprintf("Toko %s sedang tutup", strings.TrimSpace(e.NamaToko))
+	if e.Status.Libur {
+		msg = fmt.Sprintf("Toko %s sedang libur", strings.TrimSpace(e.NamaToko))
+	}
+	if e.Status.BukaLagi != nil {
+		msg += ", buka lagi " + e.Status.BukaLagi.In(e.Zona).Format("02-01-2006 15:04 MST")
+	}
+	if e.Status.Pesan != "" {
+		msg += ": " + e.Status.Pesan
+	}
+	return msg
+}
+
+func (e *ClosedError) Unwrap() error { return ErrTokoClosed }
+
 // List returns user's transactions with pagination
 func (s *Service) List(userID uint, limit, page int) (*TrxListResponse, error) {
 	trxs, _, err := s.repo.ListTrxByUser(userID, limit, page)
@@ -112,6 +212,26 @@ func (s *Service) List(userID uint, limit, page int) (*TrxListResponse, error) {
 	return &TrxListResponse{Data: items, Page: page, Limit: limit}, nil
 }
 
+// ListForToko returns the transactions containing items of a toko, for its
+// seller. Only the toko's items are shown and HargaTotal is their sum.
+func (s *Service) ListForToko(tokoID uint, limit, page int) (*TrxListResponse, error) {
+	trxs, _, err := s.repo.ListTrxByToko(tokoID, limit, page)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]TrxItem, 0, len(trxs))
+	for _, t := range trxs {
+		item, err := s.buildItem(&t, tokoID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+
+	return &TrxListResponse{Data: items, Page: page, Limit: limit}, nil
+}
+
 // GetByID returns transaction details if owned by user
 func (s *Service) GetByID(trxID, userID uint) (*TrxItem, error) {
 	owner, err := s.repo.GetOwnerUserIDOfTrx(trxID)
@@ -133,6 +253,64 @@ func (s *Service) GetByID(trxID, userID uint) (*TrxItem, error) {
 	return s.buildTrxItem(trx)
 }
 
+// Rate saves the buyer's rating of one item of their transaction. Each
+// item can be rated once; the rating counts toward its toko's average.
+func (s *Service) Rate(trxID, userID uint, req UlasanRequest) (*UlasanResp, error) {
+	owner, err := s.repo.GetOwnerUserIDOfTrx(trxID)
+	if err != nil {
+		return nil, err
+	}
+	if owner == 0 {
+		return nil, errors.New("not found")
+	}
+	if owner != userID {
+		return nil, errors.New("forbidden")
+	}
+	if req.Rating < 1 || req.Rating > 5 {
+		return nil, errors.New("rating harus 1-5")
+	}
+	komentar := strings.TrimSpace(req.Komentar)
+	if len(komentar) > MaxKomentar {
+		return nil, fmt.Errorf("komentar maksimal %d char", MaxKomentar)
+	}
+	detail, err := s.repo.GetDetailItem(trxID, req.IDDetailTrx)
+	if err != nil {
+		return nil, err
+	}
+	if detail == nil {
+		return nil, errors.New("detail not found")
+	}
+	log, err := s.repo.GetLogProdukByID(detail.IDLogProduk)
+	if err != nil {
+		return nil, err
+	}
+	if log == nil {
+		return nil, errors.New("detail not found")
+	}
+
+	now := time.Now()
+	u := &trxmodel.Ulasan{
+		IDDetailTrx: detail.ID,
+		IDUser:      userID,
+		IDProduk:    log.IDProduk,
+		IDToko:      detail.IDToko,
+		Rating:      req.Rating,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if komentar != "" {
+		u.Komentar = &komentar
+	}
+	ok, err := s.repo.CreateUlasan(u)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrRated
+	}
+	return &UlasanResp{ID: u.ID, Rating: u.Rating, Komentar: u.Komentar, CreatedAt: u.CreatedAt}, nil
+}
+
 // Create creates a new transaction with validation and snapshot
 func (s *Service) Create(userID uint, req CreateRequest) (uint, error) {
 	// Validate alamat ownership
@@ -151,6 +329,8 @@ func (s *Service) Create(userID uint, req CreateRequest) (uint, error) {
 	var hargaTotal int
 	items := make([]trxmodel.DetailTrx, 0, len(req.DetailTrx))
 	logs := make([]trxmodel.LogProduk, 0, len(req.DetailTrx))
+	// flash sale item per order item (0 = normal price)
+	sales := make([]uint, 0, len(req.DetailTrx))
 
 	for _, item := range req.DetailTrx {
 		if item.Kuantitas <= 0 {
@@ -161,11 +341,37 @@ func (s *Service) Create(userID uint, req CreateRequest) (uint, error) {
 		if err1 != nil {
 			return 0, err
 		}
-		if prod == nil {
+		// unpublished products are not public, so they cannot be ordered either
+		if prod == nil || prod.Status != prodmodel.StatusPublished {
 			return 0, errors.New("product not found")
 		}
+		if prod.Toko != nil {
+			if st := tokosvc.StatusOf(prod.Toko.Operasional, time.Now()); !st.Buka {
+				return 0, &ClosedError{NamaToko: prod.Toko.NamaToko, Status: st, Zona: tokosvc.Location(prod.Toko.ZonaWaktu)}
+			}
+		}
+
+		variant, err1 := pickVariant(prod, item.VariantID)
+		if err1 != nil {
+			return 0, err1
+		}
+		// early answer for sold out variants; the locked ledger change in the
+		// transaction below is what actually guards the stock
+		if variant != nil && item.Kuantitas > variant.Stok {
+			return 0, fmt.Errorf("%w: %s %s", prodrepo.ErrInsufficientStock, prod.NamaProduk, variant.NamaVarian)
+		}
 
 		hargaSatuan, _ := strconv.Atoi(prod.HargaKonsumen)
+		if variant != nil {
+			hargaSatuan = variant.HargaKonsumen
+		}
+		hargaNormal := hargaSatuan
+		sale := prod.Sale(variant)
+		if sale != nil && sale.HargaSale < hargaSatuan {
+			hargaSatuan = sale.HargaSale
+		} else {
+			sale = nil
+		}
 		hargaItem := hargaSatuan * item.Kuantitas
 		hargaTotal += hargaItem
 
@@ -175,13 +381,18 @@ func (s *Service) Create(userID uint, req CreateRequest) (uint, error) {
 			IDToko:     prod.IDToko,
 		})
 
-		// Create product snapshot
-		photoURLs := make([]string, len(prod.Photos))
-		for i, p := range prod.Photos {
+		// Create product snapshot; variant photos take precedence when present
+		photos := prod.Photos
+		if variant != nil && len(variant.Photos) > 0 {
+			photos = variant.Photos
+		}
+		photoURLs := make([]string, len(photos))
+		for i, p := range photos {
 			photoURLs[i] = p.URL
 		}
 
-		logs = append(logs, trxmodel.LogProduk{
+		dim := prod.Dimensi(variant)
+		lp := trxmodel.LogProduk{
 			IDProduk:      prod.ID,
 			NamaProduk:    prod.NamaProduk,
 			Slug:          prod.Slug,
@@ -191,7 +402,30 @@ func (s *Service) Create(userID uint, req CreateRequest) (uint, error) {
 			IDToko:        prod.IDToko,
 			IDCategory:    prod.IDCategory,
 			PhotosJSON:    trxrepo.MarshalPhotos(photoURLs),
-		})
+			Berat:         dim.Berat,
+			Panjang:       dim.Panjang,
+			Lebar:         dim.Lebar,
+			Tinggi:        dim.Tinggi,
+		}
+		if variant != nil {
+			vid := variant.ID
+			lp.IDVarian = &vid
+			lp.SKU = variant.SKU
+			lp.NamaVarian = variant.NamaVarian
+			lp.OpsiJSON = variant.OpsiJSON
+			lp.HargaReseller = strconv.Itoa(variant.HargaReseller)
+			lp.HargaKonsumen = strconv.Itoa(variant.HargaKonsumen)
+		}
+		if sale != nil {
+			fid, normal := sale.IDFlashSale, hargaNormal
+			lp.IDFlashSale = &fid
+			lp.HargaNormal = &normal
+			lp.HargaKonsumen = strconv.Itoa(hargaSatuan)
+			sales = append(sales, sale.ID)
+		} else {
+			sales = append(sales, 0)
+		}
+		logs = append(logs, lp)
 	}
 
 	// Generate invoice code
@@ -213,6 +447,21 @@ func (s *Service) Create(userID uint, req CreateRequest) (uint, error) {
 		}
 		trxID = trx.ID
 
+		// flash sale prices hold only while the quota lasts; a sold out
+		// quota fails the whole checkout
+		for i, itemID := range sales {
+			if itemID == 0 {
+				continue
+			}
+			ok, err2 := s.repo.ReserveSaleQuota(tx, itemID, req.DetailTrx[i].Kuantitas)
+			if err2 != nil {
+				return err2
+			}
+			if !ok {
+				return ErrSaleQuota
+			}
+		}
+
 		// Create product logs first
 		for i := range logs {
 			if err2 := s.repo.CreateLogProduk(tx, &logs[i]); err2 != nil {
@@ -220,6 +469,9 @@ func (s *Service) Create(userID uint, req CreateRequest) (uint, error) {
 			}
 			items[i].IDLogProduk = logs[i].ID
 			items[i].IDTrx = trxID
+			if err2 := s.repo.RecordOrderPrice(tx, &logs[i]); err2 != nil {
+				return err2
+			}
 		}
 
 		// Create detail items
@@ -227,11 +479,11 @@ func (s *Service) Create(userID uint, req CreateRequest) (uint, error) {
 			return err
 		}
 
-		// Optional: Reduce stock
-		for _, item := range req.DetailTrx {
-			if err4 := s.repo.UpdateProductStock(tx, item.ProductID, item.Kuantitas); err4 != nil {
-				// Log warning but don't fail transaction for stock issues
-				continue
+		// Reduce stock through the stock ledger; running out of stock (or a
+		// failed ledger write) fails the whole checkout
+		for i, item := range req.DetailTrx {
+			if err4 := s.repo.UpdateProductStock(tx, item.ProductID, logs[i].IDVarian, item.Kuantitas, trxID, userID); err4 != nil {
+				return err4
 			}
 		}
 
@@ -241,8 +493,33 @@ func (s *Service) Create(userID uint, req CreateRequest) (uint, error) {
 	return trxID, err
 }
 
+// pickVariant resolves the ordered variant. Products with variants require one;
+// products without variants reject a variant_id.
+func pickVariant(prod *prodmodel.Product, variantID uint) (*prodmodel.Variant, error) {
+	if len(prod.Variants) == 0 {
+		if variantID != 0 {
+			return nil, errors.New("variant not found")
+		}
+		return nil, nil
+	}
+	if variantID == 0 {
+		return nil, errors.New("variant_id required")
+	}
+	for i := range prod.Variants {
+		if prod.Variants[i].ID == variantID {
+			return &prod.Variants[i], nil
+		}
+	}
+	return nil, errors.New("variant not found")
+}
+
 // buildTrxItem constructs response with joined data
 func (s *Service) buildTrxItem(trx *trxmodel.Trx) (*TrxItem, error) {
+	return s.buildItem(trx, 0)
+}
+
+// buildItem is buildTrxItem limited to the items of tokoID when it is not 0.
+func (s *Service) buildItem(trx *trxmodel.Trx, tokoID uint) (*TrxItem, error) {
 	// Get alamat
 	alamat, err := s.repo.GetAlamatByID(trx.AlamatPengiriman)
 	if err != nil {
@@ -263,7 +540,30 @@ func (s *Service) buildTrxItem(trx *trxmodel.Trx) (*TrxItem, error) {
 		return nil, err
 	}
 
+	hargaTotal := trx.HargaTotal
+	if tokoID != 0 {
+		own := details[:0]
+		hargaTotal = 0
+		for _, d := range details {
+			if d.IDToko == tokoID {
+				own = append(own, d)
+				hargaTotal += d.HargaTotal
+			}
+		}
+		details = own
+	}
+
+	ids := make([]uint, 0, len(details))
+	for _, d := range details {
+		ids = append(ids, d.ID)
+	}
+	ratings, err := s.repo.GetUlasanByDetails(ids)
+	if err != nil {
+		return nil, err
+	}
+
 	detailResp := make([]DetailTrxResp, 0, len(details))
+	var pakets packages
 	for _, detail := range details {
 		// Get product log
 		log, err := s.repo.GetLogProdukByID(detail.IDLogProduk)
@@ -296,6 +596,10 @@ func (s *Service) buildTrxItem(trx *trxmodel.Trx) (*TrxItem, error) {
 			Toko:          TokoResp{ID: toko.ID, NamaToko: toko.NamaToko, URLFoto: toko.UrlFoto},
 			Category:      CategoryResp{ID: cat.ID, NamaCategory: cat.NamaCategory},
 			Photos:        []PhotoResp{},
+			Berat:         log.Berat,
+			Panjang:       log.Panjang,
+			Lebar:         log.Lebar,
+			Tinggi:        log.Tinggi,
 		}
 		// Parse photos JSON into []PhotoResp
 		var urls []string
@@ -305,21 +609,71 @@ func (s *Service) buildTrxItem(trx *trxmodel.Trx) (*TrxItem, error) {
 			photos = append(photos, PhotoResp{ID: 0, ProductID: log.IDProduk, URL: u})
 		}
 		prodResp.Photos = photos
+		if log.IDVarian != nil {
+			opsi := map[string]string{}
+			_ = json.Unmarshal([]byte(log.OpsiJSON), &opsi)
+			prodResp.Variant = &VariantResp{ID: *log.IDVarian, SKU: log.SKU, Nama: log.NamaVarian, Opsi: opsi}
+		}
+		if log.IDFlashSale != nil && log.HargaNormal != nil {
+			prodResp.FlashSale = &FlashSaleResp{ID: *log.IDFlashSale, HargaNormal: *log.HargaNormal}
+		}
 
-		detailResp = append(detailResp, DetailTrxResp{
+		item := DetailTrxResp{
+			ID:         detail.ID,
 			Product:    prodResp,
 			Toko:       TokoResp{ID: toko.ID, NamaToko: toko.NamaToko, URLFoto: toko.UrlFoto},
 			Kuantitas:  detail.Kuantitas,
 			HargaTotal: detail.HargaTotal,
-		})
+		}
+		if u, ok := ratings[detail.ID]; ok {
+			item.Ulasan = &UlasanResp{ID: u.ID, Rating: u.Rating, Komentar: u.Komentar, CreatedAt: u.CreatedAt}
+		}
+		detailResp = append(detailResp, item)
+		pakets.add(toko, log, detail.Kuantitas)
 	}
 
 	return &TrxItem{
 		ID:          trx.ID,
-		HargaTotal:  trx.HargaTotal,
+		HargaTotal:  hargaTotal,
 		KodeInvoice: trx.KodeInvoice,
 		MethodBayar: trx.MethodBayar,
 		AlamatKirim: alamatResp,
 		DetailTrx:   detailResp,
+		Paket:       pakets.list(),
 	}, nil
 }
+
+// packages sums the shipping weight of order items per toko, keeping the
+// order in which the tokos first appear.
+type packages struct {
+	items  []PaketResp
+	volume []int // cm3 per toko
+}
+
+func (ps *packages) add(toko *tokomodel.Toko, log *trxmodel.LogProduk, qty int) {
+	i := 0
+	for i < len(ps.items) && ps.items[i].IDToko != toko.ID {
+		i++
+	}
+	if i == len(ps.items) {
+		ps.items = append(ps.items, PaketResp{IDToko: toko.ID, NamaToko: toko.NamaToko, AsalIDKota: toko.IDKota, AsalKota: toko.NamaKota})
+		ps.volume = append(ps.volume, 0)
+	}
+	ps.items[i].Kuantitas += qty
+	ps.items[i].Berat += log.Berat * qty
+	ps.volume[i] += log.Panjang * log.Lebar * log.Tinggi * qty
+}
+
+func (ps *packages) list() []PaketResp {
+	out := make([]PaketResp, len(ps.items))
+	for i, p := range ps.items {
+		// kg = cm3 / divisor, in grams rounded up
+		p.BeratVolumetrik = (ps.volume[i]*1000 + VolumetricDivisor - 1) / VolumetricDivisor
+		p.BeratTagihan = p.Berat
+		if p.BeratVolumetrik > p.BeratTagihan {
+			p.BeratTagihan = p.BeratVolumetrik
+		}
+		out[i] = p
+	}
+	return out
+}
@@ -50,7 +50,9 @@ func parseDateDMY(s string) (*time.Time, error) {
     return &t, nil
 }
 
-func (s *Service) UpdateProfile(userID uint, in UpdateProfileInput) error {
+// UpdateProfile saves the user's profile; changing the password signs out
+// every other session than sessionID.
+func (s *Service) UpdateProfile(userID, sessionID uint, in UpdateProfileInput) error {
     // validations
     var errs []string
     if strings.TrimSpace(in.Nama) == "" { errs = append(errs, "nama wajib diisi") }
@@ -100,7 +102,7 @@ func (s *Service) UpdateProfile(userID uint, in UpdateProfileInput) error {
         u.KataSandi = string(h)
     }
 
-    if err := s.repo.UpdateSelf(u); err != nil { return err }
+    if err := s.repo.UpdateSelf(u, sessionID); err != nil { return err }
     return nil
 }
 
@@ -0,0 +1,12 @@
+-- 0019_product_variants.down.sql
+ALTER TABLE log_produk
+  DROP COLUMN opsi_json,
+  DROP COLUMN nama_varian,
+  DROP COLUMN sku,
+  DROP COLUMN id_varian;
+
+ALTER TABLE foto_produk DROP FOREIGN KEY fk_foto_produk_varian;
+ALTER TABLE foto_produk DROP COLUMN id_varian;
+
+DROP TABLE IF EXISTS varian_produk;
+DROP TABLE IF EXISTS opsi_produk;
@@ -0,0 +1,46 @@
+-- 0019_product_variants.up.sql
+-- Opsi varian per produk (mis. Ukuran: S/M/L, Warna: Merah/Biru)
+CREATE TABLE IF NOT EXISTS opsi_produk (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_produk INT NOT NULL,
+  nama_opsi VARCHAR(100) NOT NULL,
+  nilai_json TEXT,
+  urutan INT NOT NULL DEFAULT 0,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+  CONSTRAINT fk_opsi_produk_produk
+    FOREIGN KEY (id_produk) REFERENCES produk(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Varian (SKU) dengan harga dan stok sendiri
+CREATE TABLE IF NOT EXISTS varian_produk (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_produk INT NOT NULL,
+  sku VARCHAR(100) NOT NULL,
+  nama_varian VARCHAR(255),
+  opsi_json TEXT,
+  harga_reseller INT NOT NULL DEFAULT 0,
+  harga_konsumen INT NOT NULL DEFAULT 0,
+  stok INT NOT NULL DEFAULT 0,
+  created_at DATETIME NULL,
+  updated_at DATETIME NULL,
+  UNIQUE KEY uq_varian_produk_sku (id_produk, sku),
+  CONSTRAINT fk_varian_produk_produk
+    FOREIGN KEY (id_produk) REFERENCES produk(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Foto khusus varian (NULL = foto produk umum)
+ALTER TABLE foto_produk
+  ADD COLUMN id_varian INT NULL,
+  ADD CONSTRAINT fk_foto_produk_varian
+    FOREIGN KEY (id_varian) REFERENCES varian_produk(id)
+    ON UPDATE CASCADE ON DELETE CASCADE;
+
+-- Snapshot varian yang dipilih saat transaksi
+ALTER TABLE log_produk
+  ADD COLUMN id_varian INT NULL,
+  ADD COLUMN sku VARCHAR(100) NULL,
+  ADD COLUMN nama_varian VARCHAR(255) NULL,
+  ADD COLUMN opsi_json TEXT NULL;
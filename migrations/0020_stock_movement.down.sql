@@ -0,0 +1,2 @@
+-- 0020_stock_movement.down.sql
+DROP TABLE IF EXISTS stock_movement;
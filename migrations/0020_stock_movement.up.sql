@@ -0,0 +1,28 @@
+-- 0020_stock_movement.up.sql
+-- Riwayat setiap perubahan stok produk/varian
+CREATE TABLE IF NOT EXISTS stock_movement (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_produk INT NOT NULL,
+  id_varian INT NULL,
+  tipe VARCHAR(20) NOT NULL,
+  jumlah INT NOT NULL,
+  stok_sebelum INT NOT NULL,
+  stok_sesudah INT NOT NULL,
+  alasan VARCHAR(255),
+  id_user INT NULL,
+  id_trx INT NULL,
+  created_at DATETIME NOT NULL,
+  INDEX idx_stock_movement_produk (id_produk, id),
+  CONSTRAINT fk_stock_movement_produk
+    FOREIGN KEY (id_produk) REFERENCES produk(id)
+    ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_stock_movement_varian
+    FOREIGN KEY (id_varian) REFERENCES varian_produk(id)
+    ON UPDATE CASCADE ON DELETE SET NULL,
+  CONSTRAINT fk_stock_movement_user
+    FOREIGN KEY (id_user) REFERENCES users(id)
+    ON UPDATE CASCADE ON DELETE SET NULL,
+  CONSTRAINT fk_stock_movement_trx
+    FOREIGN KEY (id_trx) REFERENCES trx(id)
+    ON UPDATE CASCADE ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,4 @@
+-- 0021_produk_soft_delete.down.sql
+ALTER TABLE produk
+  DROP INDEX idx_produk_deleted_at,
+  DROP COLUMN deleted_at;
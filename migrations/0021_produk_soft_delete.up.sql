@@ -0,0 +1,5 @@
+-- 0021_produk_soft_delete.up.sql
+-- Produk yang dihapus masuk tempat sampah dulu; dibersihkan permanen setelah masa retensi
+ALTER TABLE produk
+  ADD COLUMN deleted_at DATETIME NULL,
+  ADD INDEX idx_produk_deleted_at (deleted_at);
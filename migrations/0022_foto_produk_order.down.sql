@@ -0,0 +1,4 @@
+-- 0022_foto_produk_order.down.sql
+ALTER TABLE foto_produk
+  DROP COLUMN is_primary,
+  DROP COLUMN urutan;
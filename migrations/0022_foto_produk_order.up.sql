@@ -0,0 +1,8 @@
+-- 0022_foto_produk_order.up.sql
+-- Urutan tampil foto dan penanda foto utama (cover)
+ALTER TABLE foto_produk
+  ADD COLUMN urutan INT NOT NULL DEFAULT 0,
+  ADD COLUMN is_primary TINYINT(1) NOT NULL DEFAULT 0;
+
+-- Pertahankan urutan lama (berdasarkan id)
+UPDATE foto_produk SET urutan = id;
@@ -0,0 +1,3 @@
+-- 0023_image_sizes.down.sql
+ALTER TABLE toko DROP COLUMN foto_sizes_json;
+ALTER TABLE foto_produk DROP COLUMN sizes_json;
@@ -0,0 +1,4 @@
+-- 0023_image_sizes.up.sql
+-- URL per ukuran gambar (original/medium/thumb + webp) hasil pemrosesan upload
+ALTER TABLE foto_produk ADD COLUMN sizes_json TEXT NULL;
+ALTER TABLE toko ADD COLUMN foto_sizes_json TEXT NULL;
@@ -0,0 +1,2 @@
+-- 0024_job.down.sql
+DROP TABLE IF EXISTS job;
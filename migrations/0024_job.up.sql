@@ -0,0 +1,23 @@
+-- 0024_job.up.sql
+-- Job latar belakang (mis. import produk) beserta progres dan laporan error
+CREATE TABLE IF NOT EXISTS job (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  tipe VARCHAR(50) NOT NULL,
+  id_user INT NOT NULL,
+  id_toko INT NULL,
+  status VARCHAR(20) NOT NULL,
+  dry_run TINYINT(1) NOT NULL DEFAULT 0,
+  total INT NOT NULL DEFAULT 0,
+  processed INT NOT NULL DEFAULT 0,
+  succeeded INT NOT NULL DEFAULT 0,
+  failed INT NOT NULL DEFAULT 0,
+  error_message VARCHAR(500) NULL,
+  report_key VARCHAR(255) NULL,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+  finished_at DATETIME NULL,
+  INDEX idx_job_user (id_user, id),
+  CONSTRAINT fk_job_user
+    FOREIGN KEY (id_user) REFERENCES users(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
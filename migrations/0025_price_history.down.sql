@@ -0,0 +1,2 @@
+-- 0025_price_history.down.sql
+DROP TABLE IF EXISTS price_history;
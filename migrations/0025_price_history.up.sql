@@ -0,0 +1,41 @@
+-- 0025_price_history.up.sql
+-- Riwayat harga produk/varian; satu baris per perubahan harga
+CREATE TABLE IF NOT EXISTS price_history (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_produk INT NOT NULL,
+  id_varian INT NULL,
+  harga_reseller INT NOT NULL,
+  harga_konsumen INT NOT NULL,
+  sumber VARCHAR(20) NOT NULL,
+  id_user INT NULL,
+  created_at DATETIME NOT NULL,
+  INDEX idx_price_history_produk (id_produk, id_varian, created_at),
+  CONSTRAINT fk_price_history_produk
+    FOREIGN KEY (id_produk) REFERENCES produk(id)
+    ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_price_history_varian
+    FOREIGN KEY (id_varian) REFERENCES varian_produk(id)
+    ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_price_history_user
+    FOREIGN KEY (id_user) REFERENCES users(id)
+    ON UPDATE CASCADE ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Harga dari snapshot transaksi (log_produk) yang produknya masih ada
+INSERT INTO price_history (id_produk, id_varian, harga_reseller, harga_konsumen, sumber, created_at)
+SELECT lp.id_produk, v.id, CAST(lp.`harga reseller` AS SIGNED), CAST(lp.`harga konsumen` AS SIGNED), 'order',
+       COALESCE(lp.created_at, NOW())
+FROM log_produk lp
+JOIN produk p ON p.id = lp.id_produk
+LEFT JOIN varian_produk v ON v.id = lp.id_varian AND v.id_produk = lp.id_produk
+WHERE lp.id_varian IS NULL OR v.id IS NOT NULL
+ORDER BY lp.id;
+
+-- Harga saat ini sebagai titik awal perubahan berikutnya
+INSERT INTO price_history (id_produk, id_varian, harga_reseller, harga_konsumen, sumber, created_at)
+SELECT p.id, NULL, CAST(p.`harga reseller` AS SIGNED), CAST(p.`harga konsumen` AS SIGNED), 'snapshot', NOW()
+FROM produk p;
+
+INSERT INTO price_history (id_produk, id_varian, harga_reseller, harga_konsumen, sumber, created_at)
+SELECT v.id_produk, v.id, v.harga_reseller, v.harga_konsumen, 'snapshot', NOW()
+FROM varian_produk v;
@@ -0,0 +1,6 @@
+-- 0026_slug_history.down.sql
+DROP TABLE IF EXISTS toko_slug_lama;
+DROP TABLE IF EXISTS produk_slug_lama;
+ALTER TABLE produk DROP INDEX idx_produk_slug;
+ALTER TABLE toko DROP INDEX uq_toko_slug;
+ALTER TABLE toko DROP COLUMN slug;
@@ -0,0 +1,36 @@
+-- 0026_slug_history.up.sql
+-- Slug toko untuk URL publik
+ALTER TABLE toko ADD COLUMN slug VARCHAR(255) NULL;
+
+UPDATE toko SET slug = TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(COALESCE(nama_toko, '')), '[^a-z0-9]+', '-'));
+UPDATE toko SET slug = CONCAT('toko-', id) WHERE slug IS NULL OR slug = '';
+UPDATE toko t
+JOIN (SELECT slug, MIN(id) AS keep_id FROM toko GROUP BY slug HAVING COUNT(*) > 1) d
+  ON t.slug = d.slug AND t.id <> d.keep_id
+SET t.slug = CONCAT(t.slug, '-', t.id);
+
+ALTER TABLE toko ADD UNIQUE KEY uq_toko_slug (slug);
+ALTER TABLE produk ADD INDEX idx_produk_slug (slug);
+
+-- Slug lama setelah rename, agar link lama tetap diarahkan ke slug baru
+CREATE TABLE IF NOT EXISTS produk_slug_lama (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_produk INT NOT NULL,
+  slug VARCHAR(255) NOT NULL,
+  created_at DATETIME NOT NULL,
+  UNIQUE KEY uq_produk_slug_lama (slug),
+  CONSTRAINT fk_produk_slug_lama_produk
+    FOREIGN KEY (id_produk) REFERENCES produk(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS toko_slug_lama (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_toko INT NOT NULL,
+  slug VARCHAR(255) NOT NULL,
+  created_at DATETIME NOT NULL,
+  UNIQUE KEY uq_toko_slug_lama (slug),
+  CONSTRAINT fk_toko_slug_lama_toko
+    FOREIGN KEY (id_toko) REFERENCES toko(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
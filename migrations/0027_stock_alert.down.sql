@@ -0,0 +1,4 @@
+-- 0027_stock_alert.down.sql
+DROP TABLE IF EXISTS stock_alert;
+DROP TABLE IF EXISTS notifikasi;
+ALTER TABLE produk DROP COLUMN stok_minimum;
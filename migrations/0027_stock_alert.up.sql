@@ -0,0 +1,42 @@
+-- 0027_stock_alert.up.sql
+-- Batas stok minimum per produk; alert saat stok turun ke/di bawah batas
+ALTER TABLE produk ADD COLUMN stok_minimum INT NOT NULL DEFAULT 0;
+
+-- Notifikasi untuk user
+CREATE TABLE IF NOT EXISTS notifikasi (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_user INT NOT NULL,
+  tipe VARCHAR(30) NOT NULL,
+  judul VARCHAR(255) NOT NULL,
+  pesan TEXT,
+  data_json TEXT,
+  dibaca_at DATETIME NULL,
+  created_at DATETIME NOT NULL,
+  INDEX idx_notifikasi_user (id_user, id),
+  CONSTRAINT fk_notifikasi_user
+    FOREIGN KEY (id_user) REFERENCES users(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Alert stok rendah; satu alert terbuka per produk/varian sampai stok naik lagi
+CREATE TABLE IF NOT EXISTS stock_alert (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_toko INT NOT NULL,
+  id_produk INT NOT NULL,
+  id_varian INT NULL,
+  stok INT NOT NULL,
+  batas INT NOT NULL,
+  created_at DATETIME NOT NULL,
+  resolved_at DATETIME NULL,
+  INDEX idx_stock_alert_toko (id_toko, id),
+  INDEX idx_stock_alert_produk (id_produk, id_varian, resolved_at),
+  CONSTRAINT fk_stock_alert_toko
+    FOREIGN KEY (id_toko) REFERENCES toko(id)
+    ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_stock_alert_produk
+    FOREIGN KEY (id_produk) REFERENCES produk(id)
+    ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_stock_alert_varian
+    FOREIGN KEY (id_varian) REFERENCES varian_produk(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
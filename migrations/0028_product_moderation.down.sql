@@ -0,0 +1,7 @@
+-- 0028_product_moderation.down.sql
+ALTER TABLE produk
+  DROP INDEX idx_produk_status,
+  DROP COLUMN reviewed_at,
+  DROP COLUMN reviewed_by,
+  DROP COLUMN alasan_penolakan,
+  DROP COLUMN status;
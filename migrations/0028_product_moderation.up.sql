@@ -0,0 +1,12 @@
+-- 0028_product_moderation.up.sql
+-- Status moderasi produk: draft, pending_review, published, rejected.
+-- Produk yang sudah ada dianggap sudah tayang (published).
+ALTER TABLE produk
+  ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'published',
+  ADD COLUMN alasan_penolakan VARCHAR(500) NULL,
+  ADD COLUMN reviewed_by INT NULL,
+  ADD COLUMN reviewed_at DATETIME NULL,
+  ADD INDEX idx_produk_status (status, id);
+
+-- Produk baru harus melalui review
+ALTER TABLE produk ALTER COLUMN status SET DEFAULT 'draft';
@@ -0,0 +1,3 @@
+-- 0029_product_attributes.down.sql
+DROP TABLE IF EXISTS atribut_produk;
+DROP TABLE IF EXISTS atribut_category;
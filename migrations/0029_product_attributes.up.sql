@@ -0,0 +1,35 @@
+-- 0029_product_attributes.up.sql
+-- Skema atribut per kategori (diatur admin): text, number, enum, boolean
+CREATE TABLE IF NOT EXISTS atribut_category (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_category INT NOT NULL,
+  kode VARCHAR(50) NOT NULL,
+  nama VARCHAR(100) NOT NULL,
+  tipe VARCHAR(20) NOT NULL,
+  nilai_json TEXT,
+  wajib TINYINT(1) NOT NULL DEFAULT 0,
+  urutan INT NOT NULL DEFAULT 0,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+  UNIQUE KEY uq_atribut_category_kode (id_category, kode),
+  CONSTRAINT fk_atribut_category
+    FOREIGN KEY (id_category) REFERENCES category(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Nilai atribut produk; ikut terhapus saat atribut dihapus dari skema
+CREATE TABLE IF NOT EXISTS atribut_produk (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_produk INT NOT NULL,
+  id_atribut INT NOT NULL,
+  nilai VARCHAR(255) NOT NULL,
+  created_at DATETIME NOT NULL,
+  UNIQUE KEY uq_atribut_produk (id_produk, id_atribut),
+  INDEX idx_atribut_produk_nilai (id_atribut, nilai),
+  CONSTRAINT fk_atribut_produk_produk
+    FOREIGN KEY (id_produk) REFERENCES produk(id)
+    ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_atribut_produk_atribut
+    FOREIGN KEY (id_atribut) REFERENCES atribut_category(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,18 @@
+-- 0030_product_dimensions.down.sql
+ALTER TABLE log_produk
+  DROP COLUMN berat,
+  DROP COLUMN panjang,
+  DROP COLUMN lebar,
+  DROP COLUMN tinggi;
+
+ALTER TABLE varian_produk
+  DROP COLUMN berat,
+  DROP COLUMN panjang,
+  DROP COLUMN lebar,
+  DROP COLUMN tinggi;
+
+ALTER TABLE produk
+  DROP COLUMN berat,
+  DROP COLUMN panjang,
+  DROP COLUMN lebar,
+  DROP COLUMN tinggi;
@@ -0,0 +1,21 @@
+-- 0030_product_dimensions.up.sql
+-- Berat (gram) dan dimensi kemasan (cm) untuk ongkos kirim. Produk lama
+-- bernilai 0 sampai penjual mengisinya; varian NULL = ikut produk.
+ALTER TABLE produk
+  ADD COLUMN berat INT NOT NULL DEFAULT 0,
+  ADD COLUMN panjang INT NOT NULL DEFAULT 0,
+  ADD COLUMN lebar INT NOT NULL DEFAULT 0,
+  ADD COLUMN tinggi INT NOT NULL DEFAULT 0;
+
+ALTER TABLE varian_produk
+  ADD COLUMN berat INT NULL,
+  ADD COLUMN panjang INT NULL,
+  ADD COLUMN lebar INT NULL,
+  ADD COLUMN tinggi INT NULL;
+
+-- Snapshot berat/dimensi efektif (varian atau produk) saat transaksi
+ALTER TABLE log_produk
+  ADD COLUMN berat INT NOT NULL DEFAULT 0,
+  ADD COLUMN panjang INT NOT NULL DEFAULT 0,
+  ADD COLUMN lebar INT NOT NULL DEFAULT 0,
+  ADD COLUMN tinggi INT NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+-- 0031_flash_sale.down.sql
+ALTER TABLE log_produk
+  DROP COLUMN id_flash_sale,
+  DROP COLUMN harga_normal;
+DROP TABLE IF EXISTS flash_sale_item;
+DROP TABLE IF EXISTS flash_sale;
@@ -0,0 +1,40 @@
+-- 0031_flash_sale.up.sql
+-- Kampanye flash sale: harga sale produk/varian dalam jendela waktu dengan
+-- kuota per item. terjual dinaikkan secara kondisional saat checkout.
+CREATE TABLE IF NOT EXISTS flash_sale (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  nama VARCHAR(100) NOT NULL,
+  mulai DATETIME NOT NULL,
+  selesai DATETIME NOT NULL,
+  created_by INT NULL,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+  INDEX idx_flash_sale_waktu (mulai, selesai)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS flash_sale_item (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_flash_sale INT NOT NULL,
+  id_produk INT NOT NULL,
+  id_varian INT NULL,
+  harga_sale INT NOT NULL,
+  kuota INT NOT NULL,
+  terjual INT NOT NULL DEFAULT 0,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+  INDEX idx_flash_sale_item_produk (id_produk, id_varian),
+  CONSTRAINT fk_flash_sale_item_campaign
+    FOREIGN KEY (id_flash_sale) REFERENCES flash_sale(id)
+    ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_flash_sale_item_produk
+    FOREIGN KEY (id_produk) REFERENCES produk(id)
+    ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_flash_sale_item_varian
+    FOREIGN KEY (id_varian) REFERENCES varian_produk(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Snapshot transaksi: kampanye yang dipakai dan harga normal saat itu
+ALTER TABLE log_produk
+  ADD COLUMN id_flash_sale INT NULL,
+  ADD COLUMN harga_normal INT NULL;
@@ -0,0 +1,2 @@
+-- 0032_store_ratings.down.sql
+DROP TABLE IF EXISTS ulasan;
@@ -0,0 +1,23 @@
+-- 0032_store_ratings.up.sql
+-- Ulasan pembeli: satu rating (1-5) per item transaksi. id_produk dan
+-- id_toko disalin dari snapshot agar rata-rata toko/produk cukup satu query.
+CREATE TABLE IF NOT EXISTS ulasan (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_detail_trx INT NOT NULL,
+  id_user INT NOT NULL,
+  id_produk INT NOT NULL,
+  id_toko INT NOT NULL,
+  rating TINYINT NOT NULL,
+  komentar VARCHAR(1000) NULL,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+  UNIQUE KEY uq_ulasan_detail_trx (id_detail_trx),
+  INDEX idx_ulasan_toko (id_toko),
+  INDEX idx_ulasan_produk (id_produk),
+  CONSTRAINT fk_ulasan_detail_trx
+    FOREIGN KEY (id_detail_trx) REFERENCES detail_trx(id)
+    ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_ulasan_toko
+    FOREIGN KEY (id_toko) REFERENCES toko(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
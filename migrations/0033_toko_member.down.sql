@@ -0,0 +1,3 @@
+-- 0033_toko_member.down.sql
+DROP TABLE IF EXISTS toko_undangan;
+DROP TABLE IF EXISTS toko_member;
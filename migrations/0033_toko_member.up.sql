@@ -0,0 +1,37 @@
+-- 0033_toko_member.up.sql
+-- Anggota toko selain pemilik (toko.id_user) dengan role, dan undangan
+-- yang dikirim ke email atau no telp calon anggota.
+CREATE TABLE IF NOT EXISTS toko_member (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_toko INT NOT NULL,
+  id_user INT NOT NULL,
+  role VARCHAR(20) NOT NULL,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+  UNIQUE KEY uq_toko_member (id_toko, id_user),
+  INDEX idx_toko_member_user (id_user),
+  CONSTRAINT fk_toko_member_toko
+    FOREIGN KEY (id_toko) REFERENCES toko(id)
+    ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_toko_member_user
+    FOREIGN KEY (id_user) REFERENCES users(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS toko_undangan (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_toko INT NOT NULL,
+  kontak VARCHAR(255) NOT NULL,
+  role VARCHAR(20) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending',
+  invited_by INT NULL,
+  id_user INT NULL,
+  expires_at DATETIME NOT NULL,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+  INDEX idx_toko_undangan_kontak (kontak, status),
+  INDEX idx_toko_undangan_toko (id_toko, status),
+  CONSTRAINT fk_toko_undangan_toko
+    FOREIGN KEY (id_toko) REFERENCES toko(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,6 @@
+-- 0034_toko_operasional.down.sql
+ALTER TABLE toko DROP COLUMN pesan_libur;
+ALTER TABLE toko DROP COLUMN libur_sampai;
+ALTER TABLE toko DROP COLUMN libur;
+ALTER TABLE toko DROP COLUMN zona_waktu;
+ALTER TABLE toko DROP COLUMN jam_operasional;
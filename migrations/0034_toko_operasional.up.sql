@@ -0,0 +1,8 @@
+-- 0034_toko_operasional.up.sql
+-- Jam operasional mingguan (JSON, kosong = selalu buka) dan mode libur toko.
+-- libur_sampai NULL = libur sampai dinonaktifkan manual.
+ALTER TABLE toko ADD COLUMN jam_operasional TEXT NULL;
+ALTER TABLE toko ADD COLUMN zona_waktu VARCHAR(4) NOT NULL DEFAULT 'WIB';
+ALTER TABLE toko ADD COLUMN libur TINYINT(1) NOT NULL DEFAULT 0;
+ALTER TABLE toko ADD COLUMN libur_sampai DATETIME NULL;
+ALTER TABLE toko ADD COLUMN pesan_libur VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+-- 0035_toko_alamat_asal.down.sql
+ALTER TABLE toko DROP COLUMN asal_kode_pos;
+ALTER TABLE toko DROP COLUMN asal_detail_alamat;
+ALTER TABLE toko DROP COLUMN asal_nama_kota;
+ALTER TABLE toko DROP COLUMN asal_id_kota;
+ALTER TABLE toko DROP COLUMN asal_nama_provinsi;
+ALTER TABLE toko DROP COLUMN asal_id_provinsi;
@@ -0,0 +1,9 @@
+-- 0035_toko_alamat_asal.up.sql
+-- Alamat asal/pickup toko untuk ongkir dan label "dikirim dari". Nama
+-- provinsi/kota disimpan saat alamat diatur agar tidak perlu memanggil EMSIFA.
+ALTER TABLE toko ADD COLUMN asal_id_provinsi VARCHAR(255) NULL;
+ALTER TABLE toko ADD COLUMN asal_nama_provinsi VARCHAR(255) NULL;
+ALTER TABLE toko ADD COLUMN asal_id_kota VARCHAR(255) NULL;
+ALTER TABLE toko ADD COLUMN asal_nama_kota VARCHAR(255) NULL;
+ALTER TABLE toko ADD COLUMN asal_detail_alamat VARCHAR(255) NULL;
+ALTER TABLE toko ADD COLUMN asal_kode_pos VARCHAR(10) NULL;
@@ -0,0 +1,2 @@
+-- 0036_toko_follower.down.sql
+DROP TABLE IF EXISTS toko_follower;
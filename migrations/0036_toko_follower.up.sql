@@ -0,0 +1,17 @@
+-- 0036_toko_follower.up.sql
+-- Pengguna yang mengikuti toko; produk baru dari toko yang diikuti tampil
+-- di GET /user/feed.
+CREATE TABLE IF NOT EXISTS toko_follower (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_toko INT NOT NULL,
+  id_user INT NOT NULL,
+  created_at DATETIME NOT NULL,
+  UNIQUE KEY uq_toko_follower (id_toko, id_user),
+  INDEX idx_toko_follower_user (id_user),
+  CONSTRAINT fk_toko_follower_toko
+    FOREIGN KEY (id_toko) REFERENCES toko(id)
+    ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT fk_toko_follower_user
+    FOREIGN KEY (id_user) REFERENCES users(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,4 @@
+-- 0037_toko_verifikasi.down.sql
+DROP TABLE IF EXISTS toko_verifikasi;
+ALTER TABLE toko DROP COLUMN verified_at;
+ALTER TABLE toko DROP COLUMN verified;
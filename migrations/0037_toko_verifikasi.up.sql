@@ -0,0 +1,24 @@
+-- 0037_toko_verifikasi.up.sql
+-- Badge toko terverifikasi dan pengajuan verifikasi yang direview admin.
+-- dokumen_json berisi daftar dokumen yang disimpan sebagai file private.
+ALTER TABLE toko ADD COLUMN verified TINYINT(1) NOT NULL DEFAULT 0;
+ALTER TABLE toko ADD COLUMN verified_at DATETIME NULL;
+
+CREATE TABLE IF NOT EXISTS toko_verifikasi (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_toko INT NOT NULL,
+  id_user INT NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending',
+  dokumen_json TEXT NOT NULL,
+  catatan VARCHAR(500) NOT NULL DEFAULT '',
+  alasan VARCHAR(500) NOT NULL DEFAULT '',
+  reviewed_by INT NULL,
+  reviewed_at DATETIME NULL,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+  INDEX idx_toko_verifikasi_status (status, id),
+  INDEX idx_toko_verifikasi_toko (id_toko, id),
+  CONSTRAINT fk_toko_verifikasi_toko
+    FOREIGN KEY (id_toko) REFERENCES toko(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,3 @@
+-- 0038_sesi_login.down.sql
+DROP TABLE IF EXISTS refresh_token;
+DROP TABLE IF EXISTS sesi_login;
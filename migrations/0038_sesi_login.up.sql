@@ -0,0 +1,33 @@
+-- 0038_sesi_login.up.sql
+-- Sesi login server-side. Access token JWT berumur pendek membawa id sesi
+-- (claim "sid"); sesi diperpanjang lewat refresh token yang dirotasi setiap
+-- dipakai. Refresh token hanya disimpan sebagai hash SHA-256.
+CREATE TABLE IF NOT EXISTS sesi_login (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_user INT NOT NULL,
+  user_agent VARCHAR(255) NOT NULL DEFAULT '',
+  ip VARCHAR(45) NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL,
+  last_active_at DATETIME NOT NULL,
+  expires_at DATETIME NOT NULL,
+  revoked_at DATETIME NULL,
+  revoke_reason VARCHAR(20) NULL,
+  INDEX idx_sesi_login_user (id_user, revoked_at),
+  CONSTRAINT fk_sesi_login_user
+    FOREIGN KEY (id_user) REFERENCES users(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS refresh_token (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_sesi INT NOT NULL,
+  token_hash CHAR(64) NOT NULL,
+  expires_at DATETIME NOT NULL,
+  used_at DATETIME NULL,
+  created_at DATETIME NOT NULL,
+  UNIQUE KEY uq_refresh_token_hash (token_hash),
+  INDEX idx_refresh_token_sesi (id_sesi),
+  CONSTRAINT fk_refresh_token_sesi
+    FOREIGN KEY (id_sesi) REFERENCES sesi_login(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
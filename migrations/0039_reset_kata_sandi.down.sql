@@ -0,0 +1,2 @@
+-- 0039_reset_kata_sandi.down.sql
+DROP TABLE IF EXISTS reset_kata_sandi;
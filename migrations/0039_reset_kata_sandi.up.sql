@@ -0,0 +1,17 @@
+-- 0039_reset_kata_sandi.up.sql
+-- Kode OTP lupa kata sandi. Kode hanya disimpan sebagai hash bcrypt, berlaku
+-- singkat, dibatasi jumlah percobaannya dan hanya bisa dipakai sekali.
+CREATE TABLE IF NOT EXISTS reset_kata_sandi (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  id_user INT NOT NULL,
+  kode_hash VARCHAR(255) NOT NULL,
+  kanal VARCHAR(10) NOT NULL,
+  percobaan INT NOT NULL DEFAULT 0,
+  expires_at DATETIME NOT NULL,
+  used_at DATETIME NULL,
+  created_at DATETIME NOT NULL,
+  INDEX idx_reset_kata_sandi_user (id_user, id),
+  CONSTRAINT fk_reset_kata_sandi_user
+    FOREIGN KEY (id_user) REFERENCES users(id)
+    ON UPDATE CASCADE ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
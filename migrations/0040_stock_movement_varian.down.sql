@@ -0,0 +1,3 @@
+-- 0040_stock_movement_varian.down.sql
+ALTER TABLE stock_movement DROP COLUMN nama_varian;
+ALTER TABLE stock_movement DROP COLUMN sku;
@@ -0,0 +1,9 @@
+-- 0040_stock_movement_varian.up.sql
+-- SKU dan nama varian disalin ke riwayat stok agar tetap terbaca setelah
+-- varian dihapus (id_varian menjadi NULL).
+ALTER TABLE stock_movement ADD COLUMN sku VARCHAR(100) NULL;
+ALTER TABLE stock_movement ADD COLUMN nama_varian VARCHAR(255) NULL;
+
+UPDATE stock_movement m
+JOIN varian_produk v ON v.id = m.id_varian
+SET m.sku = v.sku, m.nama_varian = v.nama_varian;